@@ -0,0 +1,24 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	app "github.com/ecintiawan/loan-service/internal/app/worker"
+)
+
+func main() {
+	// Load the desired time zone
+	location, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		log.Fatalf("failed to load location: %v", err)
+	}
+
+	// Set the default time zone
+	time.Local = location
+
+	// init worker binary
+	worker := app.InitWorker()
+
+	worker.Start()
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Borrower is a handler for http request related to Borrower
+type Borrower struct {
+	service service.Borrower
+}
+
+// NewBorrower returns new Borrower handler.
+func NewBorrower(service service.Borrower) *Borrower {
+	return &Borrower{
+		service: service,
+	}
+}
+
+// HandleGet handles the http request process of getting borrower
+func (b *Borrower) HandleGet(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.Borrower
+		err    error
+	)
+
+	filter := transformToBorrowerFilter(c)
+	result, err = b.service.Get(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleGetDetail handles the http request process of getting borrower detail
+func (b *Borrower) HandleGetDetail(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result *entity.Borrower
+		err    error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = b.service.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleCreate handles the http request process of registering borrower
+func (b *Borrower) HandleCreate(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.Borrower{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("error binding request", errorwrapper.CodeInvalid)
+	}
+
+	err = b.service.Create(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.Created(c, constant.MessageSuccessCreate, model)
+}
+
+// HandleUpdate handles the http request process of updating borrower
+func (b *Borrower) HandleUpdate(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.Borrower{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("error binding request", errorwrapper.CodeInvalid)
+	}
+	model.ID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+
+	err = b.service.Update(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessUpdate, nil)
+}
+
+// HandleActivate handles the http request process of activating borrower
+func (b *Borrower) HandleActivate(c echo.Context) error {
+	return b.updateStatus(c, constant.GeneralStatusActive)
+}
+
+// HandleDeactivate handles the http request process of deactivating borrower
+func (b *Borrower) HandleDeactivate(c echo.Context) error {
+	return b.updateStatus(c, constant.GeneralStatusInactive)
+}
+
+func (b *Borrower) updateStatus(c echo.Context, status int) error {
+	var (
+		ctx = c.Request().Context()
+
+		err error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	err = b.service.UpdateStatus(ctx, id, status)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessUpdate, nil)
+}
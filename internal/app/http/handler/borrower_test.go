@@ -0,0 +1,515 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBorrower(t *testing.T) {
+	type args struct {
+		service service.Borrower
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Borrower
+	}{
+		{
+			name: "success",
+			want: &Borrower{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewBorrower(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBorrower() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrower_HandleGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Borrower
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.BorrowerFilter{}).
+						Return([]*entity.Borrower{
+							{
+								ID:                   1,
+								IdentificationNumber: "1234567890123451",
+								Name:                 "Borrower A",
+								Status:               constant.GeneralStatusActive,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":[{\"id\":1,\"identification_number\":\"1234567890123451\",\"name\":\"Borrower A\",\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Borrower{
+				service: tt.fields.service,
+			}
+			if err := b.HandleGet(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Borrower.HandleGet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Borrower.HandleGet() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrower_HandleGetDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Borrower
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Borrower{
+							ID:                   1,
+							IdentificationNumber: "1234567890123451",
+							Name:                 "Borrower A",
+							Status:               constant.GeneralStatusActive,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":{\"id\":1,\"identification_number\":\"1234567890123451\",\"name\":\"Borrower A\",\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Borrower{
+				service: tt.fields.service,
+			}
+			if err := b.HandleGetDetail(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Borrower.HandleGetDetail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Borrower.HandleGetDetail() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrower_HandleCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Borrower
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, model *entity.Borrower) error {
+							model.ID = 5
+							model.Status = constant.GeneralStatusActive
+							return nil
+						})
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":{\"id\":5,\"identification_number\":\"\",\"name\":\"\",\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"},\"message\":\"Success create data\",\"status\":\"Created\"}\n",
+		},
+		{
+			name:   "error on bind",
+			fields: fields{},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Borrower{
+				service: tt.fields.service,
+			}
+			if err := b.HandleCreate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Borrower.HandleCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Borrower.HandleCreate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrower_HandleUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Borrower
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Borrower{ID: 1}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name:   "error on bind",
+			fields: fields{},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Borrower{
+				service: tt.fields.service,
+			}
+			if err := b.HandleUpdate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Borrower.HandleUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Borrower.HandleUpdate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrower_HandleActivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Borrower
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), int64(1), constant.GeneralStatusActive).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Borrower{
+				service: tt.fields.service,
+			}
+			if err := b.HandleActivate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Borrower.HandleActivate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Borrower.HandleActivate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrower_HandleDeactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Borrower
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), int64(1), constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockBorrower {
+					mock := service.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Borrower{
+				service: tt.fields.service,
+			}
+			if err := b.HandleDeactivate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Borrower.HandleDeactivate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Borrower.HandleDeactivate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ type (
 		echo.Context
 
 		mockParam      func() string
+		mockFormValue  func(name string) string
 		mockBind       func(i interface{}) error
 		mockAttachment func() error
 	}
@@ -42,6 +43,12 @@ func (m *mockEchoContext) Param(name string) string {
 	}
 	return ""
 }
+func (m *mockEchoContext) FormValue(name string) string {
+	if m.mockFormValue != nil {
+		return m.mockFormValue(name)
+	}
+	return m.Context.FormValue(name)
+}
 func (m *mockEchoContext) Bind(i interface{}) error {
 	if m.mockBind != nil {
 		return m.mockBind(i)
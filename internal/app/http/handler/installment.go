@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Installment is a handler for http request related to Installment
+type Installment struct {
+	service service.Installment
+}
+
+// NewInstallment returns new Installment handler.
+func NewInstallment(service service.Installment) *Installment {
+	return &Installment{
+		service: service,
+	}
+}
+
+// HandleGetSchedule handles the http request process of getting loan repayment schedule
+func (i *Installment) HandleGetSchedule(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.Installment
+		err    error
+	)
+
+	loanID, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = i.service.GetSchedule(ctx, loanID)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
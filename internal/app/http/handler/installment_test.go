@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInstallment(t *testing.T) {
+	type args struct {
+		service service.Installment
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Installment
+	}{
+		{
+			name: "success",
+			want: &Installment{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewInstallment(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewInstallment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstallment_HandleGetSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Installment
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						GetSchedule(gomock.Any(), int64(4)).
+						Return([]*entity.Installment{
+							{
+								ID:     1,
+								LoanID: 4,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "4"
+					},
+				}),
+			},
+			want: "{\"data\":[{\"id\":1,\"loan_id\":4,\"sequence\":0,\"due_date\":\"0001-01-01T00:00:00Z\",\"principal\":0,\"interest\":0,\"penalty\":0,\"amount\":0,\"outstanding\":0,\"principal_paid\":0,\"interest_paid\":0,\"penalty_paid\":0,\"status\":0,\"paid_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on get schedule",
+			fields: fields{
+				service: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						GetSchedule(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Installment{
+				service: tt.fields.service,
+			}
+			if err := i.HandleGetSchedule(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Installment.HandleGetSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Installment.HandleGetSchedule() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
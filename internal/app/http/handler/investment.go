@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"strconv"
+
 	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/service"
@@ -60,3 +62,38 @@ func (l *Investment) HandleInvest(c echo.Context) error {
 
 	return responsewrapper.Created(c, constant.MessageSuccessCreate, nil)
 }
+
+// HandleCancel handles the http request process of cancelling investment
+func (l *Investment) HandleCancel(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		err error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	err = l.service.Cancel(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessDelete, nil)
+}
+
+// HandleGetPortfolio handles the http request process of getting investor portfolio summary
+func (l *Investment) HandleGetPortfolio(c echo.Context) error {
+	var (
+		ctx    = c.Request().Context()
+		filter = transformToPortfolioFilter(c)
+
+		result *entity.Portfolio
+		err    error
+	)
+
+	result, err = l.service.GetPortfolio(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
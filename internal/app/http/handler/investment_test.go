@@ -4,8 +4,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -75,7 +77,7 @@ func TestInvestment_HandleGet(t *testing.T) {
 			args: args{
 				c: newMockEchoContext(&mockEchoContext{}),
 			},
-			want: "{\"data\":{\"List\":[{\"id\":1,\"investor_id\":0,\"loan_id\":0,\"amount\":0,\"roi\":0,\"status\":0,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"count\":1,\"row\":0,\"page\":0},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+			want: "{\"data\":{\"List\":[{\"id\":1,\"investor_id\":0,\"loan_id\":0,\"currency\":\"\",\"amount\":0,\"roi\":0,\"expected_return\":0,\"service_fee\":0,\"tax\":0,\"status\":0,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"count\":1,\"row\":0,\"page\":0},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
 		},
 		{
 			name: "error on get",
@@ -182,3 +184,174 @@ func TestInvestment_HandleInvest(t *testing.T) {
 		})
 	}
 }
+
+func TestInvestment_HandleCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investment
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestment {
+					mock := service.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Cancel(gomock.Any(), int64(1)).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success delete data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on cancel",
+			fields: fields{
+				service: func() *service.MockInvestment {
+					mock := service.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Cancel(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Investment{
+				service: tt.fields.service,
+			}
+			if err := l.HandleCancel(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investment.HandleCancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investment.HandleCancel() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestment_HandleGetPortfolio(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investment
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestment {
+					mock := service.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetPortfolio(gomock.Any(), &entity.PortfolioFilter{
+							InvestorID: 1,
+						}).
+						Return(&entity.Portfolio{
+							InvestorID:      1,
+							Currency:        currency.IDR,
+							InvestmentCount: 1,
+							TotalInvested:   currency.FromMajor(1000000),
+							Outstanding:     currency.FromMajor(1000000),
+							Exposures: []*entity.PortfolioExposure{
+								{
+									LoanStatus:      constant.StatusDisbursed,
+									InvestmentCount: 1,
+									Outstanding:     currency.FromMajor(1000000),
+								},
+							},
+							Concentrations: []*entity.PortfolioConcentration{
+								{
+									BorrowerID:      4,
+									InvestmentCount: 1,
+									Outstanding:     currency.FromMajor(1000000),
+									Share:           100,
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":{\"investor_id\":1,\"currency\":\"IDR\",\"investment_count\":1,\"total_invested\":1000000,\"outstanding\":1000000,\"expected_return\":0,\"expected_net_return\":0,\"realized_principal\":0,\"realized_return\":0,\"realized_net_return\":0,\"exposures\":[{\"loan_status\":4,\"investment_count\":1,\"outstanding\":1000000}],\"concentrations\":[{\"borrower_id\":4,\"investment_count\":1,\"outstanding\":1000000,\"share\":100}]},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockInvestment {
+					mock := service.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetPortfolio(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Investment{
+				service: tt.fields.service,
+			}
+			if err := l.HandleGetPortfolio(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investment.HandleGetPortfolio() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investment.HandleGetPortfolio() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
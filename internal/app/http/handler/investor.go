@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Investor is a handler for http request related to Investor
+type Investor struct {
+	service service.Investor
+}
+
+// NewInvestor returns new Investor handler.
+func NewInvestor(service service.Investor) *Investor {
+	return &Investor{
+		service: service,
+	}
+}
+
+// HandleGet handles the http request process of getting investor
+func (i *Investor) HandleGet(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.Investor
+		err    error
+	)
+
+	filter := transformToInvestorFilter(c)
+	result, err = i.service.Get(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleGetDetail handles the http request process of getting investor detail
+func (i *Investor) HandleGetDetail(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result *entity.Investor
+		err    error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = i.service.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleCreate handles the http request process of registering investor
+func (i *Investor) HandleCreate(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.Investor{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("error binding request", errorwrapper.CodeInvalid)
+	}
+
+	err = i.service.Create(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.Created(c, constant.MessageSuccessCreate, model)
+}
+
+// HandleUpdate handles the http request process of updating investor
+func (i *Investor) HandleUpdate(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.Investor{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("error binding request", errorwrapper.CodeInvalid)
+	}
+	model.ID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+
+	err = i.service.Update(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessUpdate, nil)
+}
+
+// HandleActivate handles the http request process of activating investor
+func (i *Investor) HandleActivate(c echo.Context) error {
+	return i.updateStatus(c, constant.GeneralStatusActive)
+}
+
+// HandleDeactivate handles the http request process of deactivating investor
+func (i *Investor) HandleDeactivate(c echo.Context) error {
+	return i.updateStatus(c, constant.GeneralStatusInactive)
+}
+
+func (i *Investor) updateStatus(c echo.Context, status int) error {
+	var (
+		ctx = c.Request().Context()
+
+		err error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	err = i.service.UpdateStatus(ctx, id, status)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessUpdate, nil)
+}
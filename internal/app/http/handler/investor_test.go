@@ -0,0 +1,517 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInvestor(t *testing.T) {
+	type args struct {
+		service service.Investor
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Investor
+	}{
+		{
+			name: "success",
+			want: &Investor{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewInvestor(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewInvestor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestor_HandleGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investor
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InvestorFilter{}).
+						Return([]*entity.Investor{
+							{
+								ID:                   1,
+								IdentificationNumber: "1234567890123454",
+								Name:                 "Investor A",
+								Email:                "investor.a@example.com",
+								Status:               constant.GeneralStatusActive,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":[{\"id\":1,\"identification_number\":\"1234567890123454\",\"name\":\"Investor A\",\"email\":\"investor.a@example.com\",\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Investor{
+				service: tt.fields.service,
+			}
+			if err := i.HandleGet(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investor.HandleGet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investor.HandleGet() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestor_HandleGetDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investor
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Investor{
+							ID:                   1,
+							IdentificationNumber: "1234567890123454",
+							Name:                 "Investor A",
+							Email:                "investor.a@example.com",
+							Status:               constant.GeneralStatusActive,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":{\"id\":1,\"identification_number\":\"1234567890123454\",\"name\":\"Investor A\",\"email\":\"investor.a@example.com\",\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Investor{
+				service: tt.fields.service,
+			}
+			if err := i.HandleGetDetail(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investor.HandleGetDetail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investor.HandleGetDetail() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestor_HandleCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investor
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, model *entity.Investor) error {
+							model.ID = 5
+							model.Status = constant.GeneralStatusActive
+							return nil
+						})
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":{\"id\":5,\"identification_number\":\"\",\"name\":\"\",\"email\":\"\",\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"},\"message\":\"Success create data\",\"status\":\"Created\"}\n",
+		},
+		{
+			name:   "error on bind",
+			fields: fields{},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Investor{
+				service: tt.fields.service,
+			}
+			if err := i.HandleCreate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investor.HandleCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investor.HandleCreate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestor_HandleUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investor
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Investor{ID: 1}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name:   "error on bind",
+			fields: fields{},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Investor{
+				service: tt.fields.service,
+			}
+			if err := i.HandleUpdate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investor.HandleUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investor.HandleUpdate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestor_HandleActivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investor
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), int64(1), constant.GeneralStatusActive).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Investor{
+				service: tt.fields.service,
+			}
+			if err := i.HandleActivate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investor.HandleActivate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investor.HandleActivate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestor_HandleDeactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Investor
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), int64(1), constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockInvestor {
+					mock := service.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Investor{
+				service: tt.fields.service,
+			}
+			if err := i.HandleDeactivate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Investor.HandleDeactivate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Investor.HandleDeactivate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Ledger is a handler for http request related to Ledger
+type Ledger struct {
+	service service.Ledger
+}
+
+// NewLedger returns new Ledger handler.
+func NewLedger(service service.Ledger) *Ledger {
+	return &Ledger{
+		service: service,
+	}
+}
+
+// HandleGetTrialBalance handles the http request process of getting ledger trial balance
+func (l *Ledger) HandleGetTrialBalance(c echo.Context) error {
+	var (
+		ctx    = c.Request().Context()
+		filter = transformToTrialBalanceFilter(c)
+
+		result *entity.TrialBalance
+		err    error
+	)
+
+	result, err = l.service.GetTrialBalance(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
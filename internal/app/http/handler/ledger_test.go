@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLedger(t *testing.T) {
+	type args struct {
+		service service.Ledger
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Ledger
+	}{
+		{
+			name: "success",
+			want: &Ledger{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLedger(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewLedger() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLedger_HandleGetTrialBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Ledger
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						GetTrialBalance(gomock.Any(), &entity.TrialBalanceFilter{}).
+						Return(&entity.TrialBalance{
+							Currency: currency.IDR,
+							AsOf:     time.Date(2024, 8, 17, 13, 58, 0, 0, time.UTC),
+							Accounts: []*entity.TrialBalanceAccount{
+								{
+									Code:    constant.LedgerAccountBank,
+									Name:    "Bank",
+									Type:    constant.LedgerAccountTypeAsset,
+									Debit:   currency.FromMajor(1000),
+									Balance: currency.FromMajor(1000),
+								},
+								{
+									Code:    constant.LedgerAccountInvestorWallet,
+									Name:    "Investor Wallet",
+									Type:    constant.LedgerAccountTypeLiability,
+									Credit:  currency.FromMajor(1000),
+									Balance: currency.FromMajor(1000),
+								},
+							},
+							TotalDebit:  currency.FromMajor(1000),
+							TotalCredit: currency.FromMajor(1000),
+							IsBalanced:  true,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":{\"currency\":\"IDR\",\"as_of\":\"2024-08-17T13:58:00Z\",\"accounts\":[{\"code\":\"bank\",\"name\":\"Bank\",\"type\":1,\"debit\":1000,\"credit\":0,\"balance\":1000},{\"code\":\"investor_wallet\",\"name\":\"Investor Wallet\",\"type\":2,\"debit\":0,\"credit\":1000,\"balance\":1000}],\"total_debit\":1000,\"total_credit\":1000,\"is_balanced\":true},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						GetTrialBalance(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Ledger{
+				service: tt.fields.service,
+			}
+			if err := l.HandleGetTrialBalance(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Ledger.HandleGetTrialBalance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Ledger.HandleGetTrialBalance() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
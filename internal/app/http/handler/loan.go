@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/service"
@@ -61,6 +64,29 @@ func (l *Loan) HandleCreate(c echo.Context) error {
 	return responsewrapper.Created(c, constant.MessageSuccessCreate, nil)
 }
 
+// HandleSimulate handles the http request process of simulating loan without storing it
+func (l *Loan) HandleSimulate(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+		req = &entity.LoanSimulation{}
+
+		result *entity.LoanQuote
+		err    error
+	)
+
+	err = c.Bind(req)
+	if err != nil {
+		return errorwrapper.E("error binding request", errorwrapper.CodeInvalid)
+	}
+
+	result, err = l.service.Simulate(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
 // HandleProceed handles the http request process of proceeding loan
 func (l *Loan) HandleProceed(c echo.Context) error {
 	var (
@@ -71,6 +97,10 @@ func (l *Loan) HandleProceed(c echo.Context) error {
 	)
 
 	req = transformToLoanProceed(c)
+	if req.Action.IsAutomatic() {
+		return errorwrapper.E("automatic action can't be requested directly", errorwrapper.CodeInvalid)
+	}
+
 	err = l.service.Proceed(ctx, req)
 	if err != nil {
 		return err
@@ -78,3 +108,54 @@ func (l *Loan) HandleProceed(c echo.Context) error {
 
 	return responsewrapper.OK(c, constant.MessageSuccessUpdate, nil)
 }
+
+// HandleGetHistory handles the http request process of getting loan status transition history
+func (l *Loan) HandleGetHistory(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.LoanStateHistory
+		err    error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = l.service.GetHistory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleGetAllowedActions handles the http request process of getting actions which can be proceeded on a loan
+func (l *Loan) HandleGetAllowedActions(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.LoanAllowedAction
+		err    error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = l.service.GetAllowedActions(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleGetTransitions handles the http request process of getting allowed loan status transitions,
+// the transition graph is rendered in Graphviz DOT format when requested
+func (l *Loan) HandleGetTransitions(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	result := l.service.GetTransitions(ctx)
+	if c.QueryParam("format") == "dot" {
+		return c.String(http.StatusOK, result.DOT())
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
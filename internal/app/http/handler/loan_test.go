@@ -6,6 +6,7 @@ import (
 
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -75,7 +76,7 @@ func TestLoan_HandleGet(t *testing.T) {
 			args: args{
 				c: newMockEchoContext(&mockEchoContext{}),
 			},
-			want: "{\"data\":{\"List\":[{\"id\":1,\"borrower_id\":0,\"amount\":0,\"rate\":0,\"status\":0,\"created_by\":0,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\",\"approved_at\":\"0001-01-01T00:00:00Z\",\"invested_at\":\"0001-01-01T00:00:00Z\",\"disbursed_at\":\"0001-01-01T00:00:00Z\"}],\"count\":1,\"row\":0,\"page\":0},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+			want: "{\"data\":{\"List\":[{\"id\":1,\"borrower_id\":0,\"product_id\":0,\"currency\":\"\",\"amount\":0,\"rate\":0,\"tenor\":0,\"status\":0,\"created_by\":0,\"funding_deadline\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\",\"approved_at\":\"0001-01-01T00:00:00Z\",\"invested_at\":\"0001-01-01T00:00:00Z\",\"disbursed_at\":\"0001-01-01T00:00:00Z\",\"rejected_at\":\"0001-01-01T00:00:00Z\",\"cancelled_at\":\"0001-01-01T00:00:00Z\",\"repaid_at\":\"0001-01-01T00:00:00Z\",\"defaulted_at\":\"0001-01-01T00:00:00Z\",\"expired_at\":\"0001-01-01T00:00:00Z\",\"allowed_actions\":null}],\"count\":1,\"row\":0,\"page\":0},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
 		},
 		{
 			name: "error on get",
@@ -183,6 +184,86 @@ func TestLoan_HandleCreate(t *testing.T) {
 	}
 }
 
+func TestLoan_HandleSimulate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Loan
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Simulate(gomock.Any(), gomock.Any()).
+						Return(&entity.LoanQuote{
+							ProductID:       1,
+							Currency:        currency.IDR,
+							Amount:          currency.FromMajor(1000000),
+							Rate:            12,
+							Tenor:           1,
+							TotalInterest:   currency.FromMajor(10000),
+							TotalPayment:    currency.FromMajor(1010000),
+							AdminFee:        currency.FromMajor(50000),
+							NetDisbursement: currency.FromMajor(950000),
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":{\"product_id\":1,\"currency\":\"IDR\",\"amount\":1000000,\"rate\":12,\"tenor\":1,\"installments\":null,\"total_interest\":10000,\"total_payment\":1010000,\"admin_fee\":50000,\"provision_fee\":0,\"origination_fee\":0,\"net_disbursement\":950000},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on simulate",
+			fields: fields{
+				service: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Simulate(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Loan{
+				service: tt.fields.service,
+			}
+			if err := l.HandleSimulate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Loan.HandleSimulate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Loan.HandleSimulate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
 func TestLoan_HandleProceed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -217,6 +298,22 @@ func TestLoan_HandleProceed(t *testing.T) {
 			},
 			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
 		},
+		{
+			name:   "automatic action",
+			fields: fields{},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockFormValue: func(name string) string {
+						if name == "action" {
+							return "6"
+						}
+						return ""
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "error on proceed",
 			fields: fields{
@@ -252,3 +349,241 @@ func TestLoan_HandleProceed(t *testing.T) {
 		})
 	}
 }
+
+func TestLoan_HandleGetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Loan
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetHistory(gomock.Any(), int64(4)).
+						Return([]*entity.LoanStateHistory{
+							{
+								ID:         1,
+								LoanID:     4,
+								FromStatus: 1,
+								ToStatus:   2,
+								Action:     1,
+								ActorID:    2,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "4"
+					},
+				}),
+			},
+			want: "{\"data\":[{\"id\":1,\"loan_id\":4,\"from_status\":1,\"to_status\":2,\"action\":1,\"actor_id\":2,\"metadata\":{},\"created_at\":\"0001-01-01T00:00:00Z\"}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on get history",
+			fields: fields{
+				service: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetHistory(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Loan{
+				service: tt.fields.service,
+			}
+			if err := l.HandleGetHistory(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Loan.HandleGetHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Loan.HandleGetHistory() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoan_HandleGetAllowedActions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Loan
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetAllowedActions(gomock.Any(), int64(4)).
+						Return([]*entity.LoanAllowedAction{
+							{
+								Action: 3,
+								Name:   "disburse",
+								Fields: []string{"disbursed_by", "disbursed_at"},
+								Files:  []string{"agreement_letter"},
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "4"
+					},
+				}),
+			},
+			want: "{\"data\":[{\"action\":3,\"name\":\"disburse\",\"fields\":[\"disbursed_by\",\"disbursed_at\"],\"files\":[\"agreement_letter\"],\"automatic\":false}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on get allowed actions",
+			fields: fields{
+				service: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetAllowedActions(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Loan{
+				service: tt.fields.service,
+			}
+			if err := l.HandleGetAllowedActions(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Loan.HandleGetAllowedActions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Loan.HandleGetAllowedActions() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoan_HandleGetTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Loan
+	}
+	type args struct {
+		c echo.Context
+	}
+	mockService := func() *service.MockLoan {
+		mock := service.NewMockLoan(ctrl)
+		mock.EXPECT().
+			GetTransitions(gomock.Any()).
+			Return(entity.LoanTransitionGraph{
+				{
+					From:   1,
+					Action: 1,
+					To:     2,
+				},
+			})
+
+		return mock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: mockService(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":[{\"from\":1,\"action\":1,\"to\":2}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "success dot format",
+			fields: fields{
+				service: mockService(),
+			},
+			args: args{
+				c: func() echo.Context {
+					c := newMockEchoContext(&mockEchoContext{})
+					c.Request().URL.RawQuery = "format=dot"
+
+					return c
+				}(),
+			},
+			want: "digraph loan_state {\n\trankdir=LR;\n\t\"proposed\" [shape=circle];\n\t\"approved\" [shape=doublecircle];\n\t\"proposed\" -> \"approved\" [label=\"approve\"];\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Loan{
+				service: tt.fields.service,
+			}
+			if err := l.HandleGetTransitions(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Loan.HandleGetTransitions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Loan.HandleGetTransitions() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
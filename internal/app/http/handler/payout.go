@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Payout is a handler for http request related to Payout
+type Payout struct {
+	service service.Payout
+}
+
+// NewPayout returns new Payout handler.
+func NewPayout(service service.Payout) *Payout {
+	return &Payout{
+		service: service,
+	}
+}
+
+// HandleGetByInvestment handles the http request process of getting investment payout history
+func (p *Payout) HandleGetByInvestment(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.Payout
+		err    error
+	)
+
+	investmentID, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = p.service.GetByInvestment(ctx, investmentID)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
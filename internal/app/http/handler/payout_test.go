@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPayout(t *testing.T) {
+	type args struct {
+		service service.Payout
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Payout
+	}{
+		{
+			name: "success",
+			want: &Payout{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPayout(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayout_HandleGetByInvestment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Payout
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockPayout {
+					mock := service.NewMockPayout(ctrl)
+					mock.EXPECT().
+						GetByInvestment(gomock.Any(), int64(4)).
+						Return([]*entity.Payout{
+							{
+								ID:           1,
+								InvestmentID: 4,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "4"
+					},
+				}),
+			},
+			want: "{\"data\":[{\"id\":1,\"repayment_id\":0,\"investment_id\":4,\"investor_id\":0,\"loan_id\":0,\"principal\":0,\"interest\":0,\"penalty\":0,\"amount\":0,\"service_fee\":0,\"tax\":0,\"net_amount\":0,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on get by investment",
+			fields: fields{
+				service: func() *service.MockPayout {
+					mock := service.NewMockPayout(ctrl)
+					mock.EXPECT().
+						GetByInvestment(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Payout{
+				service: tt.fields.service,
+			}
+			if err := p.HandleGetByInvestment(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Payout.HandleGetByInvestment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Payout.HandleGetByInvestment() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
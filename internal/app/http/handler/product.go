@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Product is a handler for http request related to LoanProduct
+type Product struct {
+	service service.LoanProduct
+}
+
+// NewProduct returns new Product handler.
+func NewProduct(service service.LoanProduct) *Product {
+	return &Product{
+		service: service,
+	}
+}
+
+// HandleGet handles the http request process of getting loan product
+func (p *Product) HandleGet(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.LoanProduct
+		err    error
+	)
+
+	filter := transformToLoanProductFilter(c)
+	result, err = p.service.Get(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleGetDetail handles the http request process of getting loan product detail
+func (p *Product) HandleGetDetail(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result *entity.LoanProduct
+		err    error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = p.service.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleCreate handles the http request process of creating loan product
+func (p *Product) HandleCreate(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.LoanProduct{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("error binding request", errorwrapper.CodeInvalid)
+	}
+
+	err = p.service.Create(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.Created(c, constant.MessageSuccessCreate, nil)
+}
+
+// HandleUpdate handles the http request process of updating loan product
+func (p *Product) HandleUpdate(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.LoanProduct{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("error binding request", errorwrapper.CodeInvalid)
+	}
+	model.ID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+
+	err = p.service.Update(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessUpdate, nil)
+}
+
+// HandleDelete handles the http request process of deactivating loan product
+func (p *Product) HandleDelete(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		err error
+	)
+
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	err = p.service.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessDelete, nil)
+}
@@ -0,0 +1,447 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProduct(t *testing.T) {
+	type args struct {
+		service service.LoanProduct
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Product
+	}{
+		{
+			name: "success",
+			want: &Product{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProduct(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProduct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProduct_HandleGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.LoanProduct
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.LoanProductFilter{}).
+						Return([]*entity.LoanProduct{
+							{
+								ID:           1,
+								Name:         "Personal Loan",
+								Currency:     currency.IDR,
+								MinAmount:    currency.FromMajor(1000000),
+								MaxAmount:    currency.FromMajor(50000000),
+								MinRate:      8,
+								MaxRate:      15,
+								TenorOptions: []int{6, 12},
+								Status:       constant.GeneralStatusActive,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":[{\"id\":1,\"name\":\"Personal Loan\",\"currency\":\"IDR\",\"min_amount\":1000000,\"max_amount\":50000000,\"min_rate\":8,\"max_rate\":15,\"tenor_options\":[6,12],\"admin_fee\":0,\"provision_fee\":0,\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Product{
+				service: tt.fields.service,
+			}
+			if err := p.HandleGet(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Product.HandleGet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Product.HandleGet() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestProduct_HandleGetDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.LoanProduct
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.LoanProduct{
+							ID:           1,
+							Name:         "Personal Loan",
+							Currency:     currency.IDR,
+							MinAmount:    currency.FromMajor(1000000),
+							MaxAmount:    currency.FromMajor(50000000),
+							MinRate:      8,
+							MaxRate:      15,
+							TenorOptions: []int{6, 12},
+							Status:       constant.GeneralStatusActive,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":{\"id\":1,\"name\":\"Personal Loan\",\"currency\":\"IDR\",\"min_amount\":1000000,\"max_amount\":50000000,\"min_rate\":8,\"max_rate\":15,\"tenor_options\":[6,12],\"admin_fee\":0,\"provision_fee\":0,\"status\":1,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"},\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Product{
+				service: tt.fields.service,
+			}
+			if err := p.HandleGetDetail(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Product.HandleGetDetail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Product.HandleGetDetail() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestProduct_HandleCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.LoanProduct
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want: "{\"data\":null,\"message\":\"Success create data\",\"status\":\"Created\"}\n",
+		},
+		{
+			name:   "error on bind",
+			fields: fields{},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Product{
+				service: tt.fields.service,
+			}
+			if err := p.HandleCreate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Product.HandleCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Product.HandleCreate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestProduct_HandleUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.LoanProduct
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.LoanProduct{ID: 1}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success update data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name:   "error on bind",
+			fields: fields{},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Product{
+				service: tt.fields.service,
+			}
+			if err := p.HandleUpdate(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Product.HandleUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Product.HandleUpdate() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestProduct_HandleDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.LoanProduct
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Delete(gomock.Any(), int64(1)).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":null,\"message\":\"Success delete data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on service",
+			fields: fields{
+				service: func() *service.MockLoanProduct {
+					mock := service.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Delete(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Product{
+				service: tt.fields.service,
+			}
+			if err := p.HandleDelete(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Product.HandleDelete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Product.HandleDelete() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Repayment is a handler for http request related to Repayment
+type Repayment struct {
+	service service.Repayment
+}
+
+// NewRepayment returns new Repayment handler.
+func NewRepayment(service service.Repayment) *Repayment {
+	return &Repayment{
+		service: service,
+	}
+}
+
+// HandleRepay handles the http request process of recording borrower repayment
+func (r *Repayment) HandleRepay(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.Repayment{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("invalid parameter", errorwrapper.CodeInvalid)
+	}
+	model.LoanID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+
+	err = r.service.Repay(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.Created(c, constant.MessageSuccessCreate, model)
+}
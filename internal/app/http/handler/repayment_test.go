@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRepayment(t *testing.T) {
+	type args struct {
+		service service.Repayment
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Repayment
+	}{
+		{
+			name: "success",
+			want: &Repayment{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewRepayment(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRepayment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepayment_HandleRepay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Repayment
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockRepayment {
+					mock := service.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Repay(gomock.Any(), &entity.Repayment{
+							LoanID: 4,
+							Amount: currency.FromMajor(100000),
+						}).
+						DoAndReturn(func(_ interface{}, req *entity.Repayment) error {
+							req.ID = 1
+							req.Principal = currency.FromMajor(90000)
+							req.Interest = currency.FromMajor(10000)
+							return nil
+						})
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "4"
+					},
+					mockBind: func(i interface{}) error {
+						i.(*entity.Repayment).Amount = currency.FromMajor(100000)
+						return nil
+					},
+				}),
+			},
+			want: "{\"data\":{\"id\":1,\"loan_id\":4,\"amount\":100000,\"principal\":90000,\"interest\":10000,\"penalty\":0,\"paid_at\":\"0001-01-01T00:00:00Z\",\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"},\"message\":\"Success create data\",\"status\":\"Created\"}\n",
+		},
+		{
+			name: "error on bind",
+			fields: fields{
+				service: func() *service.MockRepayment {
+					mock := service.NewMockRepayment(ctrl)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on repay",
+			fields: fields{
+				service: func() *service.MockRepayment {
+					mock := service.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Repay(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Repayment{
+				service: tt.fields.service,
+			}
+			if err := r.HandleRepay(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Repayment.HandleRepay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Repayment.HandleRepay() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
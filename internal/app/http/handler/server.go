@@ -7,11 +7,19 @@ import (
 )
 
 type Server struct {
-	config            *config.Config
-	echo              *echo.Echo
-	healthHandler     *Health
-	loanHandler       *Loan
-	investmentHandler *Investment
+	config             *config.Config
+	echo               *echo.Echo
+	healthHandler      *Health
+	loanHandler        *Loan
+	investmentHandler  *Investment
+	installmentHandler *Installment
+	repaymentHandler   *Repayment
+	payoutHandler      *Payout
+	productHandler     *Product
+	walletHandler      *Wallet
+	ledgerHandler      *Ledger
+	investorHandler    *Investor
+	borrowerHandler    *Borrower
 }
 
 func NewServer(
@@ -19,15 +27,31 @@ func NewServer(
 	healthHandler *Health,
 	loanHandler *Loan,
 	investmentHandler *Investment,
+	installmentHandler *Installment,
+	repaymentHandler *Repayment,
+	payoutHandler *Payout,
+	productHandler *Product,
+	walletHandler *Wallet,
+	ledgerHandler *Ledger,
+	investorHandler *Investor,
+	borrowerHandler *Borrower,
 ) *Server {
 	e := echo.New()
 
 	s := &Server{
-		config:            config,
-		echo:              e,
-		healthHandler:     healthHandler,
-		loanHandler:       loanHandler,
-		investmentHandler: investmentHandler,
+		config:             config,
+		echo:               e,
+		healthHandler:      healthHandler,
+		loanHandler:        loanHandler,
+		investmentHandler:  investmentHandler,
+		installmentHandler: installmentHandler,
+		repaymentHandler:   repaymentHandler,
+		payoutHandler:      payoutHandler,
+		productHandler:     productHandler,
+		walletHandler:      walletHandler,
+		ledgerHandler:      ledgerHandler,
+		investorHandler:    investorHandler,
+		borrowerHandler:    borrowerHandler,
 	}
 	e.HTTPErrorHandler = s.errorHandler
 
@@ -44,11 +68,48 @@ func (s *Server) InitRoutes() {
 	// Loan
 	v1.GET("/loan", s.loanHandler.HandleGet)
 	v1.POST("/loan", s.loanHandler.HandleCreate)
+	v1.POST("/loan/simulate", s.loanHandler.HandleSimulate)
+	v1.GET("/loan/transitions", s.loanHandler.HandleGetTransitions)
 	v1.PUT("/loan/:id", s.loanHandler.HandleProceed)
+	v1.GET("/loan/:id/history", s.loanHandler.HandleGetHistory)
+	v1.GET("/loan/:id/actions", s.loanHandler.HandleGetAllowedActions)
+	v1.GET("/loan/:id/schedule", s.installmentHandler.HandleGetSchedule)
+	v1.POST("/loan/:id/repayment", s.repaymentHandler.HandleRepay)
+
+	// Loan Product
+	v1.GET("/product", s.productHandler.HandleGet)
+	v1.POST("/product", s.productHandler.HandleCreate)
+	v1.GET("/product/:id", s.productHandler.HandleGetDetail)
+	v1.PUT("/product/:id", s.productHandler.HandleUpdate)
+	v1.DELETE("/product/:id", s.productHandler.HandleDelete)
+
+	// Borrower
+	v1.GET("/borrower", s.borrowerHandler.HandleGet)
+	v1.POST("/borrower", s.borrowerHandler.HandleCreate)
+	v1.GET("/borrower/:id", s.borrowerHandler.HandleGetDetail)
+	v1.PUT("/borrower/:id", s.borrowerHandler.HandleUpdate)
+	v1.PUT("/borrower/:id/activate", s.borrowerHandler.HandleActivate)
+	v1.PUT("/borrower/:id/deactivate", s.borrowerHandler.HandleDeactivate)
 
 	// Investment
 	v1.GET("/investment", s.investmentHandler.HandleGet)
 	v1.POST("/investment", s.investmentHandler.HandleInvest)
+	v1.DELETE("/investment/:id", s.investmentHandler.HandleCancel)
+	v1.GET("/investment/:id/payouts", s.payoutHandler.HandleGetByInvestment)
+
+	// Investor
+	v1.GET("/investor", s.investorHandler.HandleGet)
+	v1.POST("/investor", s.investorHandler.HandleCreate)
+	v1.GET("/investor/:id", s.investorHandler.HandleGetDetail)
+	v1.PUT("/investor/:id", s.investorHandler.HandleUpdate)
+	v1.PUT("/investor/:id/activate", s.investorHandler.HandleActivate)
+	v1.PUT("/investor/:id/deactivate", s.investorHandler.HandleDeactivate)
+	v1.GET("/investor/:id/portfolio", s.investmentHandler.HandleGetPortfolio)
+	v1.GET("/investor/:id/wallet", s.walletHandler.HandleGet)
+	v1.POST("/investor/:id/wallet/deposit", s.walletHandler.HandleDeposit)
+
+	// Ledger
+	v1.GET("/ledger/trial-balance", s.ledgerHandler.HandleGetTrialBalance)
 }
 
 func (s *Server) ListenAndServe() {
@@ -4,6 +4,7 @@ import (
 	"io"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
@@ -24,6 +25,7 @@ func transformToLoanFilter(c echo.Context) *entity.LoanFilter {
 
 	filter.ID, _ = strconv.ParseInt(c.QueryParam("id"), 10, 64)
 	filter.BorrowerID, _ = strconv.ParseInt(c.QueryParam("borrower_id"), 10, 64)
+	filter.ProductID, _ = strconv.ParseInt(c.QueryParam("product_id"), 10, 64)
 	status, _ := strconv.Atoi(c.QueryParam("status"))
 	filter.Status = constant.LoanStatus(status)
 	filter.CreatedAtStart, _ = time.Parse(constant.TimeISOFormat, c.QueryParam("created_at_start"))
@@ -36,6 +38,43 @@ func transformToLoanFilter(c echo.Context) *entity.LoanFilter {
 	return filter
 }
 
+func transformToLoanProductFilter(c echo.Context) *entity.LoanProductFilter {
+	var (
+		filter = &entity.LoanProductFilter{}
+	)
+
+	filter.ID, _ = strconv.ParseInt(c.QueryParam("id"), 10, 64)
+	filter.Status, _ = strconv.Atoi(c.QueryParam("status"))
+
+	return filter
+}
+
+func transformToBorrowerFilter(c echo.Context) *entity.BorrowerFilter {
+	var (
+		filter = &entity.BorrowerFilter{}
+	)
+
+	filter.ID, _ = strconv.ParseInt(c.QueryParam("id"), 10, 64)
+	filter.IdentificationNumber = strings.TrimSpace(c.QueryParam("identification_number"))
+	filter.Name = strings.TrimSpace(c.QueryParam("name"))
+	filter.Status, _ = strconv.Atoi(c.QueryParam("status"))
+
+	return filter
+}
+
+func transformToInvestorFilter(c echo.Context) *entity.InvestorFilter {
+	var (
+		filter = &entity.InvestorFilter{}
+	)
+
+	filter.ID, _ = strconv.ParseInt(c.QueryParam("id"), 10, 64)
+	filter.IdentificationNumber = strings.TrimSpace(c.QueryParam("identification_number"))
+	filter.Email = strings.ToLower(strings.TrimSpace(c.QueryParam("email")))
+	filter.Status, _ = strconv.Atoi(c.QueryParam("status"))
+
+	return filter
+}
+
 func transformToInvestmentFilter(c echo.Context) *entity.InvestmentFilter {
 	var (
 		filter = &entity.InvestmentFilter{}
@@ -58,6 +97,28 @@ func transformToInvestmentFilter(c echo.Context) *entity.InvestmentFilter {
 	return filter
 }
 
+func transformToPortfolioFilter(c echo.Context) *entity.PortfolioFilter {
+	var (
+		filter = &entity.PortfolioFilter{}
+	)
+
+	filter.InvestorID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+	filter.Currency = c.QueryParam("currency")
+
+	return filter
+}
+
+func transformToTrialBalanceFilter(c echo.Context) *entity.TrialBalanceFilter {
+	var (
+		filter = &entity.TrialBalanceFilter{}
+	)
+
+	filter.Currency = c.QueryParam("currency")
+	filter.AsOf, _ = time.Parse(constant.TimeISOFormat, c.QueryParam("as_of"))
+
+	return filter
+}
+
 func transformToLoanProceed(c echo.Context) *entity.LoanProceed {
 	var (
 		req = &entity.LoanProceed{}
@@ -71,9 +132,18 @@ func transformToLoanProceed(c echo.Context) *entity.LoanProceed {
 	req.Data.ID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
 	req.Data.ApprovedBy, _ = strconv.ParseInt(c.FormValue("approved_by"), 10, 64)
 	req.Data.ApprovedAt, _ = time.Parse(constant.TimeISOFormat, c.FormValue("approved_at"))
+	req.Data.FundingDeadline, _ = time.Parse(constant.TimeISOFormat, c.FormValue("funding_deadline"))
 	req.Data.DisbursedBy, _ = strconv.ParseInt(c.FormValue("disbursed_by"), 10, 64)
 	req.Data.DisbursedAt, _ = time.Parse(constant.TimeISOFormat, c.FormValue("disbursed_at"))
 	req.Data.InvestedAt, _ = time.Parse(constant.TimeISOFormat, c.FormValue("invested_at"))
+	req.Data.RejectedBy, _ = strconv.ParseInt(c.FormValue("rejected_by"), 10, 64)
+	req.Data.RejectedAt, _ = time.Parse(constant.TimeISOFormat, c.FormValue("rejected_at"))
+	req.Data.CancelledBy, _ = strconv.ParseInt(c.FormValue("cancelled_by"), 10, 64)
+	req.Data.CancelledAt, _ = time.Parse(constant.TimeISOFormat, c.FormValue("cancelled_at"))
+	req.Data.Reason = c.FormValue("reason")
+	req.Metadata.RequestID = c.Request().Header.Get(echo.HeaderXRequestID)
+	req.Metadata.IPAddress = c.RealIP()
+	req.Metadata.UserAgent = c.Request().UserAgent()
 
 	return req
 }
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/responsewrapper"
+	"github.com/labstack/echo/v4"
+)
+
+// Wallet is a handler for http request related to Wallet
+type Wallet struct {
+	service service.Wallet
+}
+
+// NewWallet returns new Wallet handler.
+func NewWallet(service service.Wallet) *Wallet {
+	return &Wallet{
+		service: service,
+	}
+}
+
+// HandleGet handles the http request process of getting investor wallet balances
+func (w *Wallet) HandleGet(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+
+		result []*entity.Wallet
+		err    error
+	)
+
+	investorID, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	result, err = w.service.Get(ctx, investorID)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.OK(c, constant.MessageSuccessGet, result)
+}
+
+// HandleDeposit handles the http request process of depositing funds into investor wallet
+func (w *Wallet) HandleDeposit(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		model = &entity.WalletDeposit{}
+
+		err error
+	)
+
+	err = c.Bind(model)
+	if err != nil {
+		return errorwrapper.E("invalid parameter", errorwrapper.CodeInvalid)
+	}
+	model.InvestorID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+
+	err = w.service.Deposit(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	return responsewrapper.Created(c, constant.MessageSuccessCreate, model)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWallet(t *testing.T) {
+	type args struct {
+		service service.Wallet
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Wallet
+	}{
+		{
+			name: "success",
+			want: &Wallet{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewWallet(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewWallet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWallet_HandleGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Wallet
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), int64(1)).
+						Return([]*entity.Wallet{
+							{
+								ID:         1,
+								InvestorID: 1,
+								Currency:   currency.IDR,
+								Available:  currency.FromMajor(500000),
+								Held:       currency.FromMajor(200000),
+								Committed:  currency.FromMajor(300000),
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+				}),
+			},
+			want: "{\"data\":[{\"id\":1,\"investor_id\":1,\"currency\":\"IDR\",\"available\":500000,\"held\":200000,\"committed\":300000,\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\"}],\"message\":\"Success get data\",\"status\":\"OK\"}\n",
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				service: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Wallet{
+				service: tt.fields.service,
+			}
+			if err := w.HandleGet(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Wallet.HandleGet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Wallet.HandleGet() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestWallet_HandleDeposit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Wallet
+	}
+	type args struct {
+		c echo.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Deposit(gomock.Any(), &entity.WalletDeposit{
+							InvestorID: 1,
+							Amount:     currency.FromMajor(1000000),
+						}).
+						DoAndReturn(func(_ interface{}, req *entity.WalletDeposit) error {
+							req.Currency = currency.IDR
+							return nil
+						})
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockParam: func() string {
+						return "1"
+					},
+					mockBind: func(i interface{}) error {
+						i.(*entity.WalletDeposit).Amount = currency.FromMajor(1000000)
+						return nil
+					},
+				}),
+			},
+			want: "{\"data\":{\"investor_id\":1,\"currency\":\"IDR\",\"amount\":1000000},\"message\":\"Success create data\",\"status\":\"Created\"}\n",
+		},
+		{
+			name: "error on bind",
+			fields: fields{
+				service: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{
+					mockBind: func(i interface{}) error {
+						return assert.AnError
+					},
+				}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error on deposit",
+			fields: fields{
+				service: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Deposit(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				c: newMockEchoContext(&mockEchoContext{}),
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Wallet{
+				service: tt.fields.service,
+			}
+			if err := w.HandleDeposit(tt.args.c); (err != nil) != tt.wantErr {
+				t.Errorf("Wallet.HandleDeposit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := tt.args.c.(*mockEchoContext).getResponseBody()
+			if string(got) != tt.want {
+				t.Errorf("Wallet.HandleDeposit() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -2,18 +2,33 @@ package http
 
 import (
 	"github.com/ecintiawan/loan-service/internal/app/http/handler"
+	borrowerRepo "github.com/ecintiawan/loan-service/internal/repository/borrower"
+	installmentRepo "github.com/ecintiawan/loan-service/internal/repository/installment"
 	investmentRepo "github.com/ecintiawan/loan-service/internal/repository/investment"
 	investorRepo "github.com/ecintiawan/loan-service/internal/repository/investor"
+	ledgerRepo "github.com/ecintiawan/loan-service/internal/repository/ledger"
 	loanRepo "github.com/ecintiawan/loan-service/internal/repository/loan"
-	notifierRepo "github.com/ecintiawan/loan-service/internal/repository/notifier"
+	outboxRepo "github.com/ecintiawan/loan-service/internal/repository/outbox"
+	payoutRepo "github.com/ecintiawan/loan-service/internal/repository/payout"
+	productRepo "github.com/ecintiawan/loan-service/internal/repository/product"
+	repaymentRepo "github.com/ecintiawan/loan-service/internal/repository/repayment"
 	uploadRepo "github.com/ecintiawan/loan-service/internal/repository/upload"
+	walletRepo "github.com/ecintiawan/loan-service/internal/repository/wallet"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/internal/service/borrower"
+	"github.com/ecintiawan/loan-service/internal/service/installment"
 	"github.com/ecintiawan/loan-service/internal/service/investment"
+	"github.com/ecintiawan/loan-service/internal/service/investor"
+	"github.com/ecintiawan/loan-service/internal/service/ledger"
 	"github.com/ecintiawan/loan-service/internal/service/loan"
 	"github.com/ecintiawan/loan-service/internal/service/loan/action"
+	"github.com/ecintiawan/loan-service/internal/service/loan/state"
+	"github.com/ecintiawan/loan-service/internal/service/payout"
+	"github.com/ecintiawan/loan-service/internal/service/product"
+	"github.com/ecintiawan/loan-service/internal/service/repayment"
+	"github.com/ecintiawan/loan-service/internal/service/wallet"
 	"github.com/ecintiawan/loan-service/pkg/config"
 	"github.com/ecintiawan/loan-service/pkg/database"
-	"github.com/ecintiawan/loan-service/pkg/email"
 	"github.com/ecintiawan/loan-service/pkg/file"
 	"github.com/ecintiawan/loan-service/pkg/lock"
 	"github.com/google/wire"
@@ -23,10 +38,9 @@ var (
 	httpSet = wire.NewSet(
 		config.NewConfig,
 		database.NewDB,
-		email.NewEmailImpl,
 		file.NewFileImpl,
 		file.NewPDFGeneratorImpl,
-		lock.NewLockImpl,
+		lock.NewLock,
 		repositorySet,
 		serviceSet,
 		handlerSet,
@@ -37,20 +51,44 @@ var (
 		handler.NewHealth,
 		handler.NewLoan,
 		handler.NewInvestment,
+		handler.NewInstallment,
+		handler.NewRepayment,
+		handler.NewPayout,
+		handler.NewProduct,
+		handler.NewWallet,
+		handler.NewLedger,
+		handler.NewInvestor,
+		handler.NewBorrower,
 		handler.NewServer,
 	)
 
 	serviceSet = wire.NewSet(
 		action.NewLoanActionImpl,
+		state.NewLoanStateMachine,
 		loan.NewLoanImpl,
 		investment.NewInvestmentImpl,
+		installment.NewInstallmentImpl,
+		repayment.NewRepaymentImpl,
+		payout.NewPayoutImpl,
+		product.NewLoanProductImpl,
+		wallet.NewWalletImpl,
+		ledger.NewLedgerImpl,
+		investor.NewInvestorImpl,
+		borrower.NewBorrowerImpl,
 	)
 
 	repositorySet = wire.NewSet(
 		loanRepo.New,
 		investmentRepo.New,
+		installmentRepo.New,
+		repaymentRepo.New,
+		payoutRepo.New,
+		productRepo.New,
+		borrowerRepo.New,
 		investorRepo.New,
 		uploadRepo.New,
-		notifierRepo.New,
+		outboxRepo.New,
+		walletRepo.New,
+		ledgerRepo.New,
 	)
 )
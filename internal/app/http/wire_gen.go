@@ -8,17 +8,32 @@ package http
 
 import (
 	"github.com/ecintiawan/loan-service/internal/app/http/handler"
+	"github.com/ecintiawan/loan-service/internal/repository/borrower"
+	"github.com/ecintiawan/loan-service/internal/repository/installment"
 	"github.com/ecintiawan/loan-service/internal/repository/investment"
 	"github.com/ecintiawan/loan-service/internal/repository/investor"
+	"github.com/ecintiawan/loan-service/internal/repository/ledger"
 	"github.com/ecintiawan/loan-service/internal/repository/loan"
-	"github.com/ecintiawan/loan-service/internal/repository/notifier"
+	"github.com/ecintiawan/loan-service/internal/repository/outbox"
+	"github.com/ecintiawan/loan-service/internal/repository/payout"
+	"github.com/ecintiawan/loan-service/internal/repository/product"
+	"github.com/ecintiawan/loan-service/internal/repository/repayment"
 	"github.com/ecintiawan/loan-service/internal/repository/upload"
+	"github.com/ecintiawan/loan-service/internal/repository/wallet"
+	borrower2 "github.com/ecintiawan/loan-service/internal/service/borrower"
+	installment2 "github.com/ecintiawan/loan-service/internal/service/installment"
 	investment2 "github.com/ecintiawan/loan-service/internal/service/investment"
+	investor2 "github.com/ecintiawan/loan-service/internal/service/investor"
+	ledger2 "github.com/ecintiawan/loan-service/internal/service/ledger"
 	loan2 "github.com/ecintiawan/loan-service/internal/service/loan"
 	"github.com/ecintiawan/loan-service/internal/service/loan/action"
+	"github.com/ecintiawan/loan-service/internal/service/loan/state"
+	payout2 "github.com/ecintiawan/loan-service/internal/service/payout"
+	product2 "github.com/ecintiawan/loan-service/internal/service/product"
+	repayment2 "github.com/ecintiawan/loan-service/internal/service/repayment"
+	wallet2 "github.com/ecintiawan/loan-service/internal/service/wallet"
 	"github.com/ecintiawan/loan-service/pkg/config"
 	"github.com/ecintiawan/loan-service/pkg/database"
-	"github.com/ecintiawan/loan-service/pkg/email"
 	"github.com/ecintiawan/loan-service/pkg/file"
 	"github.com/ecintiawan/loan-service/pkg/lock"
 )
@@ -34,15 +49,38 @@ func InitHttp() *handler.Server {
 	repositoryInvestor := investor.New(db)
 	fileFile := file.NewFileImpl()
 	repositoryUpload := upload.New(configConfig, fileFile)
-	emailEmail := email.NewEmailImpl(configConfig)
-	repositoryNotifier := notifier.New(emailEmail)
+	repositoryOutbox := outbox.New(db)
 	pdfGenerator := file.NewPDFGeneratorImpl()
-	loanAction := action.NewLoanActionImpl(configConfig, repositoryLoan, repositoryInvestment, repositoryInvestor, repositoryUpload, repositoryNotifier, pdfGenerator)
-	serviceLoan := loan2.NewLoanImpl(repositoryLoan, loanAction)
+	repositoryInstallment := installment.New(db)
+	serviceInstallment := installment2.NewInstallmentImpl(configConfig, repositoryInstallment)
+	repositoryWallet := wallet.New(db)
+	repositoryLedger := ledger.New(db)
+	serviceLedger := ledger2.NewLedgerImpl(repositoryLedger)
+	serviceWallet := wallet2.NewWalletImpl(repositoryWallet, repositoryInvestor, serviceLedger, db)
+	repositoryLoanProduct := product.New(db)
+	loanAction := action.NewLoanActionImpl(configConfig, repositoryLoan, repositoryLoanProduct, repositoryInvestment, repositoryInvestor, repositoryUpload, repositoryOutbox, pdfGenerator, serviceInstallment, serviceWallet, serviceLedger)
+	loanStateMachine := state.NewLoanStateMachine(configConfig, loanAction)
+	repositoryBorrower := borrower.New(db)
+	serviceLoan := loan2.NewLoanImpl(configConfig, repositoryLoan, repositoryLoanProduct, repositoryBorrower, loanStateMachine, serviceInstallment, db)
 	handlerLoan := handler.NewLoan(serviceLoan)
-	lockLock := lock.NewLockImpl()
-	serviceInvestment := investment2.NewInvestmentImpl(repositoryInvestment, repositoryLoan, serviceLoan, lockLock)
+	lockLock := lock.NewLock(configConfig, db)
+	serviceInvestment := investment2.NewInvestmentImpl(configConfig, repositoryInvestment, repositoryLoan, repositoryInvestor, serviceLoan, serviceInstallment, serviceWallet, serviceLedger, lockLock, db)
 	handlerInvestment := handler.NewInvestment(serviceInvestment)
-	server := handler.NewServer(configConfig, health, handlerLoan, handlerInvestment)
+	handlerInstallment := handler.NewInstallment(serviceInstallment)
+	repositoryRepayment := repayment.New(db)
+	repositoryPayout := payout.New(db)
+	servicePayout := payout2.NewPayoutImpl(configConfig, repositoryPayout, repositoryInvestment, serviceWallet, serviceLedger)
+	serviceRepayment := repayment2.NewRepaymentImpl(repositoryRepayment, repositoryInstallment, repositoryLoan, serviceLoan, servicePayout, lockLock, db)
+	handlerRepayment := handler.NewRepayment(serviceRepayment)
+	handlerPayout := handler.NewPayout(servicePayout)
+	serviceLoanProduct := product2.NewLoanProductImpl(repositoryLoanProduct)
+	handlerProduct := handler.NewProduct(serviceLoanProduct)
+	handlerWallet := handler.NewWallet(serviceWallet)
+	handlerLedger := handler.NewLedger(serviceLedger)
+	serviceInvestor := investor2.NewInvestorImpl(repositoryInvestor)
+	handlerInvestor := handler.NewInvestor(serviceInvestor)
+	serviceBorrower := borrower2.NewBorrowerImpl(repositoryBorrower)
+	handlerBorrower := handler.NewBorrower(serviceBorrower)
+	server := handler.NewServer(configConfig, health, handlerLoan, handlerInvestment, handlerInstallment, handlerRepayment, handlerPayout, handlerProduct, handlerWallet, handlerLedger, handlerInvestor, handlerBorrower)
 	return server
 }
@@ -0,0 +1,38 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/service"
+)
+
+// Delinquency is a scheduled job to evaluate overdue loans
+type Delinquency struct {
+	service service.Delinquency
+}
+
+// NewDelinquency returns new Delinquency job.
+func NewDelinquency(service service.Delinquency) *Delinquency {
+	return &Delinquency{
+		service: service,
+	}
+}
+
+// Run evaluates delinquency of all repayable loans as of the current time
+func (d *Delinquency) Run(ctx context.Context) error {
+	result, err := d.service.Evaluate(ctx, time.Now())
+	if err != nil {
+		log.Println("error running delinquency job", err)
+		return err
+	}
+
+	log.Printf("delinquency job finished: evaluated %d, defaulted %d, buckets %v\n",
+		result.Evaluated,
+		result.Defaulted,
+		result.Buckets,
+	)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package job
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDelinquency(t *testing.T) {
+	type args struct {
+		service service.Delinquency
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Delinquency
+	}{
+		{
+			name: "success",
+			want: &Delinquency{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDelinquency(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewDelinquency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelinquency_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Delinquency
+	}
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockDelinquency {
+					mock := service.NewMockDelinquency(ctrl)
+					mock.EXPECT().
+						Evaluate(gomock.Any(), gomock.Any()).
+						Return(entity.DelinquencyResult{
+							Evaluated: 2,
+							Defaulted: 1,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+		},
+		{
+			name: "error on evaluate",
+			fields: fields{
+				service: func() *service.MockDelinquency {
+					mock := service.NewMockDelinquency(ctrl)
+					mock.EXPECT().
+						Evaluate(gomock.Any(), gomock.Any()).
+						Return(entity.DelinquencyResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Delinquency{
+				service: tt.fields.service,
+			}
+			if err := d.Run(tt.args.ctx); (err != nil) != tt.wantErr {
+				t.Errorf("Delinquency.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/service"
+)
+
+// Expiry is a scheduled job to expire under-funded loans past their funding deadline
+type Expiry struct {
+	service service.Expiry
+}
+
+// NewExpiry returns new Expiry job.
+func NewExpiry(service service.Expiry) *Expiry {
+	return &Expiry{
+		service: service,
+	}
+}
+
+// Run expires all approved loans which funding deadline has passed as of the current time
+func (e *Expiry) Run(ctx context.Context) error {
+	result, err := e.service.Expire(ctx, time.Now())
+	if err != nil {
+		log.Println("error running expiry job", err)
+		return err
+	}
+
+	log.Printf("expiry job finished: evaluated %d, expired %d\n",
+		result.Evaluated,
+		result.Expired,
+	)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package job
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExpiry(t *testing.T) {
+	type args struct {
+		service service.Expiry
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Expiry
+	}{
+		{
+			name: "success",
+			want: &Expiry{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewExpiry(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpiry_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Expiry
+	}
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockExpiry {
+					mock := service.NewMockExpiry(ctrl)
+					mock.EXPECT().
+						Expire(gomock.Any(), gomock.Any()).
+						Return(entity.ExpiryResult{
+							Evaluated: 2,
+							Expired:   1,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+		},
+		{
+			name: "error on expire",
+			fields: fields{
+				service: func() *service.MockExpiry {
+					mock := service.NewMockExpiry(ctrl)
+					mock.EXPECT().
+						Expire(gomock.Any(), gomock.Any()).
+						Return(entity.ExpiryResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Expiry{
+				service: tt.fields.service,
+			}
+			if err := e.Run(tt.args.ctx); (err != nil) != tt.wantErr {
+				t.Errorf("Expiry.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
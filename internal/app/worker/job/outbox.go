@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/service"
+)
+
+// Outbox is a scheduled job to dispatch pending notifications of the outbox
+type Outbox struct {
+	service service.Outbox
+}
+
+// NewOutbox returns new Outbox job.
+func NewOutbox(service service.Outbox) *Outbox {
+	return &Outbox{
+		service: service,
+	}
+}
+
+// Run delivers all pending notifications which are due as of the current time
+func (o *Outbox) Run(ctx context.Context) error {
+	result, err := o.service.Dispatch(ctx, time.Now())
+	if err != nil {
+		log.Println("error running outbox job", err)
+		return err
+	}
+
+	log.Printf("outbox job finished: evaluated %d, sent %d, retried %d, dead %d\n",
+		result.Evaluated,
+		result.Sent,
+		result.Retried,
+		result.Dead,
+	)
+
+	return nil
+}
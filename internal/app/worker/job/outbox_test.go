@@ -0,0 +1,100 @@
+package job
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOutbox(t *testing.T) {
+	type args struct {
+		service service.Outbox
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Outbox
+	}{
+		{
+			name: "success",
+			want: &Outbox{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutbox(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewOutbox() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutbox_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		service service.Outbox
+	}
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				service: func() *service.MockOutbox {
+					mock := service.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Dispatch(gomock.Any(), gomock.Any()).
+						Return(entity.OutboxResult{
+							Evaluated: 2,
+							Sent:      1,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+		},
+		{
+			name: "error on dispatch",
+			fields: fields{
+				service: func() *service.MockOutbox {
+					mock := service.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Dispatch(gomock.Any(), gomock.Any()).
+						Return(entity.OutboxResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Outbox{
+				service: tt.fields.service,
+			}
+			if err := o.Run(tt.args.ctx); (err != nil) != tt.wantErr {
+				t.Errorf("Outbox.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
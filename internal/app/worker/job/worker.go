@@ -0,0 +1,84 @@
+package job
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/config"
+)
+
+type Worker struct {
+	config         *config.Config
+	delinquencyJob *Delinquency
+	expiryJob      *Expiry
+	outboxJob      *Outbox
+}
+
+func NewWorker(
+	config *config.Config,
+	delinquencyJob *Delinquency,
+	expiryJob *Expiry,
+	outboxJob *Outbox,
+) *Worker {
+	return &Worker{
+		config:         config,
+		delinquencyJob: delinquencyJob,
+		expiryJob:      expiryJob,
+		outboxJob:      outboxJob,
+	}
+}
+
+// Start runs every scheduled job on its configured interval until the process is terminated
+func (w *Worker) Start() {
+	var (
+		wg   sync.WaitGroup
+		jobs = []struct {
+			name     string
+			interval string
+			run      func(ctx context.Context) error
+		}{
+			{"delinquency", w.config.Worker.Delinquency.Interval, w.delinquencyJob.Run},
+			{"expiry", w.config.Worker.Expiry.Interval, w.expiryJob.Run},
+			{"outbox", w.config.Worker.Outbox.Interval, w.outboxJob.Run},
+		}
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for _, val := range jobs {
+		interval, err := time.ParseDuration(val.interval)
+		if err != nil || interval <= 0 {
+			log.Fatalf("invalid %s job interval: %q", val.name, val.interval)
+		}
+
+		wg.Add(1)
+		go func(interval time.Duration, run func(ctx context.Context) error) {
+			defer wg.Done()
+			schedule(ctx, interval, run)
+		}(interval, val.run)
+	}
+
+	wg.Wait()
+}
+
+// schedule runs the job right away and on every interval tick until the context is done
+func schedule(ctx context.Context, interval time.Duration, run func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"github.com/ecintiawan/loan-service/internal/app/worker/job"
+	borrowerRepo "github.com/ecintiawan/loan-service/internal/repository/borrower"
+	installmentRepo "github.com/ecintiawan/loan-service/internal/repository/installment"
+	investmentRepo "github.com/ecintiawan/loan-service/internal/repository/investment"
+	investorRepo "github.com/ecintiawan/loan-service/internal/repository/investor"
+	ledgerRepo "github.com/ecintiawan/loan-service/internal/repository/ledger"
+	loanRepo "github.com/ecintiawan/loan-service/internal/repository/loan"
+	notifierRepo "github.com/ecintiawan/loan-service/internal/repository/notifier"
+	outboxRepo "github.com/ecintiawan/loan-service/internal/repository/outbox"
+	productRepo "github.com/ecintiawan/loan-service/internal/repository/product"
+	uploadRepo "github.com/ecintiawan/loan-service/internal/repository/upload"
+	walletRepo "github.com/ecintiawan/loan-service/internal/repository/wallet"
+	"github.com/ecintiawan/loan-service/internal/service/delinquency"
+	"github.com/ecintiawan/loan-service/internal/service/expiry"
+	"github.com/ecintiawan/loan-service/internal/service/installment"
+	"github.com/ecintiawan/loan-service/internal/service/ledger"
+	"github.com/ecintiawan/loan-service/internal/service/loan"
+	"github.com/ecintiawan/loan-service/internal/service/loan/action"
+	"github.com/ecintiawan/loan-service/internal/service/loan/state"
+	"github.com/ecintiawan/loan-service/internal/service/outbox"
+	"github.com/ecintiawan/loan-service/internal/service/wallet"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/email"
+	"github.com/ecintiawan/loan-service/pkg/file"
+	"github.com/google/wire"
+)
+
+var (
+	workerSet = wire.NewSet(
+		config.NewConfig,
+		database.NewDB,
+		email.NewEmailImpl,
+		file.NewFileImpl,
+		file.NewPDFGeneratorImpl,
+		repositorySet,
+		serviceSet,
+		jobSet,
+	)
+
+	jobSet = wire.NewSet(
+		job.NewDelinquency,
+		job.NewExpiry,
+		job.NewOutbox,
+		job.NewWorker,
+	)
+
+	serviceSet = wire.NewSet(
+		action.NewLoanActionImpl,
+		state.NewLoanStateMachine,
+		loan.NewLoanImpl,
+		installment.NewInstallmentImpl,
+		delinquency.NewDelinquencyImpl,
+		expiry.NewExpiryImpl,
+		outbox.NewOutboxImpl,
+		wallet.NewWalletImpl,
+		ledger.NewLedgerImpl,
+	)
+
+	repositorySet = wire.NewSet(
+		loanRepo.New,
+		productRepo.New,
+		borrowerRepo.New,
+		investmentRepo.New,
+		installmentRepo.New,
+		investorRepo.New,
+		uploadRepo.New,
+		notifierRepo.New,
+		outboxRepo.New,
+		walletRepo.New,
+		ledgerRepo.New,
+	)
+)
@@ -0,0 +1,14 @@
+//go:build wireinject
+// +build wireinject
+
+package worker
+
+import (
+	"github.com/ecintiawan/loan-service/internal/app/worker/job"
+	"github.com/google/wire"
+)
+
+func InitWorker() *job.Worker {
+	wire.Build(workerSet)
+	return &job.Worker{}
+}
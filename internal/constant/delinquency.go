@@ -0,0 +1,10 @@
+package constant
+
+// constant for delinquency buckets based on days past due
+const (
+	DelinquencyBucketCurrent = "current"
+	DelinquencyBucket1To30   = "1-30"
+	DelinquencyBucket31To60  = "31-60"
+	DelinquencyBucket61To90  = "61-90"
+	DelinquencyBucketOver90  = "90+"
+)
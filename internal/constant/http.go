@@ -9,6 +9,7 @@ const (
 	MessageSuccessGet     = "Success get data"
 	MessageSuccessCreate  = "Success create data"
 	MessageSuccessUpdate  = "Success update data"
+	MessageSuccessDelete  = "Success delete data"
 	MessageSuccessGeneral = "Success"
 )
 
@@ -0,0 +1,6 @@
+package constant
+
+const (
+	InstallmentStatusUnpaid = 1
+	InstallmentStatusPaid   = 2
+)
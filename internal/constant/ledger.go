@@ -0,0 +1,21 @@
+package constant
+
+// constant for ledger account codes of the chart of accounts
+const (
+	LedgerAccountBank                  = "bank"
+	LedgerAccountInvestorWallet        = "investor_wallet"
+	LedgerAccountLoanEscrow            = "loan_escrow"
+	LedgerAccountOriginationFeeRevenue = "origination_fee_revenue"
+	LedgerAccountAdminFeeRevenue       = "admin_fee_revenue"
+	LedgerAccountProvisionFeeRevenue   = "provision_fee_revenue"
+	LedgerAccountServiceFeeRevenue     = "service_fee_revenue"
+	LedgerAccountWithholdingTaxPayable = "withholding_tax_payable"
+)
+
+// constant for ledger account types,
+// asset accounts have a normal debit balance while the others have a normal credit balance
+const (
+	LedgerAccountTypeAsset     = 1
+	LedgerAccountTypeLiability = 2
+	LedgerAccountTypeRevenue   = 3
+)
@@ -10,16 +10,90 @@ const (
 	StatusApproved  LoanStatus = 2
 	StatusInvested  LoanStatus = 3
 	StatusDisbursed LoanStatus = 4
+	StatusRejected  LoanStatus = 5
+	StatusCancelled LoanStatus = 6
+	StatusRepaid    LoanStatus = 7
+	StatusDefaulted LoanStatus = 8
+	StatusExpired   LoanStatus = 9
 
 	ActionApprove  LoanAction = 1
 	ActionInvest   LoanAction = 2
 	ActionDisburse LoanAction = 3
+	ActionReject   LoanAction = 4
+	ActionCancel   LoanAction = 5
+	ActionRepay    LoanAction = 6
+	ActionDefault  LoanAction = 7
+	ActionExpire   LoanAction = 8
 )
 
+var (
+	loanStatusNames = map[LoanStatus]string{
+		StatusProposed:  "proposed",
+		StatusApproved:  "approved",
+		StatusInvested:  "invested",
+		StatusDisbursed: "disbursed",
+		StatusRejected:  "rejected",
+		StatusCancelled: "cancelled",
+		StatusRepaid:    "repaid",
+		StatusDefaulted: "defaulted",
+		StatusExpired:   "expired",
+	}
+	loanActionNames = map[LoanAction]string{
+		ActionApprove:  "approve",
+		ActionInvest:   "invest",
+		ActionDisburse: "disburse",
+		ActionReject:   "reject",
+		ActionCancel:   "cancel",
+		ActionRepay:    "repay",
+		ActionDefault:  "default",
+		ActionExpire:   "expire",
+	}
+)
+
+// ParseLoanStatus returns the loan status of the given name
+func ParseLoanStatus(name string) (LoanStatus, bool) {
+	for status, statusName := range loanStatusNames {
+		if statusName == name {
+			return status, true
+		}
+	}
+
+	return 0, false
+}
+
+// ParseLoanAction returns the loan action of the given name
+func ParseLoanAction(name string) (LoanAction, bool) {
+	for action, actionName := range loanActionNames {
+		if actionName == name {
+			return action, true
+		}
+	}
+
+	return 0, false
+}
+
 func (s LoanStatus) Int() int {
 	return int(s)
 }
 
+func (s LoanStatus) Name() string {
+	return loanStatusNames[s]
+}
+
 func (a LoanAction) Int() int {
 	return int(a)
 }
+
+func (a LoanAction) Name() string {
+	return loanActionNames[a]
+}
+
+// IsAutomatic returns whether the action is proceeded by the service itself and can't be requested directly
+func (a LoanAction) IsAutomatic() bool {
+	switch a {
+	case ActionRepay, ActionDefault, ActionExpire:
+		return true
+	}
+
+	return false
+}
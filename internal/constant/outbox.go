@@ -0,0 +1,7 @@
+package constant
+
+const (
+	OutboxStatusPending = 1
+	OutboxStatusSent    = 2
+	OutboxStatusDead    = 3
+)
@@ -0,0 +1,11 @@
+package constant
+
+// constant for the type of investor wallet balance movements
+const (
+	WalletTransactionDeposit = 1
+	WalletTransactionHold    = 2
+	WalletTransactionRelease = 3
+	WalletTransactionCommit  = 4
+	WalletTransactionReturn  = 5
+	WalletTransactionPayout  = 6
+)
@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type (
 	// Borrower reflects borrower table
@@ -13,4 +16,23 @@ type (
 		CreatedAt            time.Time `json:"created_at"            db:"created_at"`
 		UpdatedAt            time.Time `json:"updated_at"            db:"updated_at"`
 	}
+
+	// BorrowerFilter stores filter used in get borrower request
+	// name is searched case-insensitively as part of the borrower name
+	BorrowerFilter struct {
+		ID                   int64
+		IdentificationNumber string
+		Name                 string
+		Status               int
+	}
 )
+
+// Normalize trims the borrower data so uniqueness of identification number doesn't depend on how it is typed
+func (data *Borrower) Normalize() {
+	data.IdentificationNumber = strings.TrimSpace(data.IdentificationNumber)
+	data.Name = strings.TrimSpace(data.Name)
+}
+
+func (data *Borrower) IsValid() bool {
+	return data.Name != "" && isDigits(data.IdentificationNumber)
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBorrower_Normalize(t *testing.T) {
+	data := &Borrower{
+		IdentificationNumber: " 1234567890123451 ",
+		Name:                 " Borrower A ",
+	}
+	want := &Borrower{
+		IdentificationNumber: "1234567890123451",
+		Name:                 "Borrower A",
+	}
+	data.Normalize()
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Borrower.Normalize() = %v, want %v", data, want)
+	}
+}
+
+func TestBorrower_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		data *Borrower
+		want bool
+	}{
+		{
+			name: "valid",
+			data: &Borrower{
+				IdentificationNumber: "1234567890123451",
+				Name:                 "Borrower A",
+			},
+			want: true,
+		},
+		{
+			name: "invalid name",
+			data: &Borrower{
+				IdentificationNumber: "1234567890123451",
+			},
+			want: false,
+		},
+		{
+			name: "invalid identification number",
+			data: &Borrower{
+				IdentificationNumber: "12345-6789",
+				Name:                 "Borrower A",
+			},
+			want: false,
+		},
+		{
+			name: "empty identification number",
+			data: &Borrower{
+				Name: "Borrower A",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.IsValid(); got != tt.want {
+				t.Errorf("Borrower.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
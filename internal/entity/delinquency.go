@@ -0,0 +1,10 @@
+package entity
+
+type (
+	// DelinquencyResult summarizes a single delinquency evaluation run
+	DelinquencyResult struct {
+		Evaluated int
+		Defaulted int
+		Buckets   map[string]int
+	}
+)
@@ -0,0 +1,9 @@
+package entity
+
+type (
+	// ExpiryResult summarizes a single funding deadline expiry run
+	ExpiryResult struct {
+		Evaluated int
+		Expired   int
+	}
+)
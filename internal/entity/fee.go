@@ -0,0 +1,11 @@
+package entity
+
+import (
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+// deductInterest returns the platform service fee and withholding tax of the interest received by an investor,
+// both are charged on the gross interest so the tax base doesn't depend on the service fee
+func deductInterest(interest currency.Amount, serviceFeeRate, taxRate float64) (currency.Amount, currency.Amount) {
+	return interest.Percent(serviceFeeRate), interest.Percent(taxRate)
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// Installment reflects installment table
+	// contains a single period of loan repayment schedule
+	Installment struct {
+		ID            int64           `json:"id"             db:"id"`
+		LoanID        int64           `json:"loan_id"        db:"loan_id"`
+		Sequence      int             `json:"sequence"       db:"sequence"`
+		DueDate       time.Time       `json:"due_date"       db:"due_date"`
+		Principal     currency.Amount `json:"principal"      db:"principal"`
+		Interest      currency.Amount `json:"interest"       db:"interest"`
+		Penalty       currency.Amount `json:"penalty"        db:"penalty"`
+		Amount        currency.Amount `json:"amount"         db:"amount"`
+		Outstanding   currency.Amount `json:"outstanding"    db:"outstanding"`
+		PrincipalPaid currency.Amount `json:"principal_paid" db:"principal_paid"`
+		InterestPaid  currency.Amount `json:"interest_paid"  db:"interest_paid"`
+		PenaltyPaid   currency.Amount `json:"penalty_paid"   db:"penalty_paid"`
+		Status        int             `json:"status"         db:"status"`
+		PaidAt        time.Time       `json:"paid_at"        db:"paid_at"`
+		CreatedAt     time.Time       `json:"created_at"     db:"created_at"`
+		UpdatedAt     time.Time       `json:"updated_at"     db:"updated_at"`
+	}
+
+	// InstallmentFilter stores filter used in get installment request
+	InstallmentFilter struct {
+		LoanID     int64
+		Status     int
+		DueDateEnd time.Time
+	}
+)
+
+// PrincipalDue returns the unpaid principal of the installment
+func (data *Installment) PrincipalDue() currency.Amount {
+	return data.Principal - data.PrincipalPaid
+}
+
+// InterestDue returns the unpaid interest of the installment
+func (data *Installment) InterestDue() currency.Amount {
+	return data.Interest - data.InterestPaid
+}
+
+// PenaltyDue returns the unpaid penalty of the installment
+func (data *Installment) PenaltyDue() currency.Amount {
+	return data.Penalty - data.PenaltyPaid
+}
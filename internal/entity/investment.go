@@ -1,19 +1,28 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
 
 type (
 	// Investment reflects investment table
-	// contains investment data by certain investor/user
+	// contains investment data by certain investor/user,
+	// expected return is the projected interest over the tenor of the loan before service fee and withholding tax
 	Investment struct {
-		ID         int64     `json:"id"          db:"id"`
-		InvestorID int64     `json:"investor_id" db:"investor_id"`
-		LoanID     int64     `json:"loan_id"     db:"loan_id"`
-		Amount     float64   `json:"amount"       db:"amount"`
-		ROI        float64   `json:"roi"          db:"roi"`
-		Status     int       `json:"status"      db:"status"`
-		CreatedAt  time.Time `json:"created_at"  db:"created_at"`
-		UpdatedAt  time.Time `json:"updated_at"  db:"updated_at"`
+		ID             int64           `json:"id"              db:"id"`
+		InvestorID     int64           `json:"investor_id"     db:"investor_id"`
+		LoanID         int64           `json:"loan_id"         db:"loan_id"`
+		Currency       string          `json:"currency"        db:"currency"`
+		Amount         currency.Amount `json:"amount"          db:"amount"`
+		ROI            float64         `json:"roi"             db:"roi"`
+		ExpectedReturn currency.Amount `json:"expected_return" db:"expected_return"`
+		ServiceFee     currency.Amount `json:"service_fee"     db:"service_fee"`
+		Tax            currency.Amount `json:"tax"             db:"tax"`
+		Status         int             `json:"status"          db:"status"`
+		CreatedAt      time.Time       `json:"created_at"      db:"created_at"`
+		UpdatedAt      time.Time       `json:"updated_at"      db:"updated_at"`
 	}
 
 	// InvestmentFilter stores pagination and filter used in get investment request
@@ -37,7 +46,8 @@ type (
 )
 
 func (data *Investment) IsValid() bool {
-	return data.InvestorID > 0 && data.LoanID > 0 && data.Amount > 0
+	return data.InvestorID > 0 && data.LoanID > 0 && data.Amount > 0 &&
+		(data.Currency == "" || currency.IsSupported(data.Currency))
 }
 
 // Validate self corrects IP Whitelist filter
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
 )
 
 func TestInvestment_IsValid(t *testing.T) {
@@ -11,7 +13,7 @@ func TestInvestment_IsValid(t *testing.T) {
 		ID         int64
 		InvestorID int64
 		LoanID     int64
-		Amount     float64
+		Amount     currency.Amount
 		ROI        float64
 		Status     int
 		CreatedAt  time.Time
@@ -27,7 +29,7 @@ func TestInvestment_IsValid(t *testing.T) {
 			fields: fields{
 				InvestorID: 1,
 				LoanID:     3,
-				Amount:     10000,
+				Amount:     currency.FromMajor(10000),
 			},
 			want: true,
 		},
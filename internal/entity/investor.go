@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"net/mail"
+	"strings"
+	"time"
+)
 
 type (
 	// Investor reflects investor table
@@ -14,4 +18,30 @@ type (
 		CreatedAt            time.Time `json:"created_at"            db:"created_at"`
 		UpdatedAt            time.Time `json:"updated_at"            db:"updated_at"`
 	}
+
+	// InvestorFilter stores filter used in get investor request
+	InvestorFilter struct {
+		ID                   int64
+		IdentificationNumber string
+		Email                string
+		Status               int
+	}
 )
+
+// Normalize trims the investor data and lowercases the email
+// so uniqueness of email and identification number doesn't depend on how they are typed
+func (data *Investor) Normalize() {
+	data.IdentificationNumber = strings.TrimSpace(data.IdentificationNumber)
+	data.Name = strings.TrimSpace(data.Name)
+	data.Email = strings.ToLower(strings.TrimSpace(data.Email))
+}
+
+func (data *Investor) IsValid() bool {
+	if data.Name == "" || !isDigits(data.IdentificationNumber) {
+		return false
+	}
+
+	// email must be a bare address without display name
+	address, err := mail.ParseAddress(data.Email)
+	return err == nil && address.Address == data.Email
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInvestor_Normalize(t *testing.T) {
+	data := &Investor{
+		IdentificationNumber: " 1234567890123454 ",
+		Name:                 " Investor A ",
+		Email:                " Investor.A@Example.com ",
+	}
+	want := &Investor{
+		IdentificationNumber: "1234567890123454",
+		Name:                 "Investor A",
+		Email:                "investor.a@example.com",
+	}
+	data.Normalize()
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Investor.Normalize() = %v, want %v", data, want)
+	}
+}
+
+func TestInvestor_IsValid(t *testing.T) {
+	valid := func() *Investor {
+		return &Investor{
+			IdentificationNumber: "1234567890123454",
+			Name:                 "Investor A",
+			Email:                "investor.a@example.com",
+		}
+	}
+	tests := []struct {
+		name string
+		data *Investor
+		want bool
+	}{
+		{
+			name: "valid",
+			data: valid(),
+			want: true,
+		},
+		{
+			name: "invalid name",
+			data: func() *Investor {
+				data := valid()
+				data.Name = ""
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "invalid identification number",
+			data: func() *Investor {
+				data := valid()
+				data.IdentificationNumber = "12345-6789"
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "invalid email",
+			data: func() *Investor {
+				data := valid()
+				data.Email = "investor.a"
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "email with display name",
+			data: func() *Investor {
+				data := valid()
+				data.Email = "Investor A <investor.a@example.com>"
+				return data
+			}(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.IsValid(); got != tt.want {
+				t.Errorf("Investor.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// LedgerJournal reflects ledger_journal table
+	// contains a single money movement posted as a balanced set of ledger entries,
+	// journals with a reference are posted at most once
+	LedgerJournal struct {
+		ID          int64          `json:"id"          db:"id"`
+		Reference   string         `json:"reference"   db:"reference"`
+		Currency    string         `json:"currency"    db:"currency"`
+		LoanID      int64          `json:"loan_id"     db:"loan_id"`
+		InvestorID  int64          `json:"investor_id" db:"investor_id"`
+		Description string         `json:"description" db:"description"`
+		Entries     []*LedgerEntry `json:"entries"`
+		CreatedAt   time.Time      `json:"created_at"  db:"created_at"`
+	}
+
+	// LedgerEntry reflects ledger_entry table
+	// contains the debit or credit of a journal on a single ledger account
+	LedgerEntry struct {
+		ID          int64           `json:"id"           db:"id"`
+		JournalID   int64           `json:"journal_id"   db:"journal_id"`
+		AccountCode string          `json:"account_code" db:"account_code"`
+		Debit       currency.Amount `json:"debit"        db:"debit"`
+		Credit      currency.Amount `json:"credit"       db:"credit"`
+	}
+
+	// TrialBalanceFilter stores filter used in get trial balance request
+	TrialBalanceFilter struct {
+		Currency string
+		AsOf     time.Time
+	}
+
+	// TrialBalanceAccount contains the debit and credit totals of a ledger account,
+	// balance is the difference on the normal side of the account
+	TrialBalanceAccount struct {
+		Code    string          `json:"code"`
+		Name    string          `json:"name"`
+		Type    int             `json:"type"`
+		Debit   currency.Amount `json:"debit"`
+		Credit  currency.Amount `json:"credit"`
+		Balance currency.Amount `json:"balance"`
+	}
+
+	// TrialBalance for API trial balance response
+	TrialBalance struct {
+		Currency    string                 `json:"currency"`
+		AsOf        time.Time              `json:"as_of"`
+		Accounts    []*TrialBalanceAccount `json:"accounts"`
+		TotalDebit  currency.Amount        `json:"total_debit"`
+		TotalCredit currency.Amount        `json:"total_credit"`
+		IsBalanced  bool                   `json:"is_balanced"`
+	}
+)
+
+// IsBalanced returns whether the journal has at least two entries, every entry is posted on exactly one side
+// and the total debit equals the total credit
+func (data *LedgerJournal) IsBalanced() bool {
+	var debit, credit currency.Amount
+	for _, entry := range data.Entries {
+		if entry.AccountCode == "" || entry.Debit < 0 || entry.Credit < 0 ||
+			(entry.Debit > 0) == (entry.Credit > 0) {
+			return false
+		}
+		debit += entry.Debit
+		credit += entry.Credit
+	}
+
+	return len(data.Entries) >= 2 && debit > 0 && debit == credit
+}
+
+// NewTrialBalance sums up the accounts and calculates the balance of every account on its normal side
+func NewTrialBalance(filter *TrialBalanceFilter, accounts []*TrialBalanceAccount) *TrialBalance {
+	result := &TrialBalance{
+		Currency: filter.Currency,
+		AsOf:     filter.AsOf,
+		Accounts: accounts,
+	}
+	for _, account := range accounts {
+		account.Balance = account.Credit - account.Debit
+		if account.Type == constant.LedgerAccountTypeAsset {
+			account.Balance = account.Debit - account.Credit
+		}
+		result.TotalDebit += account.Debit
+		result.TotalCredit += account.Credit
+	}
+	result.IsBalanced = result.TotalDebit == result.TotalCredit
+
+	return result
+}
+
+// NewDepositJournal posts funds received from an investor into the investor wallet
+func NewDepositJournal(deposit *WalletDeposit) *LedgerJournal {
+	journal := newJournal(deposit.Currency, "investor deposit",
+		debit(constant.LedgerAccountBank, deposit.Amount),
+		credit(constant.LedgerAccountInvestorWallet, deposit.Amount),
+	)
+	journal.InvestorID = deposit.InvestorID
+
+	return journal
+}
+
+// NewFundingJournal posts the investment amount from the investor wallet into the escrow of its loan
+func NewFundingJournal(investment *Investment) *LedgerJournal {
+	journal := newJournal(investment.Currency, "loan funding",
+		debit(constant.LedgerAccountInvestorWallet, investment.Amount),
+		credit(constant.LedgerAccountLoanEscrow, investment.Amount),
+	)
+	journal.InvestorID = investment.InvestorID
+	journal.LoanID = investment.LoanID
+
+	return journal
+}
+
+// NewFundingReversalJournal posts the investment amount from the escrow of its loan back into the investor wallet
+func NewFundingReversalJournal(investment *Investment) *LedgerJournal {
+	journal := newJournal(investment.Currency, "loan funding reversal",
+		debit(constant.LedgerAccountLoanEscrow, investment.Amount),
+		credit(constant.LedgerAccountInvestorWallet, investment.Amount),
+	)
+	journal.InvestorID = investment.InvestorID
+	journal.LoanID = investment.LoanID
+
+	return journal
+}
+
+// NewDisbursementJournal posts the escrow of the loan out to the borrower less the fees earned by the platform,
+// a loan is disbursed once so the journal is posted at most once
+func NewDisbursementJournal(loan *Loan) *LedgerJournal {
+	journal := newJournal(loan.Currency, "loan disbursement",
+		debit(constant.LedgerAccountLoanEscrow, loan.Amount),
+		credit(constant.LedgerAccountBank, loan.DisbursedAmount),
+		credit(constant.LedgerAccountAdminFeeRevenue, loan.AdminFee),
+		credit(constant.LedgerAccountProvisionFeeRevenue, loan.ProvisionFee),
+		credit(constant.LedgerAccountOriginationFeeRevenue, loan.OriginationFee),
+	)
+	journal.Reference = fmt.Sprintf("disbursement:%d", loan.ID)
+	journal.LoanID = loan.ID
+
+	return journal
+}
+
+// NewRepaymentJournal posts a borrower repayment into the wallets of the investors
+// less the service fee earned by the platform and the withholding tax owed on their behalf,
+// a repayment is distributed once so the journal is posted at most once
+func NewRepaymentJournal(repayment *Repayment, payouts []*Payout, code string) *LedgerJournal {
+	var amount, netAmount, serviceFee, tax currency.Amount
+	for _, payout := range payouts {
+		amount += payout.Amount
+		netAmount += payout.NetAmount
+		serviceFee += payout.ServiceFee
+		tax += payout.Tax
+	}
+
+	journal := newJournal(code, "loan repayment",
+		debit(constant.LedgerAccountBank, amount),
+		credit(constant.LedgerAccountInvestorWallet, netAmount),
+		credit(constant.LedgerAccountServiceFeeRevenue, serviceFee),
+		credit(constant.LedgerAccountWithholdingTaxPayable, tax),
+	)
+	journal.Reference = fmt.Sprintf("repayment:%d", repayment.ID)
+	journal.LoanID = repayment.LoanID
+
+	return journal
+}
+
+// newJournal returns a journal of the given entries, zero entries are left out
+func newJournal(code, description string, entries ...*LedgerEntry) *LedgerJournal {
+	journal := &LedgerJournal{
+		Currency:    code,
+		Description: description,
+		Entries:     make([]*LedgerEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		if entry.Debit == 0 && entry.Credit == 0 {
+			continue
+		}
+		journal.Entries = append(journal.Entries, entry)
+	}
+
+	return journal
+}
+
+func debit(accountCode string, amount currency.Amount) *LedgerEntry {
+	return &LedgerEntry{AccountCode: accountCode, Debit: amount}
+}
+
+func credit(accountCode string, amount currency.Amount) *LedgerEntry {
+	return &LedgerEntry{AccountCode: accountCode, Credit: amount}
+}
@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+func TestLedgerJournal_IsBalanced(t *testing.T) {
+	tests := []struct {
+		name string
+		data *LedgerJournal
+		want bool
+	}{
+		{
+			name: "balanced",
+			data: &LedgerJournal{
+				Entries: []*LedgerEntry{
+					{AccountCode: constant.LedgerAccountLoanEscrow, Debit: currency.FromMajor(1000)},
+					{AccountCode: constant.LedgerAccountBank, Credit: currency.FromMajor(990)},
+					{AccountCode: constant.LedgerAccountOriginationFeeRevenue, Credit: currency.FromMajor(10)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "unbalanced",
+			data: &LedgerJournal{
+				Entries: []*LedgerEntry{
+					{AccountCode: constant.LedgerAccountBank, Debit: currency.FromMajor(1000)},
+					{AccountCode: constant.LedgerAccountInvestorWallet, Credit: currency.FromMajor(999)},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "entry on both sides",
+			data: &LedgerJournal{
+				Entries: []*LedgerEntry{
+					{AccountCode: constant.LedgerAccountBank, Debit: currency.FromMajor(1000), Credit: currency.FromMajor(1000)},
+					{AccountCode: constant.LedgerAccountInvestorWallet, Debit: currency.FromMajor(1000), Credit: currency.FromMajor(1000)},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "missing account",
+			data: &LedgerJournal{
+				Entries: []*LedgerEntry{
+					{AccountCode: constant.LedgerAccountBank, Debit: currency.FromMajor(1000)},
+					{Credit: currency.FromMajor(1000)},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "empty",
+			data: &LedgerJournal{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.IsBalanced(); got != tt.want {
+				t.Errorf("LedgerJournal.IsBalanced() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDisbursementJournal(t *testing.T) {
+	got := NewDisbursementJournal(&Loan{
+		ID:              4,
+		Currency:        currency.IDR,
+		Amount:          currency.FromMajor(2000000),
+		OriginationFee:  currency.FromMajor(20000),
+		DisbursedAmount: currency.FromMajor(1980000),
+	})
+	want := &LedgerJournal{
+		Reference:   "disbursement:4",
+		Currency:    currency.IDR,
+		LoanID:      4,
+		Description: "loan disbursement",
+		Entries: []*LedgerEntry{
+			{AccountCode: constant.LedgerAccountLoanEscrow, Debit: currency.FromMajor(2000000)},
+			{AccountCode: constant.LedgerAccountBank, Credit: currency.FromMajor(1980000)},
+			{AccountCode: constant.LedgerAccountOriginationFeeRevenue, Credit: currency.FromMajor(20000)},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDisbursementJournal() = %v, want %v", got, want)
+	}
+	if !got.IsBalanced() {
+		t.Errorf("NewDisbursementJournal() is not balanced")
+	}
+}
+
+func TestNewRepaymentJournal(t *testing.T) {
+	got := NewRepaymentJournal(&Repayment{
+		ID:     3,
+		LoanID: 4,
+	}, []*Payout{
+		{
+			Amount:     currency.FromMajor(110500),
+			ServiceFee: currency.FromMajor(1000),
+			Tax:        currency.FromMajor(1500),
+			NetAmount:  currency.FromMajor(108000),
+		},
+		{
+			Amount:    currency.FromMajor(50000),
+			NetAmount: currency.FromMajor(50000),
+		},
+	}, currency.IDR)
+	want := &LedgerJournal{
+		Reference:   "repayment:3",
+		Currency:    currency.IDR,
+		LoanID:      4,
+		Description: "loan repayment",
+		Entries: []*LedgerEntry{
+			{AccountCode: constant.LedgerAccountBank, Debit: currency.FromMajor(160500)},
+			{AccountCode: constant.LedgerAccountInvestorWallet, Credit: currency.FromMajor(158000)},
+			{AccountCode: constant.LedgerAccountServiceFeeRevenue, Credit: currency.FromMajor(1000)},
+			{AccountCode: constant.LedgerAccountWithholdingTaxPayable, Credit: currency.FromMajor(1500)},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewRepaymentJournal() = %v, want %v", got, want)
+	}
+	if !got.IsBalanced() {
+		t.Errorf("NewRepaymentJournal() is not balanced")
+	}
+}
+
+func TestNewTrialBalance(t *testing.T) {
+	asOf := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	got := NewTrialBalance(&TrialBalanceFilter{
+		Currency: currency.IDR,
+		AsOf:     asOf,
+	}, []*TrialBalanceAccount{
+		{Code: constant.LedgerAccountBank, Type: constant.LedgerAccountTypeAsset, Debit: currency.FromMajor(1000), Credit: currency.FromMajor(400)},
+		{Code: constant.LedgerAccountInvestorWallet, Type: constant.LedgerAccountTypeLiability, Debit: currency.FromMajor(300), Credit: currency.FromMajor(1000)},
+		{Code: constant.LedgerAccountLoanEscrow, Type: constant.LedgerAccountTypeLiability, Debit: currency.FromMajor(400), Credit: currency.FromMajor(300)},
+	})
+	want := &TrialBalance{
+		Currency: currency.IDR,
+		AsOf:     asOf,
+		Accounts: []*TrialBalanceAccount{
+			{Code: constant.LedgerAccountBank, Type: constant.LedgerAccountTypeAsset, Debit: currency.FromMajor(1000), Credit: currency.FromMajor(400), Balance: currency.FromMajor(600)},
+			{Code: constant.LedgerAccountInvestorWallet, Type: constant.LedgerAccountTypeLiability, Debit: currency.FromMajor(300), Credit: currency.FromMajor(1000), Balance: currency.FromMajor(700)},
+			{Code: constant.LedgerAccountLoanEscrow, Type: constant.LedgerAccountTypeLiability, Debit: currency.FromMajor(400), Credit: currency.FromMajor(300), Balance: currency.FromMajor(-100)},
+		},
+		TotalDebit:  currency.FromMajor(1700),
+		TotalCredit: currency.FromMajor(1700),
+		IsBalanced:  true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTrialBalance() = %v, want %v", got, want)
+	}
+}
@@ -4,27 +4,46 @@ import (
 	"time"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 )
 
 type (
 	// Loan reflects loan table
 	// contains loan data by certain borrower
 	Loan struct {
-		ID                 int64               `json:"id"                             db:"id"`
-		BorrowerID         int64               `json:"borrower_id"                    db:"borrower_id"`
-		Amount             float64             `json:"amount"                          db:"amount"`
-		Rate               float64             `json:"rate"                            db:"rate"`
-		ApprovalProofURL   string              `json:"approval_proof_url,omitempty"   db:"approval_proof_url"`
-		AgreementLetterURL string              `json:"agreement_letter_url,omitempty" db:"agreement_letter_url"`
-		Status             constant.LoanStatus `json:"status"                         db:"status"`
-		CreatedBy          int64               `json:"created_by"                     db:"created_by"`
-		ApprovedBy         int64               `json:"approved_by,omitempty"          db:"approved_by"`
-		DisbursedBy        int64               `json:"disbursed_by,omitempty"         db:"disbursed_by"`
-		CreatedAt          time.Time           `json:"created_at"                     db:"created_at"`
-		UpdatedAt          time.Time           `json:"updated_at,omitempty"           db:"updated_at"`
-		ApprovedAt         time.Time           `json:"approved_at,omitempty"          db:"approved_at"`
-		InvestedAt         time.Time           `json:"invested_at,omitempty"          db:"invested_at"`
-		DisbursedAt        time.Time           `json:"disbursed_at,omitempty"         db:"disbursed_at"`
+		ID                 int64                `json:"id"                             db:"id"`
+		BorrowerID         int64                `json:"borrower_id"                    db:"borrower_id"`
+		ProductID          int64                `json:"product_id"                     db:"product_id"`
+		Currency           string               `json:"currency"                       db:"currency"`
+		Amount             currency.Amount      `json:"amount"                         db:"amount"`
+		Rate               float64              `json:"rate"                           db:"rate"`
+		Tenor              int                  `json:"tenor"                          db:"tenor"`
+		ApprovalProofURL   string               `json:"approval_proof_url,omitempty"   db:"approval_proof_url"`
+		AgreementLetterURL string               `json:"agreement_letter_url,omitempty" db:"agreement_letter_url"`
+		Status             constant.LoanStatus  `json:"status"                         db:"status"`
+		CreatedBy          int64                `json:"created_by"                     db:"created_by"`
+		ApprovedBy         int64                `json:"approved_by,omitempty"          db:"approved_by"`
+		DisbursedBy        int64                `json:"disbursed_by,omitempty"         db:"disbursed_by"`
+		RejectedBy         int64                `json:"rejected_by,omitempty"          db:"rejected_by"`
+		CancelledBy        int64                `json:"cancelled_by,omitempty"         db:"cancelled_by"`
+		Reason             string               `json:"reason,omitempty"               db:"reason"`
+		DaysPastDue        int                  `json:"days_past_due,omitempty"        db:"days_past_due"`
+		AdminFee           currency.Amount      `json:"admin_fee,omitempty"            db:"admin_fee"`
+		ProvisionFee       currency.Amount      `json:"provision_fee,omitempty"        db:"provision_fee"`
+		OriginationFee     currency.Amount      `json:"origination_fee,omitempty"      db:"origination_fee"`
+		DisbursedAmount    currency.Amount      `json:"disbursed_amount,omitempty"     db:"disbursed_amount"`
+		FundingDeadline    time.Time            `json:"funding_deadline,omitempty"     db:"funding_deadline"`
+		CreatedAt          time.Time            `json:"created_at"                     db:"created_at"`
+		UpdatedAt          time.Time            `json:"updated_at,omitempty"           db:"updated_at"`
+		ApprovedAt         time.Time            `json:"approved_at,omitempty"          db:"approved_at"`
+		InvestedAt         time.Time            `json:"invested_at,omitempty"          db:"invested_at"`
+		DisbursedAt        time.Time            `json:"disbursed_at,omitempty"         db:"disbursed_at"`
+		RejectedAt         time.Time            `json:"rejected_at,omitempty"          db:"rejected_at"`
+		CancelledAt        time.Time            `json:"cancelled_at,omitempty"         db:"cancelled_at"`
+		RepaidAt           time.Time            `json:"repaid_at,omitempty"            db:"repaid_at"`
+		DefaultedAt        time.Time            `json:"defaulted_at,omitempty"         db:"defaulted_at"`
+		ExpiredAt          time.Time            `json:"expired_at,omitempty"           db:"expired_at"`
+		AllowedActions     []*LoanAllowedAction `json:"allowed_actions"                db:"-"`
 	}
 
 	// LoanFilter stores pagination and filter used in get loan request
@@ -32,6 +51,7 @@ type (
 		DataTable      DataTableFilter
 		ID             int64
 		BorrowerID     int64
+		ProductID      int64
 		Status         constant.LoanStatus
 		CreatedAtStart time.Time
 		CreatedAtEnd   time.Time
@@ -39,6 +59,11 @@ type (
 		UpdatedAtEnd   time.Time
 		ApprovedBy     int64
 		DisbursedBy    int64
+
+		FundingDeadlineEnd time.Time
+
+		// ForUpdate locks the selected rows until the running transaction ends
+		ForUpdate bool
 	}
 
 	// LoanResult for API fetch response with pagination
@@ -53,11 +78,43 @@ type (
 		ApprovalProof   File                `json:"-"`
 		AgreementLetter File                `json:"-"`
 		Data            *Loan               `json:"data"`
+		Metadata        RequestMetadata     `json:"-"`
+		NextStatus      constant.LoanStatus `json:"-"`
 	}
 )
 
 func (data *Loan) IsValid() bool {
-	return data.BorrowerID > 0 && data.Amount > 0 && data.Rate > 0
+	return data.BorrowerID > 0 && data.Amount > 0 && data.Rate > 0 && data.Tenor > 0 &&
+		(data.Currency == "" || currency.IsSupported(data.Currency))
+}
+
+// DelinquencyBucket groups loan into a delinquency bucket based on its days past due
+func (data *Loan) DelinquencyBucket() string {
+	switch {
+	case data.DaysPastDue <= 0:
+		return constant.DelinquencyBucketCurrent
+	case data.DaysPastDue <= 30:
+		return constant.DelinquencyBucket1To30
+	case data.DaysPastDue <= 60:
+		return constant.DelinquencyBucket31To60
+	case data.DaysPastDue <= 90:
+		return constant.DelinquencyBucket61To90
+	default:
+		return constant.DelinquencyBucketOver90
+	}
+}
+
+// ChargeFees deducts the admin fee and provision fee of its product
+// and the platform origination fee of rate percent from the amount disbursed to the borrower,
+// provision fee of the product is a percentage of the loan amount, a loan without product is only charged the origination fee
+func (data *Loan) ChargeFees(product *LoanProduct, originationFeeRate float64) {
+	data.AdminFee, data.ProvisionFee = 0, 0
+	if product != nil {
+		data.AdminFee = product.AdminFee
+		data.ProvisionFee = data.Amount.Percent(product.ProvisionFee)
+	}
+	data.OriginationFee = data.Amount.Percent(originationFeeRate)
+	data.DisbursedAmount = data.Amount - data.AdminFee - data.ProvisionFee - data.OriginationFee
 }
 
 func (req *LoanProceed) IsValid() bool {
@@ -85,6 +142,7 @@ func (filter *LoanFilter) Validate() {
 	columnSortability := map[string]bool{
 		"id":          true,
 		"borrower_id": true,
+		"product_id":  true,
 		"amount":      true,
 		"rate":        true,
 		"status":      true,
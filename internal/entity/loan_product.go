@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// LoanProduct reflects loan_product table
+	// contains the limits and fees a loan created under certain product must comply with
+	LoanProduct struct {
+		ID           int64           `json:"id"            db:"id"`
+		Name         string          `json:"name"          db:"name"`
+		Currency     string          `json:"currency"      db:"currency"`
+		MinAmount    currency.Amount `json:"min_amount"    db:"min_amount"`
+		MaxAmount    currency.Amount `json:"max_amount"    db:"max_amount"`
+		MinRate      float64         `json:"min_rate"      db:"min_rate"`
+		MaxRate      float64         `json:"max_rate"      db:"max_rate"`
+		TenorOptions []int           `json:"tenor_options" db:"tenor_options"`
+		AdminFee     currency.Amount `json:"admin_fee"     db:"admin_fee"`
+		ProvisionFee float64         `json:"provision_fee" db:"provision_fee"`
+		Status       int             `json:"status"        db:"status"`
+		CreatedAt    time.Time       `json:"created_at"    db:"created_at"`
+		UpdatedAt    time.Time       `json:"updated_at"    db:"updated_at"`
+	}
+
+	// LoanProductFilter stores filter used in get loan product request
+	LoanProductFilter struct {
+		ID     int64
+		Status int
+	}
+)
+
+// IsValid returns whether the product limits are consistent,
+// fees must leave something to disburse on the smallest loan so admin fee stays below the minimum amount
+// and provision fee below 100 percent
+func (data *LoanProduct) IsValid() bool {
+	if data.Name == "" ||
+		(data.Currency != "" && !currency.IsSupported(data.Currency)) ||
+		data.MinAmount <= 0 || data.MaxAmount < data.MinAmount ||
+		data.MinRate <= 0 || data.MaxRate < data.MinRate ||
+		data.AdminFee < 0 || data.AdminFee >= data.MinAmount ||
+		data.ProvisionFee < 0 || data.ProvisionFee >= 100 ||
+		len(data.TenorOptions) <= 0 {
+		return false
+	}
+
+	for _, tenor := range data.TenorOptions {
+		if tenor <= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasTenor returns whether the given tenor is one of the product's tenor options
+func (data *LoanProduct) HasTenor(tenor int) bool {
+	for _, val := range data.TenorOptions {
+		if val == tenor {
+			return true
+		}
+	}
+
+	return false
+}
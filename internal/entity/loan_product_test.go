@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+func TestLoanProduct_IsValid(t *testing.T) {
+	valid := func() *LoanProduct {
+		return &LoanProduct{
+			Name:         "Personal Loan",
+			MinAmount:    currency.FromMajor(1000000),
+			MaxAmount:    currency.FromMajor(50000000),
+			MinRate:      8,
+			MaxRate:      15,
+			TenorOptions: []int{6, 12},
+			AdminFee:     currency.FromMajor(50000),
+			ProvisionFee: 1,
+		}
+	}
+	tests := []struct {
+		name string
+		data *LoanProduct
+		want bool
+	}{
+		{
+			name: "valid",
+			data: valid(),
+			want: true,
+		},
+		{
+			name: "invalid name",
+			data: func() *LoanProduct {
+				data := valid()
+				data.Name = ""
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "invalid amount range",
+			data: func() *LoanProduct {
+				data := valid()
+				data.MaxAmount = currency.FromMajor(500000)
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "invalid rate range",
+			data: func() *LoanProduct {
+				data := valid()
+				data.MinRate = 0
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "negative fee",
+			data: func() *LoanProduct {
+				data := valid()
+				data.AdminFee = currency.FromMajor(-1)
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "admin fee reaching minimum amount",
+			data: func() *LoanProduct {
+				data := valid()
+				data.AdminFee = data.MinAmount
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "provision fee reaching 100 percent",
+			data: func() *LoanProduct {
+				data := valid()
+				data.ProvisionFee = 100
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "empty tenor options",
+			data: func() *LoanProduct {
+				data := valid()
+				data.TenorOptions = nil
+				return data
+			}(),
+			want: false,
+		},
+		{
+			name: "invalid tenor option",
+			data: func() *LoanProduct {
+				data := valid()
+				data.TenorOptions = []int{6, 0}
+				return data
+			}(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.IsValid(); got != tt.want {
+				t.Errorf("LoanProduct.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoanProduct_HasTenor(t *testing.T) {
+	tests := []struct {
+		name  string
+		tenor int
+		want  bool
+	}{
+		{
+			name:  "listed tenor",
+			tenor: 12,
+			want:  true,
+		},
+		{
+			name:  "unlisted tenor",
+			tenor: 24,
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &LoanProduct{
+				TenorOptions: []int{6, 12},
+			}
+			if got := data.HasTenor(tt.tenor); got != tt.want {
+				t.Errorf("LoanProduct.HasTenor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
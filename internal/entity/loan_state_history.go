@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+)
+
+type (
+	// LoanStateHistory reflects loan_state_history table
+	// contains a single status transition of a loan
+	LoanStateHistory struct {
+		ID         int64               `json:"id"          db:"id"`
+		LoanID     int64               `json:"loan_id"     db:"loan_id"`
+		FromStatus constant.LoanStatus `json:"from_status" db:"from_status"`
+		ToStatus   constant.LoanStatus `json:"to_status"   db:"to_status"`
+		Action     constant.LoanAction `json:"action"      db:"action"`
+		ActorID    int64               `json:"actor_id"    db:"actor_id"`
+		Metadata   RequestMetadata     `json:"metadata"`
+		CreatedAt  time.Time           `json:"created_at"  db:"created_at"`
+	}
+
+	// RequestMetadata stores information of the request that triggers a loan status transition
+	RequestMetadata struct {
+		RequestID string `json:"request_id,omitempty" db:"request_id"`
+		IPAddress string `json:"ip_address,omitempty" db:"ip_address"`
+		UserAgent string `json:"user_agent,omitempty" db:"user_agent"`
+	}
+)
@@ -6,14 +6,16 @@ import (
 	"time"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 )
 
 func TestLoan_IsValid(t *testing.T) {
 	type fields struct {
 		ID                 int64
 		BorrowerID         int64
-		Amount             float64
+		Amount             currency.Amount
 		Rate               float64
+		Tenor              int
 		ApprovalProofURL   string
 		AgreementLetterURL string
 		Status             constant.LoanStatus
@@ -35,16 +37,26 @@ func TestLoan_IsValid(t *testing.T) {
 			name: "valid",
 			fields: fields{
 				BorrowerID: 1,
-				Amount:     10000,
+				Amount:     currency.FromMajor(10000),
 				Rate:       10,
+				Tenor:      12,
 			},
 			want: true,
 		},
+		{
+			name: "invalid tenor",
+			fields: fields{
+				BorrowerID: 1,
+				Amount:     currency.FromMajor(10000),
+				Rate:       10,
+			},
+			want: false,
+		},
 		{
 			name: "invalid",
 			fields: fields{
 				BorrowerID: 1,
-				Amount:     0,
+				Amount:     currency.FromMajor(0),
 			},
 			want: false,
 		},
@@ -56,6 +68,7 @@ func TestLoan_IsValid(t *testing.T) {
 				BorrowerID:         tt.fields.BorrowerID,
 				Amount:             tt.fields.Amount,
 				Rate:               tt.fields.Rate,
+				Tenor:              tt.fields.Tenor,
 				ApprovalProofURL:   tt.fields.ApprovalProofURL,
 				AgreementLetterURL: tt.fields.AgreementLetterURL,
 				Status:             tt.fields.Status,
@@ -75,6 +88,103 @@ func TestLoan_IsValid(t *testing.T) {
 	}
 }
 
+func TestLoan_DelinquencyBucket(t *testing.T) {
+	tests := []struct {
+		name        string
+		daysPastDue int
+		want        string
+	}{
+		{
+			name:        "current",
+			daysPastDue: 0,
+			want:        constant.DelinquencyBucketCurrent,
+		},
+		{
+			name:        "1-30",
+			daysPastDue: 30,
+			want:        constant.DelinquencyBucket1To30,
+		},
+		{
+			name:        "31-60",
+			daysPastDue: 31,
+			want:        constant.DelinquencyBucket31To60,
+		},
+		{
+			name:        "61-90",
+			daysPastDue: 90,
+			want:        constant.DelinquencyBucket61To90,
+		},
+		{
+			name:        "90+",
+			daysPastDue: 91,
+			want:        constant.DelinquencyBucketOver90,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &Loan{
+				DaysPastDue: tt.daysPastDue,
+			}
+			if got := data.DelinquencyBucket(); got != tt.want {
+				t.Errorf("Loan.DelinquencyBucket() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoan_ChargeFees(t *testing.T) {
+	tests := []struct {
+		name    string
+		product *LoanProduct
+		rate    float64
+		want    *Loan
+	}{
+		{
+			name: "with product and origination fees",
+			product: &LoanProduct{
+				AdminFee:     currency.FromMajor(25000),
+				ProvisionFee: 1,
+			},
+			rate: 1.5,
+			want: &Loan{
+				Amount:          currency.FromMajor(1000000),
+				AdminFee:        currency.FromMajor(25000),
+				ProvisionFee:    currency.FromMajor(10000),
+				OriginationFee:  currency.FromMajor(15000),
+				DisbursedAmount: currency.FromMajor(950000),
+			},
+		},
+		{
+			name: "without product",
+			rate: 1.5,
+			want: &Loan{
+				Amount:          currency.FromMajor(1000000),
+				OriginationFee:  currency.FromMajor(15000),
+				DisbursedAmount: currency.FromMajor(985000),
+			},
+		},
+		{
+			name:    "without fee",
+			product: &LoanProduct{},
+			want: &Loan{
+				Amount:          currency.FromMajor(1000000),
+				DisbursedAmount: currency.FromMajor(1000000),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &Loan{
+				Amount: currency.FromMajor(1000000),
+			}
+			data.ChargeFees(tt.product, tt.rate)
+			if !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("Loan.ChargeFees() = %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
+
 func TestLoanProceed_IsValid(t *testing.T) {
 	type fields struct {
 		Action          constant.LoanAction
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+)
+
+type (
+	// LoanTransition stores a single allowed status transition of the loan state machine
+	LoanTransition struct {
+		From   constant.LoanStatus `json:"from"`
+		Action constant.LoanAction `json:"action"`
+		To     constant.LoanStatus `json:"to"`
+		Guards []string            `json:"guards,omitempty"`
+		Hooks  []string            `json:"hooks,omitempty"`
+	}
+
+	// LoanTransitionGraph stores all allowed status transitions of the loan state machine
+	LoanTransitionGraph []*LoanTransition
+
+	// LoanAllowedAction stores an action which can be proceeded on a loan
+	// along with the form fields and files required by the proceed request
+	LoanAllowedAction struct {
+		Action    constant.LoanAction `json:"action"`
+		Name      string              `json:"name"`
+		Fields    []string            `json:"fields"`
+		Files     []string            `json:"files"`
+		Automatic bool                `json:"automatic"`
+	}
+)
+
+// DOT renders the transition graph in Graphviz DOT format,
+// statuses without any outgoing transition are drawn as terminal nodes
+func (graph LoanTransitionGraph) DOT() string {
+	var (
+		builder  = strings.Builder{}
+		statuses = []constant.LoanStatus{}
+		visited  = map[constant.LoanStatus]bool{}
+		outgoing = map[constant.LoanStatus]bool{}
+	)
+
+	for _, transition := range graph {
+		for _, status := range []constant.LoanStatus{transition.From, transition.To} {
+			if !visited[status] {
+				visited[status] = true
+				statuses = append(statuses, status)
+			}
+		}
+		outgoing[transition.From] = true
+	}
+
+	builder.WriteString("digraph loan_state {\n")
+	builder.WriteString("\trankdir=LR;\n")
+	for _, status := range statuses {
+		shape := "circle"
+		if !outgoing[status] {
+			shape = "doublecircle"
+		}
+		builder.WriteString(fmt.Sprintf("\t%q [shape=%s];\n", status.Name(), shape))
+	}
+	for _, transition := range graph {
+		label := transition.Action.Name()
+		if len(transition.Guards) > 0 {
+			label = fmt.Sprintf("%s [%s]", label, strings.Join(transition.Guards, ", "))
+		}
+		builder.WriteString(fmt.Sprintf("\t%q -> %q [label=%q];\n", transition.From.Name(), transition.To.Name(), label))
+	}
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
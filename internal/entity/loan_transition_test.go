@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+)
+
+func TestLoanTransitionGraph_DOT(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph LoanTransitionGraph
+		want  string
+	}{
+		{
+			name: "success",
+			graph: LoanTransitionGraph{
+				{
+					From:   constant.StatusProposed,
+					Action: constant.ActionApprove,
+					To:     constant.StatusApproved,
+				},
+				{
+					From:   constant.StatusProposed,
+					Action: constant.ActionReject,
+					To:     constant.StatusRejected,
+					Guards: []string{"reason_required"},
+				},
+			},
+			want: `digraph loan_state {
+	rankdir=LR;
+	"proposed" [shape=circle];
+	"approved" [shape=doublecircle];
+	"rejected" [shape=doublecircle];
+	"proposed" -> "approved" [label="approve"];
+	"proposed" -> "rejected" [label="reject [reason_required]"];
+}
+`,
+		},
+		{
+			name:  "empty",
+			graph: LoanTransitionGraph{},
+			want: `digraph loan_state {
+	rankdir=LR;
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.graph.DOT(); got != tt.want {
+				t.Errorf("LoanTransitionGraph.DOT() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+)
+
+type (
+	// Outbox reflects notification_outbox table
+	// contains a notification written along with the loan state change,
+	// which is delivered afterwards by the outbox dispatcher
+	Outbox struct {
+		ID             int64     `json:"id"              db:"id"`
+		Recipients     []string  `json:"recipients"      db:"recipients"`
+		Subject        string    `json:"subject"         db:"subject"`
+		Body           string    `json:"body"            db:"body"`
+		AttachmentName string    `json:"attachment_name" db:"attachment_name"`
+		Attachment     []byte    `json:"-"               db:"attachment"`
+		Status         int       `json:"status"          db:"status"`
+		Attempts       int       `json:"attempts"        db:"attempts"`
+		LastError      string    `json:"last_error"      db:"last_error"`
+		NextAttemptAt  time.Time `json:"next_attempt_at" db:"next_attempt_at"`
+		SentAt         time.Time `json:"sent_at"         db:"sent_at"`
+		CreatedAt      time.Time `json:"created_at"      db:"created_at"`
+		UpdatedAt      time.Time `json:"updated_at"      db:"updated_at"`
+	}
+
+	// OutboxFilter stores filter used in get outbox request
+	// for update locks the returned rows and skips the ones already locked by another dispatcher
+	OutboxFilter struct {
+		Status         int
+		NextAttemptEnd time.Time
+		Limit          int
+		ForUpdate      bool
+	}
+
+	// OutboxResult stores summary of a single outbox dispatch run
+	OutboxResult struct {
+		Evaluated int `json:"evaluated"`
+		Sent      int `json:"sent"`
+		Retried   int `json:"retried"`
+		Dead      int `json:"dead"`
+	}
+)
+
+// NewOutbox returns a pending outbox of the notifier
+func NewOutbox(notifier *Notifier) *Outbox {
+	return &Outbox{
+		Status:         constant.OutboxStatusPending,
+		Recipients:     notifier.To,
+		Subject:        notifier.Subject,
+		Body:           notifier.Body,
+		AttachmentName: notifier.Attachment.FileName,
+		Attachment:     notifier.Attachment.File,
+	}
+}
+
+// ToNotifier returns the notifier to be delivered of the outbox
+func (data *Outbox) ToNotifier() *Notifier {
+	return &Notifier{
+		To:      data.Recipients,
+		Subject: data.Subject,
+		Body:    data.Body,
+		Attachment: File{
+			File:     data.Attachment,
+			FileName: data.AttachmentName,
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+)
+
+func TestNewOutbox(t *testing.T) {
+	notifier := &Notifier{
+		To:      []string{"test@gmail.com"},
+		Subject: "Agreement Letter - Loan ID 1",
+		Body:    "body",
+		Attachment: File{
+			File:     []byte{123},
+			FileName: "agreement_letter_ole.pdf",
+		},
+	}
+	want := &Outbox{
+		Recipients:     []string{"test@gmail.com"},
+		Subject:        "Agreement Letter - Loan ID 1",
+		Body:           "body",
+		AttachmentName: "agreement_letter_ole.pdf",
+		Attachment:     []byte{123},
+		Status:         constant.OutboxStatusPending,
+	}
+
+	got := NewOutbox(notifier)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewOutbox() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(got.ToNotifier(), notifier) {
+		t.Errorf("Outbox.ToNotifier() = %v, want %v", got.ToNotifier(), notifier)
+	}
+}
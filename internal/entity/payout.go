@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// Payout reflects payout table
+	// contains the share of a borrower repayment received by certain investment
+	Payout struct {
+		ID           int64           `json:"id"            db:"id"`
+		RepaymentID  int64           `json:"repayment_id"  db:"repayment_id"`
+		InvestmentID int64           `json:"investment_id" db:"investment_id"`
+		InvestorID   int64           `json:"investor_id"   db:"investor_id"`
+		LoanID       int64           `json:"loan_id"       db:"loan_id"`
+		Principal    currency.Amount `json:"principal"     db:"principal"`
+		Interest     currency.Amount `json:"interest"      db:"interest"`
+		Penalty      currency.Amount `json:"penalty"       db:"penalty"`
+		Amount       currency.Amount `json:"amount"        db:"amount"`
+		ServiceFee   currency.Amount `json:"service_fee"   db:"service_fee"`
+		Tax          currency.Amount `json:"tax"           db:"tax"`
+		NetAmount    currency.Amount `json:"net_amount"    db:"net_amount"`
+		CreatedAt    time.Time       `json:"created_at"    db:"created_at"`
+		UpdatedAt    time.Time       `json:"updated_at"    db:"updated_at"`
+	}
+
+	// PayoutFilter stores filter used in get payout request
+	PayoutFilter struct {
+		InvestmentID int64
+		RepaymentID  int64
+		LoanID       int64
+	}
+)
+
+// Deduct charges the platform service fee and withholding tax on the interest of the payout,
+// the investor receives the net amount
+func (data *Payout) Deduct(serviceFeeRate, taxRate float64) {
+	data.ServiceFee, data.Tax = deductInterest(data.Interest, serviceFeeRate, taxRate)
+	data.NetAmount = data.Amount - data.ServiceFee - data.Tax
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+func TestPayout_Deduct(t *testing.T) {
+	type args struct {
+		serviceFeeRate float64
+		taxRate        float64
+	}
+	tests := []struct {
+		name string
+		data *Payout
+		args args
+		want *Payout
+	}{
+		{
+			name: "deducted from interest only",
+			data: &Payout{
+				Principal: currency.FromMajor(100000),
+				Interest:  currency.FromMajor(10000),
+				Penalty:   currency.FromMajor(500),
+				Amount:    currency.FromMajor(110500),
+			},
+			args: args{
+				serviceFeeRate: 10,
+				taxRate:        15,
+			},
+			want: &Payout{
+				Principal:  currency.FromMajor(100000),
+				Interest:   currency.FromMajor(10000),
+				Penalty:    currency.FromMajor(500),
+				Amount:     currency.FromMajor(110500),
+				ServiceFee: currency.FromMajor(1000),
+				Tax:        currency.FromMajor(1500),
+				NetAmount:  currency.FromMajor(108000),
+			},
+		},
+		{
+			name: "without deduction",
+			data: &Payout{
+				Principal: currency.FromMajor(100000),
+				Interest:  currency.FromMajor(10000),
+				Amount:    currency.FromMajor(110000),
+			},
+			want: &Payout{
+				Principal: currency.FromMajor(100000),
+				Interest:  currency.FromMajor(10000),
+				Amount:    currency.FromMajor(110000),
+				NetAmount: currency.FromMajor(110000),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.Deduct(tt.args.serviceFeeRate, tt.args.taxRate)
+			if !reflect.DeepEqual(tt.data, tt.want) {
+				t.Errorf("Payout.Deduct() = %v, want %v", tt.data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// PortfolioFilter stores filter used in get investor portfolio request
+	PortfolioFilter struct {
+		InvestorID int64
+		Currency   string
+	}
+
+	// Portfolio for API portfolio response
+	// contains the aggregated active investments of an investor in a currency,
+	// outstanding is the invested principal that hasn't been paid out yet
+	// and realized returns are the interest and penalty paid out so far
+	Portfolio struct {
+		InvestorID        int64                     `json:"investor_id"`
+		Currency          string                    `json:"currency"`
+		InvestmentCount   int64                     `json:"investment_count"`
+		TotalInvested     currency.Amount           `json:"total_invested"`
+		Outstanding       currency.Amount           `json:"outstanding"`
+		ExpectedReturn    currency.Amount           `json:"expected_return"`
+		ExpectedNetReturn currency.Amount           `json:"expected_net_return"`
+		RealizedPrincipal currency.Amount           `json:"realized_principal"`
+		RealizedReturn    currency.Amount           `json:"realized_return"`
+		RealizedNetReturn currency.Amount           `json:"realized_net_return"`
+		Exposures         []*PortfolioExposure      `json:"exposures"`
+		Concentrations    []*PortfolioConcentration `json:"concentrations"`
+	}
+
+	// PortfolioExposure contains the outstanding amount of the investments in loans of a status
+	PortfolioExposure struct {
+		LoanStatus      constant.LoanStatus `json:"loan_status"`
+		InvestmentCount int64               `json:"investment_count"`
+		Outstanding     currency.Amount     `json:"outstanding"`
+	}
+
+	// PortfolioConcentration contains the outstanding amount of the investments in loans of a borrower,
+	// share is its percentage of the outstanding amount of the whole portfolio
+	PortfolioConcentration struct {
+		BorrowerID      int64           `json:"borrower_id"`
+		InvestmentCount int64           `json:"investment_count"`
+		Outstanding     currency.Amount `json:"outstanding"`
+		Share           float64         `json:"share"`
+	}
+)
+
+// SetConcentrations attaches the borrower concentrations to the portfolio
+// and calculates their share of its outstanding amount rounded to 2 decimal places
+func (data *Portfolio) SetConcentrations(concentrations []*PortfolioConcentration) {
+	for _, concentration := range concentrations {
+		concentration.Share = 0
+		if data.Outstanding > 0 {
+			concentration.Share = math.Round(1e4*concentration.Outstanding.Float64()/data.Outstanding.Float64()) / 1e2
+		}
+	}
+	data.Concentrations = concentrations
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+func TestPortfolio_SetConcentrations(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           *Portfolio
+		concentrations []*PortfolioConcentration
+		want           []*PortfolioConcentration
+	}{
+		{
+			name: "success",
+			data: &Portfolio{
+				Outstanding: currency.FromMajor(3000000),
+			},
+			concentrations: []*PortfolioConcentration{
+				{BorrowerID: 1, Outstanding: currency.FromMajor(2000000)},
+				{BorrowerID: 2, Outstanding: currency.FromMajor(1000000)},
+			},
+			want: []*PortfolioConcentration{
+				{BorrowerID: 1, Outstanding: currency.FromMajor(2000000), Share: 66.67},
+				{BorrowerID: 2, Outstanding: currency.FromMajor(1000000), Share: 33.33},
+			},
+		},
+		{
+			name: "nothing outstanding",
+			data: &Portfolio{},
+			concentrations: []*PortfolioConcentration{
+				{BorrowerID: 1},
+			},
+			want: []*PortfolioConcentration{
+				{BorrowerID: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.SetConcentrations(tt.concentrations)
+			if !reflect.DeepEqual(tt.data.Concentrations, tt.want) {
+				t.Errorf("Portfolio.SetConcentrations() = %v, want %v", tt.data.Concentrations, tt.want)
+			}
+		})
+	}
+}
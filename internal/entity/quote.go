@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// LoanSimulation for API simulate request
+	// contains the terms of a hypothetical loan and optionally a hypothetical investment in it
+	LoanSimulation struct {
+		ProductID        int64           `json:"product_id"`
+		Currency         string          `json:"currency"`
+		Amount           currency.Amount `json:"amount"`
+		Rate             float64         `json:"rate"`
+		Tenor            int             `json:"tenor"`
+		InvestmentAmount currency.Amount `json:"investment_amount"`
+	}
+
+	// LoanQuote for API simulate response
+	// contains the numbers of a hypothetical loan calculated the same way a real loan would be
+	LoanQuote struct {
+		ProductID       int64             `json:"product_id"`
+		Currency        string            `json:"currency"`
+		Amount          currency.Amount   `json:"amount"`
+		Rate            float64           `json:"rate"`
+		Tenor           int               `json:"tenor"`
+		Installments    []*Installment    `json:"installments"`
+		TotalInterest   currency.Amount   `json:"total_interest"`
+		TotalPayment    currency.Amount   `json:"total_payment"`
+		AdminFee        currency.Amount   `json:"admin_fee"`
+		ProvisionFee    currency.Amount   `json:"provision_fee"`
+		OriginationFee  currency.Amount   `json:"origination_fee"`
+		NetDisbursement currency.Amount   `json:"net_disbursement"`
+		Investment      *InvestmentReturn `json:"investment,omitempty"`
+	}
+
+	// InvestmentReturn contains the projected return of an investment over the tenor of its loan,
+	// ROI is the net return in percent of the invested amount
+	InvestmentReturn struct {
+		Amount      currency.Amount `json:"amount"`
+		Return      currency.Amount `json:"return"`
+		ServiceFee  currency.Amount `json:"service_fee"`
+		Tax         currency.Amount `json:"tax"`
+		NetReturn   currency.Amount `json:"net_return"`
+		ROI         float64         `json:"roi"`
+		FinalAmount currency.Amount `json:"final_amount"`
+	}
+)
+
+func (data *LoanSimulation) IsValid() bool {
+	return data.Amount > 0 && data.Rate > 0 && data.Tenor > 0 &&
+		data.InvestmentAmount >= 0 && data.InvestmentAmount <= data.Amount &&
+		(data.Currency == "" || currency.IsSupported(data.Currency))
+}
+
+// NewLoanQuote summarizes the schedule and fees of the loan,
+// fees must be charged on the loan beforehand so the quote matches what is deducted on disbursement
+func NewLoanQuote(loan *Loan, schedule []*Installment) *LoanQuote {
+	quote := &LoanQuote{
+		ProductID:       loan.ProductID,
+		Currency:        loan.Currency,
+		Amount:          loan.Amount,
+		Rate:            loan.Rate,
+		Tenor:           loan.Tenor,
+		Installments:    schedule,
+		AdminFee:        loan.AdminFee,
+		ProvisionFee:    loan.ProvisionFee,
+		OriginationFee:  loan.OriginationFee,
+		NetDisbursement: loan.DisbursedAmount,
+	}
+	for _, installment := range schedule {
+		quote.TotalInterest += installment.Interest
+	}
+	quote.TotalPayment = loan.Amount + quote.TotalInterest
+
+	return quote
+}
+
+// NewInvestmentReturn returns the projected return of the invested amount after the platform service fee
+// and withholding tax of rate percent are deducted from it, ROI is rounded to 4 decimal places
+func NewInvestmentReturn(amount, investmentReturn currency.Amount, serviceFeeRate, taxRate float64) *InvestmentReturn {
+	serviceFee, tax := deductInterest(investmentReturn, serviceFeeRate, taxRate)
+
+	return newInvestmentReturn(amount, investmentReturn, serviceFee, tax)
+}
+
+// ProjectedReturn returns the projected return stored on the investment when it was made,
+// so it stays the same when the interest, fee or tax config changes afterwards
+func (data *Investment) ProjectedReturn() *InvestmentReturn {
+	return newInvestmentReturn(data.Amount, data.ExpectedReturn, data.ServiceFee, data.Tax)
+}
+
+func newInvestmentReturn(amount, investmentReturn, serviceFee, tax currency.Amount) *InvestmentReturn {
+	result := &InvestmentReturn{
+		Amount:     amount,
+		Return:     investmentReturn,
+		ServiceFee: serviceFee,
+		Tax:        tax,
+	}
+	result.NetReturn = investmentReturn - result.ServiceFee - result.Tax
+	result.ROI = math.Round(1e6*result.NetReturn.Float64()/amount.Float64()) / 1e4
+	result.FinalAmount = amount + result.NetReturn
+
+	return result
+}
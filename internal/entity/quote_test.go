@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+func TestLoanSimulation_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		data *LoanSimulation
+		want bool
+	}{
+		{
+			name: "valid",
+			data: &LoanSimulation{
+				Amount:           currency.FromMajor(1000000),
+				Rate:             12,
+				Tenor:            3,
+				InvestmentAmount: currency.FromMajor(1000000),
+			},
+			want: true,
+		},
+		{
+			name: "investment exceeds amount",
+			data: &LoanSimulation{
+				Amount:           currency.FromMajor(1000000),
+				Rate:             12,
+				Tenor:            3,
+				InvestmentAmount: currency.FromMajor(1000001),
+			},
+			want: false,
+		},
+		{
+			name: "unsupported currency",
+			data: &LoanSimulation{
+				Currency: "XYZ",
+				Amount:   currency.FromMajor(1000000),
+				Rate:     12,
+				Tenor:    3,
+			},
+			want: false,
+		},
+		{
+			name: "invalid tenor",
+			data: &LoanSimulation{
+				Amount: currency.FromMajor(1000000),
+				Rate:   12,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.IsValid(); got != tt.want {
+				t.Errorf("LoanSimulation.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLoanQuote(t *testing.T) {
+	schedule := []*Installment{
+		{Sequence: 1, Principal: currency.FromMajor(500000), Interest: currency.FromMajor(10000)},
+		{Sequence: 2, Principal: currency.FromMajor(500000), Interest: currency.FromMajor(5000)},
+	}
+	got := NewLoanQuote(&Loan{
+		ProductID:       1,
+		Currency:        currency.IDR,
+		Amount:          currency.FromMajor(1000000),
+		Rate:            12,
+		Tenor:           2,
+		AdminFee:        currency.FromMajor(25000),
+		ProvisionFee:    currency.FromMajor(15000),
+		OriginationFee:  currency.FromMajor(10000),
+		DisbursedAmount: currency.FromMajor(950000),
+	}, schedule)
+	want := &LoanQuote{
+		ProductID:       1,
+		Currency:        currency.IDR,
+		Amount:          currency.FromMajor(1000000),
+		Rate:            12,
+		Tenor:           2,
+		Installments:    schedule,
+		TotalInterest:   currency.FromMajor(15000),
+		TotalPayment:    currency.FromMajor(1015000),
+		AdminFee:        currency.FromMajor(25000),
+		ProvisionFee:    currency.FromMajor(15000),
+		OriginationFee:  currency.FromMajor(10000),
+		NetDisbursement: currency.FromMajor(950000),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewLoanQuote() = %v, want %v", got, want)
+	}
+}
+
+func TestNewInvestmentReturn(t *testing.T) {
+	type args struct {
+		amount           currency.Amount
+		investmentReturn currency.Amount
+		serviceFeeRate   float64
+		taxRate          float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want *InvestmentReturn
+	}{
+		{
+			name: "without deduction",
+			args: args{
+				amount:           currency.FromMajor(2000000),
+				investmentReturn: currency.Amount(10998131),
+			},
+			want: &InvestmentReturn{
+				Amount:      currency.FromMajor(2000000),
+				Return:      currency.Amount(10998131),
+				NetReturn:   currency.Amount(10998131),
+				ROI:         5.4991,
+				FinalAmount: currency.Amount(210998131),
+			},
+		},
+		{
+			name: "with service fee and withholding tax",
+			args: args{
+				amount:           currency.FromMajor(2000000),
+				investmentReturn: currency.FromMajor(100000),
+				serviceFeeRate:   10,
+				taxRate:          15,
+			},
+			want: &InvestmentReturn{
+				Amount:      currency.FromMajor(2000000),
+				Return:      currency.FromMajor(100000),
+				ServiceFee:  currency.FromMajor(10000),
+				Tax:         currency.FromMajor(15000),
+				NetReturn:   currency.FromMajor(75000),
+				ROI:         3.75,
+				FinalAmount: currency.FromMajor(2075000),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewInvestmentReturn(tt.args.amount, tt.args.investmentReturn, tt.args.serviceFeeRate, tt.args.taxRate)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewInvestmentReturn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestment_ProjectedReturn(t *testing.T) {
+	data := &Investment{
+		Amount:         currency.FromMajor(2000000),
+		ExpectedReturn: currency.FromMajor(100000),
+		ServiceFee:     currency.FromMajor(10000),
+		Tax:            currency.FromMajor(15000),
+	}
+	want := &InvestmentReturn{
+		Amount:      currency.FromMajor(2000000),
+		Return:      currency.FromMajor(100000),
+		ServiceFee:  currency.FromMajor(10000),
+		Tax:         currency.FromMajor(15000),
+		NetReturn:   currency.FromMajor(75000),
+		ROI:         3.75,
+		FinalAmount: currency.FromMajor(2075000),
+	}
+	if got := data.ProjectedReturn(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Investment.ProjectedReturn() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// Repayment reflects repayment table
+	// contains a single borrower payment and how it was allocated
+	Repayment struct {
+		ID        int64           `json:"id"         db:"id"`
+		LoanID    int64           `json:"loan_id"    db:"loan_id"`
+		Amount    currency.Amount `json:"amount"     db:"amount"`
+		Principal currency.Amount `json:"principal"  db:"principal"`
+		Interest  currency.Amount `json:"interest"   db:"interest"`
+		Penalty   currency.Amount `json:"penalty"    db:"penalty"`
+		PaidAt    time.Time       `json:"paid_at"    db:"paid_at"`
+		CreatedAt time.Time       `json:"created_at" db:"created_at"`
+		UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+	}
+)
+
+func (data *Repayment) IsValid() bool {
+	return data.LoanID > 0 && data.Amount > 0
+}
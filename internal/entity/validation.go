@@ -0,0 +1,17 @@
+package entity
+
+import "unicode"
+
+// isDigits returns whether s is a non-empty string of digits only
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.IsDigit(c) {
+			return false
+		}
+	}
+
+	return true
+}
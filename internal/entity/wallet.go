@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+type (
+	// Wallet reflects wallet table
+	// contains the balances of an investor in certain currency,
+	// held balance is placed on investments of loans still open for funding
+	// and committed balance is the amount invested in fully funded loans
+	Wallet struct {
+		ID         int64           `json:"id"          db:"id"`
+		InvestorID int64           `json:"investor_id" db:"investor_id"`
+		Currency   string          `json:"currency"    db:"currency"`
+		Available  currency.Amount `json:"available"   db:"available"`
+		Held       currency.Amount `json:"held"        db:"held"`
+		Committed  currency.Amount `json:"committed"   db:"committed"`
+		CreatedAt  time.Time       `json:"created_at"  db:"created_at"`
+		UpdatedAt  time.Time       `json:"updated_at"  db:"updated_at"`
+	}
+
+	// WalletFilter stores filter used in get wallet request
+	WalletFilter struct {
+		InvestorID int64
+		Currency   string
+		ForUpdate  bool
+	}
+
+	// WalletTransaction reflects wallet_transaction table
+	// contains every balance movement of a wallet
+	WalletTransaction struct {
+		ID        int64           `json:"id"         db:"id"`
+		WalletID  int64           `json:"wallet_id"  db:"wallet_id"`
+		LoanID    int64           `json:"loan_id"    db:"loan_id"`
+		Type      int             `json:"type"       db:"type"`
+		Amount    currency.Amount `json:"amount"     db:"amount"`
+		CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	}
+
+	// WalletDeposit for API deposit request
+	WalletDeposit struct {
+		InvestorID int64           `json:"investor_id"`
+		Currency   string          `json:"currency"`
+		Amount     currency.Amount `json:"amount"`
+	}
+)
+
+func (data *WalletDeposit) IsValid() bool {
+	return data.InvestorID > 0 && data.Amount > 0 &&
+		(data.Currency == "" || currency.IsSupported(data.Currency))
+}
+
+// Deposit adds amount to the available balance
+func (data *Wallet) Deposit(amount currency.Amount) {
+	data.Available += amount
+}
+
+// Hold moves amount from the available to the held balance,
+// it returns false without moving anything if the available balance is insufficient
+func (data *Wallet) Hold(amount currency.Amount) bool {
+	if amount > data.Available {
+		return false
+	}
+	data.Available -= amount
+	data.Held += amount
+
+	return true
+}
+
+// Release moves amount from the held back to the available balance,
+// it returns false without moving anything if the held balance is insufficient
+func (data *Wallet) Release(amount currency.Amount) bool {
+	if amount > data.Held {
+		return false
+	}
+	data.Held -= amount
+	data.Available += amount
+
+	return true
+}
+
+// Return takes the repaid principal out of the committed balance and returns the amount taken,
+// the committed balance never goes below zero since rounding residuals of payouts may exceed it by a few minor units
+func (data *Wallet) Return(amount currency.Amount) currency.Amount {
+	amount = currency.Min(amount, data.Committed)
+	data.Committed -= amount
+
+	return amount
+}
+
+// Commit moves amount from the held to the committed balance,
+// it returns false without moving anything if the held balance is insufficient
+func (data *Wallet) Commit(amount currency.Amount) bool {
+	if amount > data.Held {
+		return false
+	}
+	data.Held -= amount
+	data.Committed += amount
+
+	return true
+}
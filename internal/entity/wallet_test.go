@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+func TestWalletDeposit_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		data *WalletDeposit
+		want bool
+	}{
+		{
+			name: "valid",
+			data: &WalletDeposit{
+				InvestorID: 1,
+				Currency:   currency.IDR,
+				Amount:     currency.FromMajor(1000000),
+			},
+			want: true,
+		},
+		{
+			name: "invalid amount",
+			data: &WalletDeposit{
+				InvestorID: 1,
+			},
+			want: false,
+		},
+		{
+			name: "unsupported currency",
+			data: &WalletDeposit{
+				InvestorID: 1,
+				Currency:   "XYZ",
+				Amount:     currency.FromMajor(1000000),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.IsValid(); got != tt.want {
+				t.Errorf("WalletDeposit.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWallet_Movement(t *testing.T) {
+	wallet := &Wallet{}
+	wallet.Deposit(currency.FromMajor(1000))
+
+	if wallet.Hold(currency.FromMajor(1001)) {
+		t.Errorf("Wallet.Hold() should fail on insufficient available balance")
+	}
+	if !wallet.Hold(currency.FromMajor(600)) {
+		t.Errorf("Wallet.Hold() should succeed on sufficient available balance")
+	}
+	if wallet.Release(currency.FromMajor(601)) || wallet.Commit(currency.FromMajor(601)) {
+		t.Errorf("Wallet.Release() and Wallet.Commit() should fail on insufficient held balance")
+	}
+	if !wallet.Release(currency.FromMajor(100)) || !wallet.Commit(currency.FromMajor(500)) {
+		t.Errorf("Wallet.Release() and Wallet.Commit() should succeed on sufficient held balance")
+	}
+
+	want := &Wallet{
+		Available: currency.FromMajor(500),
+		Held:      currency.FromMajor(0),
+		Committed: currency.FromMajor(500),
+	}
+	if !reflect.DeepEqual(wallet, want) {
+		t.Errorf("Wallet = %v, want %v", wallet, want)
+	}
+}
+
+func TestWallet_Return(t *testing.T) {
+	wallet := &Wallet{
+		Committed: currency.FromMajor(500),
+	}
+
+	if got := wallet.Return(currency.FromMajor(300)); got != currency.FromMajor(300) {
+		t.Errorf("Wallet.Return() = %v, want %v", got, currency.FromMajor(300))
+	}
+	// rounding residuals never take the committed balance below zero
+	if got := wallet.Return(currency.FromMajor(201)); got != currency.FromMajor(200) {
+		t.Errorf("Wallet.Return() = %v, want %v", got, currency.FromMajor(200))
+	}
+	if wallet.Committed != 0 {
+		t.Errorf("Wallet.Committed = %v, want 0", wallet.Committed)
+	}
+}
@@ -0,0 +1,245 @@
+package borrower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the postgres error code of a violated unique index
+const uniqueViolation = "23505"
+
+// likeEscaper escapes the wildcards of a LIKE pattern so searched name is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+type (
+	// repoImpl implements Borrower interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.Borrower {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// Get will return borrower data based on filter, ordered by ID
+func (r *repoImpl) Get(
+	ctx context.Context,
+	filter *entity.BorrowerFilter,
+) ([]*entity.Borrower, error) {
+	var (
+		result  = []*entity.Borrower{}
+		builder = sqlbuilder.NewBuilder()
+		err     error
+	)
+
+	if filter.ID > 0 {
+		builder.AddWhereClause("id", "=", filter.ID)
+	}
+
+	if filter.IdentificationNumber != "" {
+		builder.AddWhereClause("identification_number", "=", filter.IdentificationNumber)
+	}
+
+	if filter.Name != "" {
+		builder.AddWhereClause("name", "ILIKE", "%"+likeEscaper.Replace(filter.Name)+"%")
+	}
+
+	if filter.Status > 0 {
+		builder.AddWhereClause("status", "=", filter.Status)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			identification_number,
+			name,
+			status,
+			created_at,
+			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp)
+		FROM
+			borrower
+		WHERE
+			1 = 1
+			%s
+		ORDER BY
+			id`,
+		builder.WhereClause(),
+	)
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, builder.Args()...)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var borrower = &entity.Borrower{}
+		err = rows.Scan(
+			&borrower.ID,
+			&borrower.IdentificationNumber,
+			&borrower.Name,
+			&borrower.Status,
+			&borrower.CreatedAt,
+			&borrower.UpdatedAt,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, borrower)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// GetDetail will return borrower data based on ID
+func (r *repoImpl) GetDetail(
+	ctx context.Context,
+	id int64,
+) (*entity.Borrower, error) {
+	list, err := r.Get(ctx, &entity.BorrowerFilter{
+		ID: id,
+	})
+	if err != nil {
+		return nil, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	if len(list) <= 0 {
+		return nil, errorwrapper.E("data does not exist", errorwrapper.CodeNotFound)
+	}
+
+	return list[0], nil
+}
+
+// Create will insert borrower data and set its ID
+func (r *repoImpl) Create(
+	ctx context.Context,
+	model *entity.Borrower,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO borrower (
+			identification_number,
+			name,
+			status,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			NOW()
+		)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		model.IdentificationNumber,
+		model.Name,
+		model.Status,
+	).Scan(&model.ID)
+	if err != nil {
+		return wrapWriteError(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// Update will update borrower data based on its ID
+func (r *repoImpl) Update(
+	ctx context.Context,
+	model *entity.Borrower,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE
+			borrower
+		SET
+			identification_number = $1,
+			name = $2,
+			status = $3,
+			updated_at = NOW()
+		WHERE
+			id = $4
+	`
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.IdentificationNumber,
+		model.Name,
+		model.Status,
+		model.ID,
+	)
+	if err != nil {
+		return wrapWriteError(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// wrapWriteError reports a concurrent registration of the same identification number
+// as invalid instead of internal, since it is caught by the unique index rather than the service check
+func wrapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errorwrapper.E("identification number is already registered", errorwrapper.CodeInvalid)
+	}
+
+	return errorwrapper.E(err, errorwrapper.CodeInternal)
+}
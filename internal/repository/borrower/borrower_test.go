@@ -0,0 +1,549 @@
+package borrower
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	type args struct {
+		client database.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want repository.Borrower
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &repoImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.args.client); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var defaultColumns = []string{
+	"id",
+	"identification_number",
+	"name",
+	"status",
+	"created_at",
+	"updated_at",
+}
+
+func Test_repoImpl_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.BorrowerFilter
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.BorrowerFilter{
+			Name: "a_1%",
+		},
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Borrower
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+								"1234567890123451",
+								"Borrower A",
+								constant.GeneralStatusActive,
+								defaultDate,
+								defaultDate,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), `%a\_1\%%`).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.Borrower{
+				{
+					ID:                   int64(1),
+					IdentificationNumber: "1234567890123451",
+					Name:                 "Borrower A",
+					Status:               constant.GeneralStatusActive,
+					CreatedAt:            defaultDate,
+					UpdatedAt:            defaultDate,
+				},
+			},
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.Borrower{},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								"1",
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.Borrower{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.Get(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_GetDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		id:  3,
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.Borrower
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+								"",
+								"",
+								constant.GeneralStatusActive,
+								defaultDate,
+								defaultDate,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), int64(3)).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: &entity.Borrower{
+				ID:                   int64(1),
+				IdentificationNumber: "",
+				Name:                 "",
+				Status:               constant.GeneralStatusActive,
+				CreatedAt:            defaultDate,
+				UpdatedAt:            defaultDate,
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.GetDetail(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.GetDetail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.GetDetail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Borrower
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			model: &entity.Borrower{
+				IdentificationNumber: "1234567890123451",
+				Name:                 "Borrower A",
+				Status:               constant.GeneralStatusActive,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantID  int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						assert.Equal(t, []interface{}{"1234567890123451", "Borrower A", constant.GeneralStatusActive}, args)
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{int64(3)})
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:   defaultArgs(),
+			wantID: 3,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on insert",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{})
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{int64(3)})
+					}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantID:  3,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.Create(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.args.model.ID != tt.wantID {
+				t.Errorf("repoImpl.Create() ID = %v, want %v", tt.args.model.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Borrower
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		model: &entity.Borrower{
+			ID:                   1,
+			IdentificationNumber: "1234567890123451",
+			Name:                 "Borrower A",
+			Status:               constant.GeneralStatusInactive,
+		},
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantErr  bool
+		wantCode errorwrapper.Code
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(&database.MockPgxTx{}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInternal,
+		},
+		{
+			name: "error on exec",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInternal,
+		},
+		{
+			name: "already registered",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, &pgconn.PgError{Code: uniqueViolation}
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInvalid,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			err := r.Update(tt.args.ctx, tt.args.model)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errx, ok := err.(*errorwrapper.Error); ok && errx.Code != tt.wantCode {
+				t.Errorf("repoImpl.Update() code = %v, want %v", errx.Code, tt.wantCode)
+			}
+		})
+	}
+}
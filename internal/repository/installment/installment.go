@@ -0,0 +1,233 @@
+package installment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// repoImpl implements Installment interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.Installment {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// Get will return installment data based on filter, ordered by sequence
+func (r *repoImpl) Get(
+	ctx context.Context,
+	filter *entity.InstallmentFilter,
+) ([]*entity.Installment, error) {
+	var (
+		result  = []*entity.Installment{}
+		builder = sqlbuilder.NewBuilder()
+		err     error
+	)
+
+	if filter.LoanID > 0 {
+		builder.AddWhereClause("loan_id", "=", filter.LoanID)
+	}
+
+	if filter.Status > 0 {
+		builder.AddWhereClause("status", "=", filter.Status)
+	}
+
+	if !filter.DueDateEnd.IsZero() {
+		builder.AddWhereClause("due_date", "<=", filter.DueDateEnd)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			loan_id,
+			sequence,
+			due_date,
+			principal,
+			interest,
+			penalty,
+			amount,
+			outstanding,
+			principal_paid,
+			interest_paid,
+			penalty_paid,
+			status,
+			COALESCE(paid_at, '0001-01-01 00:00:00'::timestamp),
+			created_at,
+			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp)
+		FROM
+			installment
+		WHERE
+			1 = 1
+			%s
+		ORDER BY
+			loan_id, sequence`,
+		builder.WhereClause(),
+	)
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, builder.Args()...)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var installment = &entity.Installment{}
+		err = rows.Scan(
+			&installment.ID,
+			&installment.LoanID,
+			&installment.Sequence,
+			&installment.DueDate,
+			&installment.Principal,
+			&installment.Interest,
+			&installment.Penalty,
+			&installment.Amount,
+			&installment.Outstanding,
+			&installment.PrincipalPaid,
+			&installment.InterestPaid,
+			&installment.PenaltyPaid,
+			&installment.Status,
+			&installment.PaidAt,
+			&installment.CreatedAt,
+			&installment.UpdatedAt,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, installment)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// CreateBulk will insert all installment data of a repayment schedule
+func (r *repoImpl) CreateBulk(
+	ctx context.Context,
+	models []*entity.Installment,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO installment (
+			loan_id,
+			sequence,
+			due_date,
+			principal,
+			interest,
+			amount,
+			outstanding,
+			status,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+		    $4,
+		    $5,
+		    $6,
+		    $7,
+		    $8,
+		    NOW()
+		)
+	`
+
+	for _, model := range models {
+		_, err = tx.Exec(
+			ctx,
+			query,
+			model.LoanID,
+			model.Sequence,
+			model.DueDate,
+			model.Principal,
+			model.Interest,
+			model.Amount,
+			model.Outstanding,
+			model.Status,
+		)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// UpdatePenalty will update accrued late penalty of all given installments
+func (r *repoImpl) UpdatePenalty(
+	ctx context.Context,
+	models []*entity.Installment,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE
+			installment
+		SET
+			penalty = $1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+	`
+
+	for _, model := range models {
+		_, err = tx.Exec(ctx, query, model.Penalty, model.ID)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
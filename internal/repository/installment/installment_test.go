@@ -0,0 +1,421 @@
+package installment
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	type args struct {
+		client database.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want repository.Installment
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &repoImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.args.client); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.InstallmentFilter
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.InstallmentFilter{
+			LoanID: 4,
+		},
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	defaultColumns := []string{
+		"id",
+		"loan_id",
+		"sequence",
+		"due_date",
+		"principal",
+		"interest",
+		"penalty",
+		"amount",
+		"outstanding",
+		"principal_paid",
+		"interest_paid",
+		"penalty_paid",
+		"status",
+		"paid_at",
+		"created_at",
+		"updated_at",
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Installment
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+								int64(4),
+								int(1),
+								defaultDate,
+								currency.FromMajor(900000),
+								currency.FromMajor(100000),
+								currency.FromMajor(0),
+								currency.FromMajor(1000000),
+								currency.FromMajor(100000),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								constant.InstallmentStatusUnpaid,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.Installment{
+				{
+					ID:          int64(1),
+					LoanID:      int64(4),
+					Sequence:    int(1),
+					DueDate:     defaultDate,
+					Principal:   currency.FromMajor(900000),
+					Interest:    currency.FromMajor(100000),
+					Amount:      currency.FromMajor(1000000),
+					Outstanding: currency.FromMajor(100000),
+					Status:      constant.InstallmentStatusUnpaid,
+					PaidAt:      defaultDate,
+					CreatedAt:   defaultDate,
+					UpdatedAt:   defaultDate,
+				},
+			},
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.Installment{},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.Installment{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.Get(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_CreateBulk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		models []*entity.Installment
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		models: []*entity.Installment{
+			{
+				LoanID:   4,
+				Sequence: 1,
+			},
+			{
+				LoanID:   4,
+				Sequence: 2,
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(&database.MockPgxTx{}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on exec",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.CreateBulk(tt.args.ctx, tt.args.models); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.CreateBulk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_UpdatePenalty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		models []*entity.Installment
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		models: []*entity.Installment{
+			{
+				ID:      1,
+				Penalty: currency.FromMajor(1500),
+			},
+			{
+				ID:      2,
+				Penalty: currency.FromMajor(500),
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(&database.MockPgxTx{}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on exec",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.UpdatePenalty(tt.args.ctx, tt.args.models); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.UpdatePenalty() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
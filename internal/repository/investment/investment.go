@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 	"github.com/ecintiawan/loan-service/pkg/database"
 	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
@@ -89,8 +91,12 @@ func (r *repoImpl) Get(
 			id,
 			investor_id,
 			loan_id,
+			currency,
 			amount,
 			roi,
+			expected_return,
+			service_fee,
+			tax,
 			status,
 			created_at,
 			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp)
@@ -126,8 +132,12 @@ func (r *repoImpl) Get(
 			&investment.ID,
 			&investment.InvestorID,
 			&investment.LoanID,
+			&investment.Currency,
 			&investment.Amount,
 			&investment.ROI,
+			&investment.ExpectedReturn,
+			&investment.ServiceFee,
+			&investment.Tax,
 			&investment.Status,
 			&investment.CreatedAt,
 			&investment.UpdatedAt,
@@ -150,9 +160,9 @@ func (r *repoImpl) Get(
 func (r *repoImpl) GetAmountSum(
 	ctx context.Context,
 	filter *entity.InvestmentFilter,
-) (float64, error) {
+) (currency.Amount, error) {
 	var (
-		result  float64
+		result  currency.Amount
 		builder = sqlbuilder.NewBuilder()
 		err     error
 	)
@@ -229,8 +239,12 @@ func (r *repoImpl) Create(
 		INSERT INTO investment (
 			investor_id,
 			loan_id,
+			currency,
 			amount,
 			roi,
+			expected_return,
+			service_fee,
+			tax,
 			status,
 			created_at
 		)
@@ -240,6 +254,10 @@ func (r *repoImpl) Create(
 			$3,
 		    $4,
 		    $5,
+		    $6,
+		    $7,
+		    $8,
+		    $9,
 		    NOW()
 		)
 	`
@@ -249,8 +267,12 @@ func (r *repoImpl) Create(
 		query,
 		model.InvestorID,
 		model.LoanID,
+		model.Currency,
 		model.Amount,
 		model.ROI,
+		model.ExpectedReturn,
+		model.ServiceFee,
+		model.Tax,
 		model.Status,
 	)
 	if err != nil {
@@ -264,3 +286,257 @@ func (r *repoImpl) Create(
 
 	return nil
 }
+
+// UpdateStatus will update investment status based on filter
+func (r *repoImpl) UpdateStatus(
+	ctx context.Context,
+	filter *entity.InvestmentFilter,
+	status int,
+) error {
+	var (
+		err     error
+		builder = sqlbuilder.NewBuilder()
+	)
+
+	// prevent updating the whole table when no identifier is given
+	if filter.ID <= 0 && filter.LoanID <= 0 {
+		return errorwrapper.E("investment or loan ID is required", errorwrapper.CodeInvalid)
+	}
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	builder.AddUpdateSetClause("status", status)
+
+	if filter.ID > 0 {
+		builder.AddWhereClause("id", "=", filter.ID)
+	}
+
+	if filter.LoanID > 0 {
+		builder.AddWhereClause("loan_id", "=", filter.LoanID)
+	}
+
+	if filter.Status > 0 {
+		builder.AddWhereClause("status", "=", filter.Status)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE
+			investment
+		SET
+			updated_at = NOW()
+			%s
+		WHERE
+			1 = 1
+			%s
+	`, builder.UpdateSetClause(), builder.WhereClause())
+	_, err = tx.Exec(ctx, query, builder.Args()...)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// paidOutJoin joins the principal and returns paid out for every investment of the investor in $1
+const paidOutJoin = `
+	LEFT JOIN (
+		SELECT
+			investment_id,
+			SUM(principal) AS principal,
+			SUM(interest + penalty) AS interest,
+			SUM(net_amount - principal) AS net_interest
+		FROM
+			payout
+		WHERE
+			investor_id = $1
+		GROUP BY
+			investment_id
+	) paid ON paid.investment_id = investment.id`
+
+// GetPortfolio will return the totals of active investments of an investor
+func (r *repoImpl) GetPortfolio(
+	ctx context.Context,
+	filter *entity.PortfolioFilter,
+) (*entity.Portfolio, error) {
+	var (
+		result = &entity.Portfolio{
+			InvestorID:     filter.InvestorID,
+			Currency:       filter.Currency,
+			Exposures:      []*entity.PortfolioExposure{},
+			Concentrations: []*entity.PortfolioConcentration{},
+		}
+		err error
+	)
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(investment.id),
+			COALESCE(SUM(investment.amount), 0),
+			COALESCE(SUM(investment.amount - COALESCE(paid.principal, 0)), 0),
+			COALESCE(SUM(investment.expected_return), 0),
+			COALESCE(SUM(investment.expected_return - investment.service_fee - investment.tax), 0),
+			COALESCE(SUM(paid.principal), 0),
+			COALESCE(SUM(paid.interest), 0),
+			COALESCE(SUM(paid.net_interest), 0)
+		FROM
+			investment
+			%s
+		WHERE
+			investment.investor_id = $1
+			AND investment.currency = $2
+			AND investment.status = $3`,
+		paidOutJoin,
+	)
+
+	err = r.client.QueryRow(ctx, query, filter.InvestorID, filter.Currency, constant.GeneralStatusActive).Scan(
+		&result.InvestmentCount,
+		&result.TotalInvested,
+		&result.Outstanding,
+		&result.ExpectedReturn,
+		&result.ExpectedNetReturn,
+		&result.RealizedPrincipal,
+		&result.RealizedReturn,
+		&result.RealizedNetReturn,
+	)
+	if err != nil {
+		return nil, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// GetExposure will return the outstanding amount of active investments of an investor grouped by loan status,
+// loan statuses without anything outstanding are left out
+func (r *repoImpl) GetExposure(
+	ctx context.Context,
+	filter *entity.PortfolioFilter,
+) ([]*entity.PortfolioExposure, error) {
+	var (
+		result = []*entity.PortfolioExposure{}
+		err    error
+	)
+
+	query := fmt.Sprintf(`
+		SELECT
+			loan.status,
+			COUNT(investment.id),
+			SUM(investment.amount - COALESCE(paid.principal, 0)) AS outstanding
+		FROM
+			investment
+			INNER JOIN loan ON loan.id = investment.loan_id
+			%s
+		WHERE
+			investment.investor_id = $1
+			AND investment.currency = $2
+			AND investment.status = $3
+		GROUP BY
+			loan.status
+		HAVING
+			SUM(investment.amount - COALESCE(paid.principal, 0)) > 0
+		ORDER BY
+			loan.status`,
+		paidOutJoin,
+	)
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, filter.InvestorID, filter.Currency, constant.GeneralStatusActive)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var exposure = &entity.PortfolioExposure{}
+		err = rows.Scan(
+			&exposure.LoanStatus,
+			&exposure.InvestmentCount,
+			&exposure.Outstanding,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, exposure)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// GetConcentration will return the outstanding amount of active investments of an investor grouped by borrower,
+// ordered from the largest outstanding amount and leaving out borrowers without anything outstanding
+func (r *repoImpl) GetConcentration(
+	ctx context.Context,
+	filter *entity.PortfolioFilter,
+) ([]*entity.PortfolioConcentration, error) {
+	var (
+		result = []*entity.PortfolioConcentration{}
+		err    error
+	)
+
+	query := fmt.Sprintf(`
+		SELECT
+			loan.borrower_id,
+			COUNT(investment.id),
+			SUM(investment.amount - COALESCE(paid.principal, 0)) AS outstanding
+		FROM
+			investment
+			INNER JOIN loan ON loan.id = investment.loan_id
+			%s
+		WHERE
+			investment.investor_id = $1
+			AND investment.currency = $2
+			AND investment.status = $3
+		GROUP BY
+			loan.borrower_id
+		HAVING
+			SUM(investment.amount - COALESCE(paid.principal, 0)) > 0
+		ORDER BY
+			outstanding DESC,
+			loan.borrower_id`,
+		paidOutJoin,
+	)
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, filter.InvestorID, filter.Currency, constant.GeneralStatusActive)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var concentration = &entity.PortfolioConcentration{}
+		err = rows.Scan(
+			&concentration.BorrowerID,
+			&concentration.InvestmentCount,
+			&concentration.Outstanding,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, concentration)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 	"github.com/ecintiawan/loan-service/pkg/database"
 	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -80,8 +81,12 @@ func Test_repoImpl_Get(t *testing.T) {
 							"id",
 							"investor_id",
 							"loan_id",
+							"currency",
 							"amount",
 							"roi",
+							"expected_return",
+							"service_fee",
+							"tax",
 							"status",
 							"created_at",
 							"updated_at",
@@ -91,8 +96,12 @@ func Test_repoImpl_Get(t *testing.T) {
 								int64(1),
 								int64(1),
 								int64(1),
-								float64(10000),
+								currency.IDR,
+								currency.FromMajor(10000),
 								float64(11000),
+								currency.FromMajor(1000),
+								currency.FromMajor(100),
+								currency.FromMajor(150),
 								constant.GeneralStatusActive,
 								defaultDate,
 								defaultDate,
@@ -115,14 +124,18 @@ func Test_repoImpl_Get(t *testing.T) {
 			want: entity.InvestmentResult{
 				List: []*entity.Investment{
 					{
-						ID:         int64(1),
-						InvestorID: int64(1),
-						LoanID:     int64(1),
-						Amount:     float64(10000),
-						ROI:        float64(11000),
-						Status:     constant.GeneralStatusActive,
-						CreatedAt:  defaultDate,
-						UpdatedAt:  defaultDate,
+						ID:             int64(1),
+						InvestorID:     int64(1),
+						LoanID:         int64(1),
+						Currency:       currency.IDR,
+						Amount:         currency.FromMajor(10000),
+						ROI:            float64(11000),
+						ExpectedReturn: currency.FromMajor(1000),
+						ServiceFee:     currency.FromMajor(100),
+						Tax:            currency.FromMajor(150),
+						Status:         constant.GeneralStatusActive,
+						CreatedAt:      defaultDate,
+						UpdatedAt:      defaultDate,
 					},
 				},
 				Pagination: entity.Pagination{
@@ -148,8 +161,12 @@ func Test_repoImpl_Get(t *testing.T) {
 							"id",
 							"investor_id",
 							"loan_id",
+							"currency",
 							"amount",
 							"roi",
+							"expected_return",
+							"service_fee",
+							"tax",
 							"status",
 							"created_at",
 							"updated_at",
@@ -159,8 +176,12 @@ func Test_repoImpl_Get(t *testing.T) {
 								int64(1),
 								int64(1),
 								int64(1),
-								float64(10000),
+								currency.IDR,
+								currency.FromMajor(10000),
 								float64(11000),
+								currency.FromMajor(1000),
+								currency.FromMajor(100),
+								currency.FromMajor(150),
 								constant.GeneralStatusActive,
 								defaultDate,
 								defaultDate,
@@ -225,7 +246,7 @@ func Test_repoImpl_GetAmountSum(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
-		want    float64
+		want    currency.Amount
 		wantErr bool
 	}{
 		{
@@ -237,7 +258,7 @@ func Test_repoImpl_GetAmountSum(t *testing.T) {
 							"sum",
 						},
 						[]interface{}{
-							float64(10000),
+							currency.FromMajor(10000),
 						},
 					)
 
@@ -250,7 +271,7 @@ func Test_repoImpl_GetAmountSum(t *testing.T) {
 				}(),
 			},
 			args: defaultArgs,
-			want: 10000,
+			want: currency.FromMajor(10000),
 		},
 	}
 	for _, tt := range tests {
@@ -375,3 +396,440 @@ func Test_repoImpl_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_repoImpl_UpdateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.InvestmentFilter
+		status int
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.InvestmentFilter{
+			LoanID: 4,
+			Status: constant.GeneralStatusActive,
+		},
+		status: constant.GeneralStatusInactive,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(&database.MockPgxTx{}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name:   "missing identifier",
+			fields: fields{},
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.InvestmentFilter{},
+				status: constant.GeneralStatusInactive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on exec",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.UpdateStatus(tt.args.ctx, tt.args.filter, tt.args.status); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.UpdateStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_GetPortfolio(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.PortfolioFilter
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.PortfolioFilter{
+			InvestorID: 1,
+			Currency:   currency.IDR,
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.Portfolio
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					row := database.NewMockPgxRow(
+						[]string{
+							"count",
+							"total_invested",
+							"outstanding",
+							"expected_return",
+							"expected_net_return",
+							"realized_principal",
+							"realized_return",
+							"realized_net_return",
+						},
+						[]interface{}{
+							int64(2),
+							currency.FromMajor(3000000),
+							currency.FromMajor(2500000),
+							currency.FromMajor(100000),
+							currency.FromMajor(75000),
+							currency.FromMajor(500000),
+							currency.FromMajor(20000),
+							currency.FromMajor(15000),
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						QueryRow(gomock.Any(), gomock.Any(), int64(1), currency.IDR, constant.GeneralStatusActive).
+						Return(row)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: &entity.Portfolio{
+				InvestorID:        1,
+				Currency:          currency.IDR,
+				InvestmentCount:   2,
+				TotalInvested:     currency.FromMajor(3000000),
+				Outstanding:       currency.FromMajor(2500000),
+				ExpectedReturn:    currency.FromMajor(100000),
+				ExpectedNetReturn: currency.FromMajor(75000),
+				RealizedPrincipal: currency.FromMajor(500000),
+				RealizedReturn:    currency.FromMajor(20000),
+				RealizedNetReturn: currency.FromMajor(15000),
+				Exposures:         []*entity.PortfolioExposure{},
+				Concentrations:    []*entity.PortfolioConcentration{},
+			},
+		},
+		{
+			name: "error on select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						QueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(database.NewMockPgxRow([]string{}, []interface{}{}))
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.GetPortfolio(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.GetPortfolio() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.GetPortfolio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_GetExposure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.PortfolioFilter
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.PortfolioFilter{
+			InvestorID: 1,
+			Currency:   currency.IDR,
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.PortfolioExposure
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						[]string{
+							"status",
+							"count",
+							"outstanding",
+						},
+						[][]interface{}{
+							{
+								constant.StatusApproved,
+								int64(1),
+								currency.FromMajor(1000000),
+							},
+							{
+								constant.StatusDisbursed,
+								int64(2),
+								currency.FromMajor(1500000),
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), int64(1), currency.IDR, constant.GeneralStatusActive).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.PortfolioExposure{
+				{
+					LoanStatus:      constant.StatusApproved,
+					InvestmentCount: 1,
+					Outstanding:     currency.FromMajor(1000000),
+				},
+				{
+					LoanStatus:      constant.StatusDisbursed,
+					InvestmentCount: 2,
+					Outstanding:     currency.FromMajor(1500000),
+				},
+			},
+		},
+		{
+			name: "error on select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.PortfolioExposure{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.GetExposure(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.GetExposure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.GetExposure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_GetConcentration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.PortfolioFilter
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.PortfolioFilter{
+			InvestorID: 1,
+			Currency:   currency.IDR,
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.PortfolioConcentration
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						[]string{
+							"borrower_id",
+							"count",
+							"outstanding",
+						},
+						[][]interface{}{
+							{
+								int64(4),
+								int64(2),
+								currency.FromMajor(2000000),
+							},
+							{
+								int64(3),
+								int64(1),
+								currency.FromMajor(500000),
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), int64(1), currency.IDR, constant.GeneralStatusActive).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.PortfolioConcentration{
+				{
+					BorrowerID:      4,
+					InvestmentCount: 2,
+					Outstanding:     currency.FromMajor(2000000),
+				},
+				{
+					BorrowerID:      3,
+					InvestmentCount: 1,
+					Outstanding:     currency.FromMajor(500000),
+				},
+			},
+		},
+		{
+			name: "error on select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.PortfolioConcentration{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.GetConcentration(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.GetConcentration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.GetConcentration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
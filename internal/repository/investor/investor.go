@@ -2,6 +2,7 @@ package investor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ecintiawan/loan-service/internal/entity"
@@ -9,8 +10,13 @@ import (
 	"github.com/ecintiawan/loan-service/pkg/database"
 	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// uniqueViolation is the postgres error code of a violated unique index
+const uniqueViolation = "23505"
+
 type (
 	// repoImpl implements Investor interface
 	repoImpl struct {
@@ -25,18 +31,33 @@ func New(client database.DB) repository.Investor {
 	}
 }
 
-// GetDetail will return investor data based on filter
-func (r *repoImpl) GetDetail(
+// Get will return investor data based on filter, ordered by ID
+func (r *repoImpl) Get(
 	ctx context.Context,
-	id int64,
-) (*entity.Investor, error) {
+	filter *entity.InvestorFilter,
+) ([]*entity.Investor, error) {
 	var (
-		result  = &entity.Investor{}
+		result  = []*entity.Investor{}
 		builder = sqlbuilder.NewBuilder()
 		err     error
 	)
 
-	builder.AddWhereClause("id", "=", id)
+	if filter.ID > 0 {
+		builder.AddWhereClause("id", "=", filter.ID)
+	}
+
+	if filter.IdentificationNumber != "" {
+		builder.AddWhereClause("identification_number", "=", filter.IdentificationNumber)
+	}
+
+	if filter.Email != "" {
+		builder.AddWhereClause("LOWER(email)", "=", filter.Email)
+	}
+
+	if filter.Status > 0 {
+		builder.AddWhereClause("status", "=", filter.Status)
+	}
+
 	query := fmt.Sprintf(`
 		SELECT
 			id,
@@ -50,23 +71,178 @@ func (r *repoImpl) GetDetail(
 			investor
 		WHERE
 			1 = 1
-			%s`,
+			%s
+		ORDER BY
+			id`,
 		builder.WhereClause(),
 	)
 
-	err = r.client.QueryRow(ctx, query, builder.Args()...).
-		Scan(
-			&result.ID,
-			&result.IdentificationNumber,
-			&result.Name,
-			&result.Email,
-			&result.Status,
-			&result.CreatedAt,
-			&result.UpdatedAt,
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, builder.Args()...)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var investor = &entity.Investor{}
+		err = rows.Scan(
+			&investor.ID,
+			&investor.IdentificationNumber,
+			&investor.Name,
+			&investor.Email,
+			&investor.Status,
+			&investor.CreatedAt,
+			&investor.UpdatedAt,
 		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, investor)
+	}
+	err = rows.Err()
 	if err != nil {
 		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
 	}
 
 	return result, nil
 }
+
+// GetDetail will return investor data based on ID
+func (r *repoImpl) GetDetail(
+	ctx context.Context,
+	id int64,
+) (*entity.Investor, error) {
+	list, err := r.Get(ctx, &entity.InvestorFilter{
+		ID: id,
+	})
+	if err != nil {
+		return nil, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	if len(list) <= 0 {
+		return nil, errorwrapper.E("data does not exist", errorwrapper.CodeNotFound)
+	}
+
+	return list[0], nil
+}
+
+// Create will insert investor data and set its ID
+func (r *repoImpl) Create(
+	ctx context.Context,
+	model *entity.Investor,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO investor (
+			identification_number,
+			name,
+			email,
+			status,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			NOW()
+		)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		model.IdentificationNumber,
+		model.Name,
+		model.Email,
+		model.Status,
+	).Scan(&model.ID)
+	if err != nil {
+		return wrapWriteError(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// Update will update investor data based on its ID
+func (r *repoImpl) Update(
+	ctx context.Context,
+	model *entity.Investor,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE
+			investor
+		SET
+			identification_number = $1,
+			name = $2,
+			email = $3,
+			status = $4,
+			updated_at = NOW()
+		WHERE
+			id = $5
+	`
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.IdentificationNumber,
+		model.Name,
+		model.Email,
+		model.Status,
+		model.ID,
+	)
+	if err != nil {
+		return wrapWriteError(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// wrapWriteError reports a concurrent registration of the same email or identification number
+// as invalid instead of internal, since it is caught by the unique indexes rather than the service check
+func wrapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errorwrapper.E("email or identification number is already registered", errorwrapper.CodeInvalid)
+	}
+
+	return errorwrapper.E(err, errorwrapper.CodeInternal)
+}
@@ -10,7 +10,11 @@ import (
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
 	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNew(t *testing.T) {
@@ -37,6 +41,140 @@ func TestNew(t *testing.T) {
 	}
 }
 
+var defaultColumns = []string{
+	"id",
+	"identification_number",
+	"name",
+	"email",
+	"status",
+	"created_at",
+	"updated_at",
+}
+
+func Test_repoImpl_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.InvestorFilter
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.InvestorFilter{
+			Email: "investor.a@example.com",
+		},
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Investor
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+								"1234567890123454",
+								"Investor A",
+								"investor.a@example.com",
+								constant.GeneralStatusActive,
+								defaultDate,
+								defaultDate,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), "investor.a@example.com").
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.Investor{
+				{
+					ID:                   int64(1),
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                "investor.a@example.com",
+					Status:               constant.GeneralStatusActive,
+					CreatedAt:            defaultDate,
+					UpdatedAt:            defaultDate,
+				},
+			},
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.Investor{},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								"1",
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.Investor{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.Get(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_repoImpl_GetDetail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -64,31 +202,25 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 			name: "success",
 			fields: fields{
 				client: func() *database.MockDB {
-					row := database.NewMockPgxRow(
-						[]string{
-							"id",
-							"identification_number",
-							"name",
-							"email",
-							"status",
-							"created_at",
-							"updated_at",
-						},
-						[]interface{}{
-							int64(1),
-							"",
-							"",
-							"",
-							constant.GeneralStatusActive,
-							defaultDate,
-							defaultDate,
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+								"",
+								"",
+								"",
+								constant.GeneralStatusActive,
+								defaultDate,
+								defaultDate,
+							},
 						},
 					)
 
 					mock := database.NewMockDB(ctrl)
 					mock.EXPECT().
-						QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).
-						Return(row)
+						Query(gomock.Any(), gomock.Any(), int64(3)).
+						Return(rows, nil)
 
 					return mock
 				}(),
@@ -104,6 +236,43 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 				UpdatedAt:            defaultDate,
 			},
 		},
+		{
+			name: "not found",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -121,3 +290,267 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 		})
 	}
 }
+
+func Test_repoImpl_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Investor
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			model: &entity.Investor{
+				IdentificationNumber: "1234567890123454",
+				Name:                 "Investor A",
+				Email:                "investor.a@example.com",
+				Status:               constant.GeneralStatusActive,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantID  int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						assert.Equal(t, []interface{}{"1234567890123454", "Investor A", "investor.a@example.com", constant.GeneralStatusActive}, args)
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{int64(3)})
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:   defaultArgs(),
+			wantID: 3,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on insert",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{})
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{int64(3)})
+					}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantID:  3,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.Create(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.args.model.ID != tt.wantID {
+				t.Errorf("repoImpl.Create() ID = %v, want %v", tt.args.model.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Investor
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		model: &entity.Investor{
+			ID:                   1,
+			IdentificationNumber: "1234567890123454",
+			Name:                 "Investor A",
+			Email:                "investor.a@example.com",
+			Status:               constant.GeneralStatusInactive,
+		},
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantErr  bool
+		wantCode errorwrapper.Code
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(&database.MockPgxTx{}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInternal,
+		},
+		{
+			name: "error on exec",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInternal,
+		},
+		{
+			name: "already registered",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, &pgconn.PgError{Code: uniqueViolation}
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInvalid,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:     defaultArgs,
+			wantErr:  true,
+			wantCode: errorwrapper.CodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			err := r.Update(tt.args.ctx, tt.args.model)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errx, ok := err.(*errorwrapper.Error); ok && errx.Code != tt.wantCode {
+				t.Errorf("repoImpl.Update() code = %v, want %v", errx.Code, tt.wantCode)
+			}
+		})
+	}
+}
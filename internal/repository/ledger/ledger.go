@@ -0,0 +1,192 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// repoImpl implements Ledger interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.Ledger {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// CreateJournal will insert a journal along with all of its entries within a single transaction,
+// a journal with a reference which has already been posted is skipped
+func (r *repoImpl) CreateJournal(
+	ctx context.Context,
+	model *entity.LedgerJournal,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO ledger_journal (
+			reference,
+			currency,
+			loan_id,
+			investor_id,
+			description,
+			created_at
+		)
+		VALUES (
+			NULLIF($1, ''),
+			$2,
+			$3,
+		    $4,
+		    $5,
+		    NOW()
+		)
+		ON CONFLICT (reference) DO NOTHING
+		RETURNING id
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		model.Reference,
+		model.Currency,
+		model.LoanID,
+		model.InvestorID,
+		model.Description,
+	).Scan(&model.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the journal of the reference has already been posted
+		err = tx.Commit(ctx)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	query = `
+		INSERT INTO ledger_entry (
+			journal_id,
+			account_code,
+			debit,
+			credit,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+		    $4,
+		    NOW()
+		)
+	`
+
+	for _, entry := range model.Entries {
+		entry.JournalID = model.ID
+		_, err = tx.Exec(
+			ctx,
+			query,
+			entry.JournalID,
+			entry.AccountCode,
+			entry.Debit,
+			entry.Credit,
+		)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// GetTrialBalance will return debit and credit totals of every ledger account based on filter, ordered by account code,
+// accounts without any entry are returned with zero totals
+func (r *repoImpl) GetTrialBalance(
+	ctx context.Context,
+	filter *entity.TrialBalanceFilter,
+) ([]*entity.TrialBalanceAccount, error) {
+	var (
+		result = []*entity.TrialBalanceAccount{}
+		err    error
+	)
+
+	query := `
+		SELECT
+			account.code,
+			account.name,
+			account.type,
+			COALESCE(SUM(entry.debit), 0),
+			COALESCE(SUM(entry.credit), 0)
+		FROM
+			ledger_account account
+			LEFT JOIN (
+				ledger_entry entry
+				INNER JOIN ledger_journal journal ON journal.id = entry.journal_id
+			) ON entry.account_code = account.code
+				AND journal.currency = $1
+				AND journal.created_at <= $2
+		GROUP BY
+			account.code,
+			account.name,
+			account.type
+		ORDER BY
+			account.code`
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, filter.Currency, filter.AsOf)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var account = &entity.TrialBalanceAccount{}
+		err = rows.Scan(
+			&account.Code,
+			&account.Name,
+			&account.Type,
+			&account.Debit,
+			&account.Credit,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, account)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
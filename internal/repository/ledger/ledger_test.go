@@ -0,0 +1,355 @@
+package ledger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	type args struct {
+		client database.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want repository.Ledger
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &repoImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.args.client); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_CreateJournal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.LedgerJournal
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			model: &entity.LedgerJournal{
+				Reference: "disbursement:4",
+				Currency:  currency.IDR,
+				LoanID:    4,
+				Entries: []*entity.LedgerEntry{
+					{AccountCode: constant.LedgerAccountLoanEscrow, Debit: currency.FromMajor(1000)},
+					{AccountCode: constant.LedgerAccountBank, Credit: currency.FromMajor(1000)},
+				},
+			},
+		}
+	}
+	returningRow := func() pgx.Row {
+		return database.NewMockPgxRow([]string{"id"}, []interface{}{int64(1)})
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantID  int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					var execCount int
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return returningRow()
+					}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						execCount++
+						assert.Equal(t, int64(1), args[0])
+						return pgconn.CommandTag{}, nil
+					}
+					tx.CommitFunc = func(ctx context.Context) error {
+						assert.Equal(t, 2, execCount)
+						return nil
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:   defaultArgs(),
+			wantID: 1,
+		},
+		{
+			name: "already posted",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{})
+					}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs(),
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on insert journal",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{"1"})
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on insert entry",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return returningRow()
+					}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantID:  1,
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return returningRow()
+					}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantID:  1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.CreateJournal(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.CreateJournal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.args.model.ID != tt.wantID {
+				t.Errorf("repoImpl.CreateJournal() ID = %v, want %v", tt.args.model.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_GetTrialBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.TrialBalanceFilter
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		filter: &entity.TrialBalanceFilter{
+			Currency: currency.IDR,
+			AsOf:     time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+		},
+	}
+	defaultColumns := []string{
+		"code",
+		"name",
+		"type",
+		"debit",
+		"credit",
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.TrialBalanceAccount
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								constant.LedgerAccountBank,
+								"Bank",
+								constant.LedgerAccountTypeAsset,
+								currency.FromMajor(1000),
+								currency.FromMajor(400),
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), currency.IDR, defaultArgs.filter.AsOf).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.TrialBalanceAccount{
+				{
+					Code:   constant.LedgerAccountBank,
+					Name:   "Bank",
+					Type:   constant.LedgerAccountTypeAsset,
+					Debit:  currency.FromMajor(1000),
+					Credit: currency.FromMajor(400),
+				},
+			},
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.TrialBalanceAccount{},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								constant.LedgerAccountBank,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.TrialBalanceAccount{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.GetTrialBalance(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.GetTrialBalance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.GetTrialBalance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
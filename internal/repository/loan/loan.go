@@ -51,6 +51,10 @@ func (r *repoImpl) Get(
 		builder.AddWhereClause("borrower_id", "=", filter.BorrowerID)
 	}
 
+	if filter.ProductID > 0 {
+		builder.AddWhereClause("product_id", "=", filter.ProductID)
+	}
+
 	if filter.Status > 0 {
 		builder.AddWhereClause("status", "=", filter.Status)
 	}
@@ -77,6 +81,10 @@ func (r *repoImpl) Get(
 		builder.AddWhereClause("disbursed_by", "=", filter.DisbursedBy)
 	}
 
+	if !filter.FundingDeadlineEnd.IsZero() {
+		builder.AddWhereClause("funding_deadline", "<=", filter.FundingDeadlineEnd)
+	}
+
 	if filter.DataTable.IsPaginated() {
 		countQuery := fmt.Sprintf(
 			`SELECT COUNT(*) FROM loan WHERE 1 = 1 %s`,
@@ -92,19 +100,36 @@ func (r *repoImpl) Get(
 		SELECT
 			id,
 			borrower_id,
+			product_id,
+			currency,
 			amount,
 			rate,
+			tenor,
 			approval_proof_url,
 			agreement_letter_url,
 			status,
 			created_by,
 			approved_by,
 			disbursed_by,
+			rejected_by,
+			cancelled_by,
+			reason,
+			days_past_due,
+			admin_fee,
+			provision_fee,
+			origination_fee,
+			disbursed_amount,
 			created_at,
 			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp),
 			COALESCE(approved_at, '0001-01-01 00:00:00'::timestamp),
 			COALESCE(invested_at, '0001-01-01 00:00:00'::timestamp),
-			COALESCE(disbursed_at, '0001-01-01 00:00:00'::timestamp)
+			COALESCE(disbursed_at, '0001-01-01 00:00:00'::timestamp),
+			COALESCE(rejected_at, '0001-01-01 00:00:00'::timestamp),
+			COALESCE(cancelled_at, '0001-01-01 00:00:00'::timestamp),
+			COALESCE(repaid_at, '0001-01-01 00:00:00'::timestamp),
+			COALESCE(defaulted_at, '0001-01-01 00:00:00'::timestamp),
+			COALESCE(funding_deadline, '0001-01-01 00:00:00'::timestamp),
+			COALESCE(expired_at, '0001-01-01 00:00:00'::timestamp)
 		FROM
 			loan
 		WHERE
@@ -124,6 +149,10 @@ func (r *repoImpl) Get(
 		)
 	}
 
+	if filter.ForUpdate {
+		query = fmt.Sprintf("%s FOR UPDATE", query)
+	}
+
 	var rows pgx.Rows
 	rows, err = r.client.Query(ctx, query, builder.Args()...)
 	if err != nil {
@@ -136,19 +165,36 @@ func (r *repoImpl) Get(
 		err = rows.Scan(
 			&loan.ID,
 			&loan.BorrowerID,
+			&loan.ProductID,
+			&loan.Currency,
 			&loan.Amount,
 			&loan.Rate,
+			&loan.Tenor,
 			&loan.ApprovalProofURL,
 			&loan.AgreementLetterURL,
 			&loan.Status,
 			&loan.CreatedBy,
 			&loan.ApprovedBy,
 			&loan.DisbursedBy,
+			&loan.RejectedBy,
+			&loan.CancelledBy,
+			&loan.Reason,
+			&loan.DaysPastDue,
+			&loan.AdminFee,
+			&loan.ProvisionFee,
+			&loan.OriginationFee,
+			&loan.DisbursedAmount,
 			&loan.CreatedAt,
 			&loan.UpdatedAt,
 			&loan.ApprovedAt,
 			&loan.InvestedAt,
 			&loan.DisbursedAt,
+			&loan.RejectedAt,
+			&loan.CancelledAt,
+			&loan.RepaidAt,
+			&loan.DefaultedAt,
+			&loan.FundingDeadline,
+			&loan.ExpiredAt,
 		)
 		if err != nil {
 			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
@@ -168,6 +214,23 @@ func (r *repoImpl) Get(
 func (r *repoImpl) GetDetail(
 	ctx context.Context,
 	id int64,
+) (*entity.Loan, error) {
+	return r.getDetail(ctx, id, false)
+}
+
+// GetDetailForUpdate will return loan data based on ID and lock its row
+// until the transaction carried by the context ends
+func (r *repoImpl) GetDetailForUpdate(
+	ctx context.Context,
+	id int64,
+) (*entity.Loan, error) {
+	return r.getDetail(ctx, id, true)
+}
+
+func (r *repoImpl) getDetail(
+	ctx context.Context,
+	id int64,
+	forUpdate bool,
 ) (*entity.Loan, error) {
 	var (
 		err error
@@ -179,7 +242,8 @@ func (r *repoImpl) GetDetail(
 				DisablePagination: true,
 			},
 		},
-		ID: id,
+		ID:        id,
+		ForUpdate: forUpdate,
 	})
 	if err != nil {
 		return nil, errorwrapper.E(err, errorwrapper.CodeInternal)
@@ -213,8 +277,11 @@ func (r *repoImpl) Create(
 	query := `
 		INSERT INTO loan (
 			borrower_id,
+			product_id,
+			currency,
 			amount,
 			rate,
+			tenor,
 			approval_proof_url,
 			agreement_letter_url,
 			status,
@@ -236,10 +303,13 @@ func (r *repoImpl) Create(
 		    $7,
 		    $8,
 		    $9,
-		    NOW(),
 		    $10,
 		    $11,
-			$12
+		    $12,
+		    NOW(),
+		    $13,
+		    $14,
+			$15
 		)
 	`
 
@@ -247,8 +317,11 @@ func (r *repoImpl) Create(
 		ctx,
 		query,
 		model.BorrowerID,
+		model.ProductID,
+		model.Currency,
 		model.Amount,
 		model.Rate,
+		model.Tenor,
 		model.ApprovalProofURL,
 		model.AgreementLetterURL,
 		model.Status,
@@ -275,6 +348,7 @@ func (r *repoImpl) Create(
 func (r *repoImpl) Update(
 	ctx context.Context,
 	model *entity.Loan,
+	history *entity.LoanStateHistory,
 ) error {
 	var (
 		err     error
@@ -315,6 +389,34 @@ func (r *repoImpl) Update(
 		builder.AddUpdateSetClause("disbursed_by", model.DisbursedBy)
 	}
 
+	if model.RejectedBy > 0 {
+		builder.AddUpdateSetClause("rejected_by", model.RejectedBy)
+	}
+
+	if model.CancelledBy > 0 {
+		builder.AddUpdateSetClause("cancelled_by", model.CancelledBy)
+	}
+
+	if model.Reason != "" {
+		builder.AddUpdateSetClause("reason", model.Reason)
+	}
+
+	if model.AdminFee > 0 {
+		builder.AddUpdateSetClause("admin_fee", model.AdminFee)
+	}
+
+	if model.ProvisionFee > 0 {
+		builder.AddUpdateSetClause("provision_fee", model.ProvisionFee)
+	}
+
+	if model.OriginationFee > 0 {
+		builder.AddUpdateSetClause("origination_fee", model.OriginationFee)
+	}
+
+	if model.DisbursedAmount > 0 {
+		builder.AddUpdateSetClause("disbursed_amount", model.DisbursedAmount)
+	}
+
 	if !model.ApprovedAt.IsZero() {
 		builder.AddUpdateSetClause("approved_at", model.ApprovedAt)
 	}
@@ -327,6 +429,30 @@ func (r *repoImpl) Update(
 		builder.AddUpdateSetClause("disbursed_at", model.DisbursedAt)
 	}
 
+	if !model.RejectedAt.IsZero() {
+		builder.AddUpdateSetClause("rejected_at", model.RejectedAt)
+	}
+
+	if !model.CancelledAt.IsZero() {
+		builder.AddUpdateSetClause("cancelled_at", model.CancelledAt)
+	}
+
+	if !model.RepaidAt.IsZero() {
+		builder.AddUpdateSetClause("repaid_at", model.RepaidAt)
+	}
+
+	if !model.DefaultedAt.IsZero() {
+		builder.AddUpdateSetClause("defaulted_at", model.DefaultedAt)
+	}
+
+	if !model.FundingDeadline.IsZero() {
+		builder.AddUpdateSetClause("funding_deadline", model.FundingDeadline)
+	}
+
+	if !model.ExpiredAt.IsZero() {
+		builder.AddUpdateSetClause("expired_at", model.ExpiredAt)
+	}
+
 	query := fmt.Sprintf(`
 		UPDATE
 			loan
@@ -342,6 +468,13 @@ func (r *repoImpl) Update(
 		return errorwrapper.E(err, errorwrapper.CodeInternal)
 	}
 
+	if history != nil {
+		err = r.createHistory(ctx, tx, history)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return errorwrapper.E(err, errorwrapper.CodeInternal)
@@ -349,3 +482,157 @@ func (r *repoImpl) Update(
 
 	return nil
 }
+
+// UpdateDaysPastDue will update the number of days the loan has been overdue
+func (r *repoImpl) UpdateDaysPastDue(
+	ctx context.Context,
+	id int64,
+	daysPastDue int,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE
+			loan
+		SET
+			days_past_due = $1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+	`
+	_, err = tx.Exec(ctx, query, daysPastDue, id)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// GetHistory will return status transition history of a loan, ordered by ID
+func (r *repoImpl) GetHistory(
+	ctx context.Context,
+	loanID int64,
+) ([]*entity.LoanStateHistory, error) {
+	var (
+		result = []*entity.LoanStateHistory{}
+		err    error
+	)
+
+	query := `
+		SELECT
+			id,
+			loan_id,
+			from_status,
+			to_status,
+			action,
+			actor_id,
+			request_id,
+			ip_address,
+			user_agent,
+			created_at
+		FROM
+			loan_state_history
+		WHERE
+			loan_id = $1
+		ORDER BY
+			id
+	`
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, loanID)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var history = &entity.LoanStateHistory{}
+		err = rows.Scan(
+			&history.ID,
+			&history.LoanID,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.Action,
+			&history.ActorID,
+			&history.Metadata.RequestID,
+			&history.Metadata.IPAddress,
+			&history.Metadata.UserAgent,
+			&history.CreatedAt,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, history)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// createHistory will insert a loan status transition within the given transaction
+func (r *repoImpl) createHistory(
+	ctx context.Context,
+	tx pgx.Tx,
+	model *entity.LoanStateHistory,
+) error {
+	query := `
+		INSERT INTO loan_state_history (
+			loan_id,
+			from_status,
+			to_status,
+			action,
+			actor_id,
+			request_id,
+			ip_address,
+			user_agent,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+		    $4,
+		    $5,
+		    $6,
+		    $7,
+		    $8,
+		    NOW()
+		)
+	`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		model.LoanID,
+		model.FromStatus,
+		model.ToStatus,
+		model.Action,
+		model.ActorID,
+		model.Metadata.RequestID,
+		model.Metadata.IPAddress,
+		model.Metadata.UserAgent,
+	)
+
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 	"github.com/ecintiawan/loan-service/pkg/database"
 	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -79,32 +80,66 @@ func Test_repoImpl_Get(t *testing.T) {
 						[]string{
 							"id",
 							"borrower_id",
+							"product_id",
+							"currency",
 							"amount",
 							"rate",
+							"tenor",
 							"approval_proof_url",
 							"agreement_letter_url",
 							"status",
 							"created_by",
 							"approved_by",
 							"disbursed_by",
+							"rejected_by",
+							"cancelled_by",
+							"reason",
+							"days_past_due",
+							"admin_fee",
+							"provision_fee",
+							"origination_fee",
+							"disbursed_amount",
 							"created_at",
 							"updated_at",
 							"approved_at",
 							"invested_at",
 							"disbursed_at",
+							"rejected_at",
+							"cancelled_at",
+							"repaid_at",
+							"defaulted_at",
+							"funding_deadline",
+							"expired_at",
 						},
 						[][]interface{}{
 							{
 								int64(1),
 								int64(1),
-								float64(10000),
+								int64(0),
+								currency.IDR,
+								currency.FromMajor(10000),
 								float64(10),
+								int(12),
 								"",
 								"",
 								constant.StatusProposed,
 								int64(1),
 								int64(1),
 								int64(1),
+								int64(0),
+								int64(0),
+								"",
+								int(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
 								defaultDate,
 								defaultDate,
 								defaultDate,
@@ -131,8 +166,10 @@ func Test_repoImpl_Get(t *testing.T) {
 					{
 						ID:                 int64(1),
 						BorrowerID:         int64(1),
-						Amount:             float64(10000),
+						Currency:           currency.IDR,
+						Amount:             currency.FromMajor(10000),
 						Rate:               float64(10),
+						Tenor:              int(12),
 						ApprovalProofURL:   "",
 						AgreementLetterURL: "",
 						Status:             constant.StatusProposed,
@@ -144,6 +181,12 @@ func Test_repoImpl_Get(t *testing.T) {
 						ApprovedAt:         defaultDate,
 						InvestedAt:         defaultDate,
 						DisbursedAt:        defaultDate,
+						RejectedAt:         defaultDate,
+						CancelledAt:        defaultDate,
+						RepaidAt:           defaultDate,
+						DefaultedAt:        defaultDate,
+						FundingDeadline:    defaultDate,
+						ExpiredAt:          defaultDate,
 					},
 				},
 				Pagination: entity.Pagination{
@@ -168,32 +211,66 @@ func Test_repoImpl_Get(t *testing.T) {
 						[]string{
 							"id",
 							"borrower_id",
+							"product_id",
+							"currency",
 							"amount",
 							"rate",
+							"tenor",
 							"approval_proof_url",
 							"agreement_letter_url",
 							"status",
 							"created_by",
 							"approved_by",
 							"disbursed_by",
+							"rejected_by",
+							"cancelled_by",
+							"reason",
+							"days_past_due",
+							"admin_fee",
+							"provision_fee",
+							"origination_fee",
+							"disbursed_amount",
 							"created_at",
 							"updated_at",
 							"approved_at",
 							"invested_at",
 							"disbursed_at",
+							"rejected_at",
+							"cancelled_at",
+							"repaid_at",
+							"defaulted_at",
+							"funding_deadline",
+							"expired_at",
 						},
 						[][]interface{}{
 							{
 								int64(1),
 								int64(1),
-								float64(10000),
+								int64(0),
+								currency.IDR,
+								currency.FromMajor(10000),
 								float64(10),
+								int(12),
 								"",
 								"",
 								constant.StatusProposed,
 								int64(1),
 								int64(1),
 								int64(1),
+								int64(0),
+								int64(0),
+								"",
+								int(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
 								defaultDate,
 								defaultDate,
 								defaultDate,
@@ -272,32 +349,66 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 						[]string{
 							"id",
 							"borrower_id",
+							"product_id",
+							"currency",
 							"amount",
 							"rate",
+							"tenor",
 							"approval_proof_url",
 							"agreement_letter_url",
 							"status",
 							"created_by",
 							"approved_by",
 							"disbursed_by",
+							"rejected_by",
+							"cancelled_by",
+							"reason",
+							"days_past_due",
+							"admin_fee",
+							"provision_fee",
+							"origination_fee",
+							"disbursed_amount",
 							"created_at",
 							"updated_at",
 							"approved_at",
 							"invested_at",
 							"disbursed_at",
+							"rejected_at",
+							"cancelled_at",
+							"repaid_at",
+							"defaulted_at",
+							"funding_deadline",
+							"expired_at",
 						},
 						[][]interface{}{
 							{
 								int64(1),
 								int64(1),
-								float64(10000),
+								int64(0),
+								currency.IDR,
+								currency.FromMajor(10000),
 								float64(10),
+								int(12),
 								"",
 								"",
 								constant.StatusProposed,
 								int64(1),
 								int64(1),
 								int64(1),
+								int64(0),
+								int64(0),
+								"",
+								int(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
 								defaultDate,
 								defaultDate,
 								defaultDate,
@@ -319,8 +430,10 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 			want: &entity.Loan{
 				ID:                 int64(1),
 				BorrowerID:         int64(1),
-				Amount:             float64(10000),
+				Currency:           currency.IDR,
+				Amount:             currency.FromMajor(10000),
 				Rate:               float64(10),
+				Tenor:              int(12),
 				ApprovalProofURL:   "",
 				AgreementLetterURL: "",
 				Status:             constant.StatusProposed,
@@ -332,6 +445,12 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 				ApprovedAt:         defaultDate,
 				InvestedAt:         defaultDate,
 				DisbursedAt:        defaultDate,
+				RejectedAt:         defaultDate,
+				CancelledAt:        defaultDate,
+				RepaidAt:           defaultDate,
+				DefaultedAt:        defaultDate,
+				FundingDeadline:    defaultDate,
+				ExpiredAt:          defaultDate,
 			},
 		},
 		{
@@ -342,32 +461,66 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 						[]string{
 							"id",
 							"borrower_id",
+							"product_id",
+							"currency",
 							"amount",
 							"rate",
+							"tenor",
 							"approval_proof_url",
 							"agreement_letter_url",
 							"status",
 							"created_by",
 							"approved_by",
 							"disbursed_by",
+							"rejected_by",
+							"cancelled_by",
+							"reason",
+							"days_past_due",
+							"admin_fee",
+							"provision_fee",
+							"origination_fee",
+							"disbursed_amount",
 							"created_at",
 							"updated_at",
 							"approved_at",
 							"invested_at",
 							"disbursed_at",
+							"rejected_at",
+							"cancelled_at",
+							"repaid_at",
+							"defaulted_at",
+							"funding_deadline",
+							"expired_at",
 						},
 						[][]interface{}{
 							{
 								int64(1),
 								int64(1),
-								float64(10000),
+								int64(0),
+								currency.IDR,
+								currency.FromMajor(10000),
 								float64(10),
+								int(12),
 								"",
 								"",
 								constant.StatusProposed,
 								int64(1),
 								int64(1),
 								int64(1),
+								int64(0),
+								int64(0),
+								"",
+								int(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
 								defaultDate,
 								defaultDate,
 								defaultDate,
@@ -407,6 +560,248 @@ func Test_repoImpl_GetDetail(t *testing.T) {
 	}
 }
 
+func Test_repoImpl_GetDetailForUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		id:  3,
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.Loan
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						[]string{
+							"id",
+							"borrower_id",
+							"product_id",
+							"currency",
+							"amount",
+							"rate",
+							"tenor",
+							"approval_proof_url",
+							"agreement_letter_url",
+							"status",
+							"created_by",
+							"approved_by",
+							"disbursed_by",
+							"rejected_by",
+							"cancelled_by",
+							"reason",
+							"days_past_due",
+							"admin_fee",
+							"provision_fee",
+							"origination_fee",
+							"disbursed_amount",
+							"created_at",
+							"updated_at",
+							"approved_at",
+							"invested_at",
+							"disbursed_at",
+							"rejected_at",
+							"cancelled_at",
+							"repaid_at",
+							"defaulted_at",
+							"funding_deadline",
+							"expired_at",
+						},
+						[][]interface{}{
+							{
+								int64(1),
+								int64(1),
+								int64(0),
+								currency.IDR,
+								currency.FromMajor(10000),
+								float64(10),
+								int(12),
+								"",
+								"",
+								constant.StatusProposed,
+								int64(1),
+								int64(1),
+								int64(1),
+								int64(0),
+								int64(0),
+								"",
+								int(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: &entity.Loan{
+				ID:                 int64(1),
+				BorrowerID:         int64(1),
+				Currency:           currency.IDR,
+				Amount:             currency.FromMajor(10000),
+				Rate:               float64(10),
+				Tenor:              int(12),
+				ApprovalProofURL:   "",
+				AgreementLetterURL: "",
+				Status:             constant.StatusProposed,
+				CreatedBy:          int64(1),
+				ApprovedBy:         int64(1),
+				DisbursedBy:        int64(1),
+				CreatedAt:          defaultDate,
+				UpdatedAt:          defaultDate,
+				ApprovedAt:         defaultDate,
+				InvestedAt:         defaultDate,
+				DisbursedAt:        defaultDate,
+				RejectedAt:         defaultDate,
+				CancelledAt:        defaultDate,
+				RepaidAt:           defaultDate,
+				DefaultedAt:        defaultDate,
+				FundingDeadline:    defaultDate,
+				ExpiredAt:          defaultDate,
+			},
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						[]string{
+							"id",
+							"borrower_id",
+							"product_id",
+							"currency",
+							"amount",
+							"rate",
+							"tenor",
+							"approval_proof_url",
+							"agreement_letter_url",
+							"status",
+							"created_by",
+							"approved_by",
+							"disbursed_by",
+							"rejected_by",
+							"cancelled_by",
+							"reason",
+							"days_past_due",
+							"admin_fee",
+							"provision_fee",
+							"origination_fee",
+							"disbursed_amount",
+							"created_at",
+							"updated_at",
+							"approved_at",
+							"invested_at",
+							"disbursed_at",
+							"rejected_at",
+							"cancelled_at",
+							"repaid_at",
+							"defaulted_at",
+							"funding_deadline",
+							"expired_at",
+						},
+						[][]interface{}{
+							{
+								int64(1),
+								int64(1),
+								int64(0),
+								currency.IDR,
+								currency.FromMajor(10000),
+								float64(10),
+								int(12),
+								"",
+								"",
+								constant.StatusProposed,
+								int64(1),
+								int64(1),
+								int64(1),
+								int64(0),
+								int64(0),
+								"",
+								int(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								currency.FromMajor(0),
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+								defaultDate,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.GetDetailForUpdate(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.GetDetailForUpdate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.GetDetailForUpdate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_repoImpl_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -521,12 +916,20 @@ func Test_repoImpl_Update(t *testing.T) {
 		client database.DB
 	}
 	type args struct {
-		ctx   context.Context
-		model *entity.Loan
+		ctx     context.Context
+		model   *entity.Loan
+		history *entity.LoanStateHistory
 	}
 	defaultArgs := args{
 		ctx:   context.Background(),
 		model: &entity.Loan{},
+		history: &entity.LoanStateHistory{
+			LoanID:     1,
+			FromStatus: constant.StatusProposed,
+			ToStatus:   constant.StatusApproved,
+			Action:     constant.ActionApprove,
+			ActorID:    1,
+		},
 	}
 	tests := []struct {
 		name    string
@@ -583,6 +986,31 @@ func Test_repoImpl_Update(t *testing.T) {
 			args:    defaultArgs,
 			wantErr: true,
 		},
+		{
+			name: "error on inserting history",
+			fields: fields{
+				client: func() *database.MockDB {
+					var execCount int
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						execCount++
+						if execCount > 1 {
+							return pgconn.CommandTag{}, assert.AnError
+						}
+						return pgconn.CommandTag{}, nil
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
 		{
 			name: "error on commit",
 			fields: fields{
@@ -612,9 +1040,259 @@ func Test_repoImpl_Update(t *testing.T) {
 			r := &repoImpl{
 				client: tt.fields.client,
 			}
-			if err := r.Update(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+			if err := r.Update(tt.args.ctx, tt.args.model, tt.args.history); (err != nil) != tt.wantErr {
 				t.Errorf("repoImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_repoImpl_UpdateDaysPastDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx         context.Context
+		id          int64
+		daysPastDue int
+	}
+	defaultArgs := args{
+		ctx:         context.Background(),
+		id:          4,
+		daysPastDue: 31,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(&database.MockPgxTx{}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on exec",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, nil
+					}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.UpdateDaysPastDue(tt.args.ctx, tt.args.id, tt.args.daysPastDue); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.UpdateDaysPastDue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_GetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		loanID int64
+	}
+	defaultArgs := args{
+		ctx:    context.Background(),
+		loanID: 4,
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	defaultColumns := []string{
+		"id",
+		"loan_id",
+		"from_status",
+		"to_status",
+		"action",
+		"actor_id",
+		"request_id",
+		"ip_address",
+		"user_agent",
+		"created_at",
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.LoanStateHistory
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+								int64(4),
+								constant.StatusProposed,
+								constant.StatusApproved,
+								constant.ActionApprove,
+								int64(2),
+								"req-1",
+								"127.0.0.1",
+								"curl/8.0",
+								defaultDate,
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), int64(4)).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.LoanStateHistory{
+				{
+					ID:         1,
+					LoanID:     4,
+					FromStatus: constant.StatusProposed,
+					ToStatus:   constant.StatusApproved,
+					Action:     constant.ActionApprove,
+					ActorID:    2,
+					Metadata: entity.RequestMetadata{
+						RequestID: "req-1",
+						IPAddress: "127.0.0.1",
+						UserAgent: "curl/8.0",
+					},
+					CreatedAt: defaultDate,
+				},
+			},
+		},
+		{
+			name: "error select",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.LoanStateHistory{},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			fields: fields{
+				client: func() *database.MockDB {
+					rows := database.NewMockPgxRows(
+						defaultColumns,
+						[][]interface{}{
+							{
+								int64(1),
+							},
+						},
+					)
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Query(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(rows, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			want:    []*entity.LoanStateHistory{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			got, err := r.GetHistory(tt.args.ctx, tt.args.loanID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.GetHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoImpl.GetHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
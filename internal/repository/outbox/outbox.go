@@ -0,0 +1,234 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// repoImpl implements Outbox interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.Outbox {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// Get will return outbox data based on filter, ordered by the next attempt
+func (r *repoImpl) Get(
+	ctx context.Context,
+	filter *entity.OutboxFilter,
+) ([]*entity.Outbox, error) {
+	var (
+		result  = []*entity.Outbox{}
+		builder = sqlbuilder.NewBuilder()
+		err     error
+	)
+
+	if filter.Status > 0 {
+		builder.AddWhereClause("status", "=", filter.Status)
+	}
+
+	if !filter.NextAttemptEnd.IsZero() {
+		builder.AddWhereClause("next_attempt_at", "<=", filter.NextAttemptEnd)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			recipients,
+			subject,
+			body,
+			COALESCE(attachment_name, ''),
+			COALESCE(attachment, ''::bytea),
+			status,
+			attempts,
+			COALESCE(last_error, ''),
+			next_attempt_at,
+			COALESCE(sent_at, '0001-01-01 00:00:00'::timestamp),
+			created_at,
+			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp)
+		FROM
+			notification_outbox
+		WHERE
+			1 = 1
+			%s
+		ORDER BY
+			next_attempt_at, id`,
+		builder.WhereClause(),
+	)
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.ForUpdate {
+		// rows locked by another dispatcher are skipped instead of waited for
+		query += " FOR UPDATE SKIP LOCKED"
+	}
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, builder.Args()...)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var outbox = &entity.Outbox{}
+		err = rows.Scan(
+			&outbox.ID,
+			&outbox.Recipients,
+			&outbox.Subject,
+			&outbox.Body,
+			&outbox.AttachmentName,
+			&outbox.Attachment,
+			&outbox.Status,
+			&outbox.Attempts,
+			&outbox.LastError,
+			&outbox.NextAttemptAt,
+			&outbox.SentAt,
+			&outbox.CreatedAt,
+			&outbox.UpdatedAt,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, outbox)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// Create will insert outbox data, it is due for delivery right away
+func (r *repoImpl) Create(
+	ctx context.Context,
+	model *entity.Outbox,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO notification_outbox (
+			recipients,
+			subject,
+			body,
+			attachment_name,
+			attachment,
+			status,
+			next_attempt_at,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			NULLIF($4, ''),
+			$5,
+			$6,
+			NOW(),
+			NOW()
+		)
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.Recipients,
+		model.Subject,
+		model.Body,
+		model.AttachmentName,
+		model.Attachment,
+		model.Status,
+	)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// Update will update delivery state of outbox data based on its ID
+func (r *repoImpl) Update(
+	ctx context.Context,
+	model *entity.Outbox,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE
+			notification_outbox
+		SET
+			status = $1,
+			attempts = $2,
+			last_error = NULLIF($3, ''),
+			next_attempt_at = $4,
+			sent_at = NULLIF($5, '0001-01-01 00:00:00'::timestamp),
+			updated_at = NOW()
+		WHERE
+			id = $6
+	`
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.Status,
+		model.Attempts,
+		model.LastError,
+		model.NextAttemptAt,
+		model.SentAt,
+		model.ID,
+	)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package payout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// repoImpl implements Payout interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.Payout {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// Get will return payout data based on filter, ordered by ID
+func (r *repoImpl) Get(
+	ctx context.Context,
+	filter *entity.PayoutFilter,
+) ([]*entity.Payout, error) {
+	var (
+		result  = []*entity.Payout{}
+		builder = sqlbuilder.NewBuilder()
+		err     error
+	)
+
+	if filter.InvestmentID > 0 {
+		builder.AddWhereClause("investment_id", "=", filter.InvestmentID)
+	}
+
+	if filter.RepaymentID > 0 {
+		builder.AddWhereClause("repayment_id", "=", filter.RepaymentID)
+	}
+
+	if filter.LoanID > 0 {
+		builder.AddWhereClause("loan_id", "=", filter.LoanID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			repayment_id,
+			investment_id,
+			investor_id,
+			loan_id,
+			principal,
+			interest,
+			penalty,
+			amount,
+			service_fee,
+			tax,
+			net_amount,
+			created_at,
+			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp)
+		FROM
+			payout
+		WHERE
+			1 = 1
+			%s
+		ORDER BY
+			id`,
+		builder.WhereClause(),
+	)
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, builder.Args()...)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payout = &entity.Payout{}
+		err = rows.Scan(
+			&payout.ID,
+			&payout.RepaymentID,
+			&payout.InvestmentID,
+			&payout.InvestorID,
+			&payout.LoanID,
+			&payout.Principal,
+			&payout.Interest,
+			&payout.Penalty,
+			&payout.Amount,
+			&payout.ServiceFee,
+			&payout.Tax,
+			&payout.NetAmount,
+			&payout.CreatedAt,
+			&payout.UpdatedAt,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, payout)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// CreateBulk will insert all payout data of a repayment
+func (r *repoImpl) CreateBulk(
+	ctx context.Context,
+	models []*entity.Payout,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO payout (
+			repayment_id,
+			investment_id,
+			investor_id,
+			loan_id,
+			principal,
+			interest,
+			penalty,
+			amount,
+			service_fee,
+			tax,
+			net_amount,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+		    $4,
+		    $5,
+		    $6,
+		    $7,
+		    $8,
+		    $9,
+		    $10,
+		    $11,
+		    NOW()
+		)
+		ON CONFLICT (repayment_id, investment_id) DO NOTHING
+	`
+
+	for _, model := range models {
+		_, err = tx.Exec(
+			ctx,
+			query,
+			model.RepaymentID,
+			model.InvestmentID,
+			model.InvestorID,
+			model.LoanID,
+			model.Principal,
+			model.Interest,
+			model.Penalty,
+			model.Amount,
+			model.ServiceFee,
+			model.Tax,
+			model.NetAmount,
+		)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// repoImpl implements LoanProduct interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.LoanProduct {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// Get will return loan product data based on filter, ordered by ID
+func (r *repoImpl) Get(
+	ctx context.Context,
+	filter *entity.LoanProductFilter,
+) ([]*entity.LoanProduct, error) {
+	var (
+		result  = []*entity.LoanProduct{}
+		builder = sqlbuilder.NewBuilder()
+		err     error
+	)
+
+	if filter.ID > 0 {
+		builder.AddWhereClause("id", "=", filter.ID)
+	}
+
+	if filter.Status > 0 {
+		builder.AddWhereClause("status", "=", filter.Status)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			name,
+			currency,
+			min_amount,
+			max_amount,
+			min_rate,
+			max_rate,
+			tenor_options,
+			admin_fee,
+			provision_fee,
+			status,
+			created_at,
+			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp)
+		FROM
+			loan_product
+		WHERE
+			1 = 1
+			%s
+		ORDER BY
+			id`,
+		builder.WhereClause(),
+	)
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, builder.Args()...)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product = &entity.LoanProduct{}
+		err = rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Currency,
+			&product.MinAmount,
+			&product.MaxAmount,
+			&product.MinRate,
+			&product.MaxRate,
+			&product.TenorOptions,
+			&product.AdminFee,
+			&product.ProvisionFee,
+			&product.Status,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, product)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// GetDetail will return loan product data based on ID
+func (r *repoImpl) GetDetail(
+	ctx context.Context,
+	id int64,
+) (*entity.LoanProduct, error) {
+	list, err := r.Get(ctx, &entity.LoanProductFilter{
+		ID: id,
+	})
+	if err != nil {
+		return nil, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	if len(list) <= 0 {
+		return nil, errorwrapper.E("data does not exist", errorwrapper.CodeNotFound)
+	}
+
+	return list[0], nil
+}
+
+// Create will insert loan product data
+func (r *repoImpl) Create(
+	ctx context.Context,
+	model *entity.LoanProduct,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO loan_product (
+			name,
+			currency,
+			min_amount,
+			max_amount,
+			min_rate,
+			max_rate,
+			tenor_options,
+			admin_fee,
+			provision_fee,
+			status,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			$9,
+			$10,
+			NOW()
+		)
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.Name,
+		model.Currency,
+		model.MinAmount,
+		model.MaxAmount,
+		model.MinRate,
+		model.MaxRate,
+		model.TenorOptions,
+		model.AdminFee,
+		model.ProvisionFee,
+		model.Status,
+	)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// Update will update loan product data based on its ID
+func (r *repoImpl) Update(
+	ctx context.Context,
+	model *entity.LoanProduct,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE
+			loan_product
+		SET
+			name = $1,
+			currency = $2,
+			min_amount = $3,
+			max_amount = $4,
+			min_rate = $5,
+			max_rate = $6,
+			tenor_options = $7,
+			admin_fee = $8,
+			provision_fee = $9,
+			status = $10,
+			updated_at = NOW()
+		WHERE
+			id = $11
+	`
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.Name,
+		model.Currency,
+		model.MinAmount,
+		model.MaxAmount,
+		model.MinRate,
+		model.MaxRate,
+		model.TenorOptions,
+		model.AdminFee,
+		model.ProvisionFee,
+		model.Status,
+		model.ID,
+	)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
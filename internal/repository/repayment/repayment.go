@@ -0,0 +1,119 @@
+package repayment
+
+import (
+	"context"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type (
+	// repoImpl implements Repayment interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.Repayment {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// Create will insert repayment data and apply its allocation
+// to the given installments within a single transaction
+func (r *repoImpl) Create(
+	ctx context.Context,
+	model *entity.Repayment,
+	installments []*entity.Installment,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO repayment (
+			loan_id,
+			amount,
+			principal,
+			interest,
+			penalty,
+			paid_at,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+		    $4,
+		    $5,
+		    $6,
+		    NOW()
+		)
+		RETURNING id
+	`
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		model.LoanID,
+		model.Amount,
+		model.Principal,
+		model.Interest,
+		model.Penalty,
+		model.PaidAt,
+	).Scan(&model.ID)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	query = `
+		UPDATE
+			installment
+		SET
+			principal_paid = $1,
+			interest_paid = $2,
+			penalty_paid = $3,
+			status = $4,
+			paid_at = NULLIF($5, '0001-01-01 00:00:00'::timestamp),
+			updated_at = NOW()
+		WHERE
+			id = $6
+	`
+
+	for _, installment := range installments {
+		_, err = tx.Exec(
+			ctx,
+			query,
+			installment.PrincipalPaid,
+			installment.InterestPaid,
+			installment.PenaltyPaid,
+			installment.Status,
+			installment.PaidAt,
+			installment.ID,
+		)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package repayment
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	type args struct {
+		client database.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want repository.Repayment
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &repoImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.args.client); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoImpl_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		client database.DB
+	}
+	type args struct {
+		ctx          context.Context
+		model        *entity.Repayment
+		installments []*entity.Installment
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			model: &entity.Repayment{
+				LoanID:    4,
+				Amount:    100000,
+				Principal: 90000,
+				Interest:  10000,
+			},
+			installments: []*entity.Installment{
+				{
+					ID:            1,
+					PrincipalPaid: 90000,
+					InterestPaid:  10000,
+					Status:        constant.InstallmentStatusUnpaid,
+				},
+			},
+		}
+	}
+	returningRow := func() pgx.Row {
+		return database.NewMockPgxRow([]string{"id"}, []interface{}{int64(1)})
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantID  int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return returningRow()
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:   defaultArgs(),
+			wantID: 1,
+		},
+		{
+			name: "error on begin",
+			fields: fields{
+				client: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on insert",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return database.NewMockPgxRow([]string{"id"}, []interface{}{})
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on update installment",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return returningRow()
+					}
+					tx.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+						return pgconn.CommandTag{}, assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantID:  1,
+			wantErr: true,
+		},
+		{
+			name: "error on commit",
+			fields: fields{
+				client: func() *database.MockDB {
+					tx := &database.MockPgxTx{}
+					tx.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+						return returningRow()
+					}
+					tx.CommitFunc = func(ctx context.Context) error {
+						return assert.AnError
+					}
+
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						Begin(gomock.Any()).
+						Return(tx, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantID:  1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repoImpl{
+				client: tt.fields.client,
+			}
+			if err := r.Create(tt.args.ctx, tt.args.model, tt.args.installments); (err != nil) != tt.wantErr {
+				t.Errorf("repoImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.args.model.ID != tt.wantID {
+				t.Errorf("repoImpl.Create() ID = %v, want %v", tt.args.model.ID, tt.wantID)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 )
 
 //go:generate mockgen -source=internal/repository/repository.go -destination=internal/repository/repository_mock.go -package=repository
@@ -22,6 +23,13 @@ type Loan interface {
 		id int64,
 	) (*entity.Loan, error)
 
+	// GetDetailForUpdate will return loan data based on ID and lock its row
+	// until the transaction carried by the context ends
+	GetDetailForUpdate(
+		ctx context.Context,
+		id int64,
+	) (*entity.Loan, error)
+
 	// Create will insert initial loan data
 	Create(
 		ctx context.Context,
@@ -29,9 +37,51 @@ type Loan interface {
 	) error
 
 	// Proceed is an action to go through all loan states for certain loan data
+	// the status transition history is recorded within the same transaction when given
 	Update(
 		ctx context.Context,
 		model *entity.Loan,
+		history *entity.LoanStateHistory,
+	) error
+
+	// UpdateDaysPastDue will update the number of days the loan has been overdue
+	UpdateDaysPastDue(
+		ctx context.Context,
+		id int64,
+		daysPastDue int,
+	) error
+
+	// GetHistory will return status transition history of a loan
+	GetHistory(
+		ctx context.Context,
+		loanID int64,
+	) ([]*entity.LoanStateHistory, error)
+}
+
+// LoanProduct encapsulates loan product related logics
+type LoanProduct interface {
+	// Get will return loan product data based on filter, ordered by ID
+	Get(
+		ctx context.Context,
+		filter *entity.LoanProductFilter,
+	) ([]*entity.LoanProduct, error)
+
+	// GetDetail will return loan product data based on ID
+	GetDetail(
+		ctx context.Context,
+		id int64,
+	) (*entity.LoanProduct, error)
+
+	// Create will insert loan product data
+	Create(
+		ctx context.Context,
+		model *entity.LoanProduct,
+	) error
+
+	// Update will update loan product data based on its ID
+	Update(
+		ctx context.Context,
+		model *entity.LoanProduct,
 	) error
 }
 
@@ -47,22 +97,181 @@ type Investment interface {
 	GetAmountSum(
 		ctx context.Context,
 		filter *entity.InvestmentFilter,
-	) (float64, error)
+	) (currency.Amount, error)
 
 	// Create will insert initial investment data
 	Create(
 		ctx context.Context,
 		model *entity.Investment,
 	) error
+
+	// UpdateStatus will update investment status based on filter
+	UpdateStatus(
+		ctx context.Context,
+		filter *entity.InvestmentFilter,
+		status int,
+	) error
+
+	// GetPortfolio will return the totals of active investments of an investor
+	GetPortfolio(
+		ctx context.Context,
+		filter *entity.PortfolioFilter,
+	) (*entity.Portfolio, error)
+
+	// GetExposure will return the outstanding amount of active investments of an investor grouped by loan status
+	GetExposure(
+		ctx context.Context,
+		filter *entity.PortfolioFilter,
+	) ([]*entity.PortfolioExposure, error)
+
+	// GetConcentration will return the outstanding amount of active investments of an investor grouped by borrower
+	GetConcentration(
+		ctx context.Context,
+		filter *entity.PortfolioFilter,
+	) ([]*entity.PortfolioConcentration, error)
+}
+
+// Installment encapsulates installment related logics
+type Installment interface {
+	// Get will return installment data based on filter, ordered by sequence
+	Get(
+		ctx context.Context,
+		filter *entity.InstallmentFilter,
+	) ([]*entity.Installment, error)
+
+	// CreateBulk will insert all installment data of a repayment schedule
+	CreateBulk(
+		ctx context.Context,
+		models []*entity.Installment,
+	) error
+
+	// UpdatePenalty will update accrued late penalty of all given installments
+	UpdatePenalty(
+		ctx context.Context,
+		models []*entity.Installment,
+	) error
+}
+
+// Repayment encapsulates repayment related logics
+type Repayment interface {
+	// Create will insert repayment data and apply its allocation
+	// to the given installments within a single transaction
+	Create(
+		ctx context.Context,
+		model *entity.Repayment,
+		installments []*entity.Installment,
+	) error
+}
+
+// Payout encapsulates investor payout related logics
+type Payout interface {
+	// Get will return payout data based on filter, ordered by ID
+	Get(
+		ctx context.Context,
+		filter *entity.PayoutFilter,
+	) ([]*entity.Payout, error)
+
+	// CreateBulk will insert all payout data of a repayment
+	CreateBulk(
+		ctx context.Context,
+		models []*entity.Payout,
+	) error
+}
+
+// Borrower encapsulates borrower related logics
+type Borrower interface {
+	// Get will return borrower data based on filter, ordered by ID
+	// name filter matches borrowers whose name contains it regardless of case
+	Get(
+		ctx context.Context,
+		filter *entity.BorrowerFilter,
+	) ([]*entity.Borrower, error)
+
+	// GetDetail will return borrower data based on ID
+	GetDetail(
+		ctx context.Context,
+		id int64,
+	) (*entity.Borrower, error)
+
+	// Create will insert borrower data
+	Create(
+		ctx context.Context,
+		model *entity.Borrower,
+	) error
+
+	// Update will update borrower data based on its ID
+	Update(
+		ctx context.Context,
+		model *entity.Borrower,
+	) error
 }
 
 // Investor encapsulates investor related logics
 type Investor interface {
-	// GetDetail will return investor data based on filter
+	// Get will return investor data based on filter, ordered by ID
+	Get(
+		ctx context.Context,
+		filter *entity.InvestorFilter,
+	) ([]*entity.Investor, error)
+
+	// GetDetail will return investor data based on ID
 	GetDetail(
 		ctx context.Context,
 		id int64,
 	) (*entity.Investor, error)
+
+	// Create will insert investor data
+	Create(
+		ctx context.Context,
+		model *entity.Investor,
+	) error
+
+	// Update will update investor data based on its ID
+	Update(
+		ctx context.Context,
+		model *entity.Investor,
+	) error
+}
+
+// Wallet encapsulates investor wallet related logics
+type Wallet interface {
+	// Get will return wallet data based on filter, ordered by ID
+	// the rows stay locked until the transaction carried by the context ends when requested
+	Get(
+		ctx context.Context,
+		filter *entity.WalletFilter,
+	) ([]*entity.Wallet, error)
+
+	// Create will insert an empty wallet unless the investor already has one in the same currency
+	Create(
+		ctx context.Context,
+		model *entity.Wallet,
+	) error
+
+	// Update will update wallet balances based on its ID
+	// the balance movement is recorded within the same transaction
+	Update(
+		ctx context.Context,
+		model *entity.Wallet,
+		transaction *entity.WalletTransaction,
+	) error
+}
+
+// Ledger encapsulates double-entry ledger related logics,
+// the ledger is append-only so posted journals are never updated nor deleted
+type Ledger interface {
+	// CreateJournal will insert a journal along with all of its entries within a single transaction,
+	// a journal with a reference which has already been posted is skipped
+	CreateJournal(
+		ctx context.Context,
+		model *entity.LedgerJournal,
+	) error
+
+	// GetTrialBalance will return debit and credit totals of every ledger account based on filter, ordered by account code
+	GetTrialBalance(
+		ctx context.Context,
+		filter *entity.TrialBalanceFilter,
+	) ([]*entity.TrialBalanceAccount, error)
 }
 
 // Upload encapsulates upload related logics
@@ -82,3 +291,24 @@ type Notifier interface {
 		model *entity.Notifier,
 	) error
 }
+
+// Outbox encapsulates notification outbox related logics
+type Outbox interface {
+	// Get will return outbox data based on filter, ordered by the next attempt
+	Get(
+		ctx context.Context,
+		filter *entity.OutboxFilter,
+	) ([]*entity.Outbox, error)
+
+	// Create will insert outbox data
+	Create(
+		ctx context.Context,
+		model *entity.Outbox,
+	) error
+
+	// Update will update delivery state of outbox data
+	Update(
+		ctx context.Context,
+		model *entity.Outbox,
+	) error
+}
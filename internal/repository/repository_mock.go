@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	entity "github.com/ecintiawan/loan-service/internal/entity"
+	currency "github.com/ecintiawan/loan-service/pkg/currency"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -79,8 +80,133 @@ func (mr *MockLoanMockRecorder) GetDetail(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockLoan)(nil).GetDetail), ctx, id)
 }
 
+// GetDetailForUpdate mocks base method.
+func (m *MockLoan) GetDetailForUpdate(ctx context.Context, id int64) (*entity.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetailForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetailForUpdate indicates an expected call of GetDetailForUpdate.
+func (mr *MockLoanMockRecorder) GetDetailForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailForUpdate", reflect.TypeOf((*MockLoan)(nil).GetDetailForUpdate), ctx, id)
+}
+
+// GetHistory mocks base method.
+func (m *MockLoan) GetHistory(ctx context.Context, loanID int64) ([]*entity.LoanStateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, loanID)
+	ret0, _ := ret[0].([]*entity.LoanStateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockLoanMockRecorder) GetHistory(ctx, loanID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockLoan)(nil).GetHistory), ctx, loanID)
+}
+
 // Update mocks base method.
-func (m *MockLoan) Update(ctx context.Context, model *entity.Loan) error {
+func (m *MockLoan) Update(ctx context.Context, model *entity.Loan, history *entity.LoanStateHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model, history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLoanMockRecorder) Update(ctx, model, history interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLoan)(nil).Update), ctx, model, history)
+}
+
+// UpdateDaysPastDue mocks base method.
+func (m *MockLoan) UpdateDaysPastDue(ctx context.Context, id int64, daysPastDue int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDaysPastDue", ctx, id, daysPastDue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDaysPastDue indicates an expected call of UpdateDaysPastDue.
+func (mr *MockLoanMockRecorder) UpdateDaysPastDue(ctx, id, daysPastDue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDaysPastDue", reflect.TypeOf((*MockLoan)(nil).UpdateDaysPastDue), ctx, id, daysPastDue)
+}
+
+// MockLoanProduct is a mock of LoanProduct interface.
+type MockLoanProduct struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanProductMockRecorder
+}
+
+// MockLoanProductMockRecorder is the mock recorder for MockLoanProduct.
+type MockLoanProductMockRecorder struct {
+	mock *MockLoanProduct
+}
+
+// NewMockLoanProduct creates a new mock instance.
+func NewMockLoanProduct(ctrl *gomock.Controller) *MockLoanProduct {
+	mock := &MockLoanProduct{ctrl: ctrl}
+	mock.recorder = &MockLoanProductMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanProduct) EXPECT() *MockLoanProductMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLoanProduct) Create(ctx context.Context, model *entity.LoanProduct) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoanProductMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoanProduct)(nil).Create), ctx, model)
+}
+
+// Get mocks base method.
+func (m *MockLoanProduct) Get(ctx context.Context, filter *entity.LoanProductFilter) ([]*entity.LoanProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.LoanProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoanProductMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoanProduct)(nil).Get), ctx, filter)
+}
+
+// GetDetail mocks base method.
+func (m *MockLoanProduct) GetDetail(ctx context.Context, id int64) (*entity.LoanProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetail", ctx, id)
+	ret0, _ := ret[0].(*entity.LoanProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetail indicates an expected call of GetDetail.
+func (mr *MockLoanProductMockRecorder) GetDetail(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockLoanProduct)(nil).GetDetail), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockLoanProduct) Update(ctx context.Context, model *entity.LoanProduct) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, model)
 	ret0, _ := ret[0].(error)
@@ -88,9 +214,9 @@ func (m *MockLoan) Update(ctx context.Context, model *entity.Loan) error {
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockLoanMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+func (mr *MockLoanProductMockRecorder) Update(ctx, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLoan)(nil).Update), ctx, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLoanProduct)(nil).Update), ctx, model)
 }
 
 // MockInvestment is a mock of Investment interface.
@@ -146,10 +272,10 @@ func (mr *MockInvestmentMockRecorder) Get(ctx, filter interface{}) *gomock.Call
 }
 
 // GetAmountSum mocks base method.
-func (m *MockInvestment) GetAmountSum(ctx context.Context, filter *entity.InvestmentFilter) (float64, error) {
+func (m *MockInvestment) GetAmountSum(ctx context.Context, filter *entity.InvestmentFilter) (currency.Amount, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAmountSum", ctx, filter)
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(currency.Amount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -160,6 +286,301 @@ func (mr *MockInvestmentMockRecorder) GetAmountSum(ctx, filter interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAmountSum", reflect.TypeOf((*MockInvestment)(nil).GetAmountSum), ctx, filter)
 }
 
+// GetConcentration mocks base method.
+func (m *MockInvestment) GetConcentration(ctx context.Context, filter *entity.PortfolioFilter) ([]*entity.PortfolioConcentration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConcentration", ctx, filter)
+	ret0, _ := ret[0].([]*entity.PortfolioConcentration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConcentration indicates an expected call of GetConcentration.
+func (mr *MockInvestmentMockRecorder) GetConcentration(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConcentration", reflect.TypeOf((*MockInvestment)(nil).GetConcentration), ctx, filter)
+}
+
+// GetExposure mocks base method.
+func (m *MockInvestment) GetExposure(ctx context.Context, filter *entity.PortfolioFilter) ([]*entity.PortfolioExposure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExposure", ctx, filter)
+	ret0, _ := ret[0].([]*entity.PortfolioExposure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExposure indicates an expected call of GetExposure.
+func (mr *MockInvestmentMockRecorder) GetExposure(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExposure", reflect.TypeOf((*MockInvestment)(nil).GetExposure), ctx, filter)
+}
+
+// GetPortfolio mocks base method.
+func (m *MockInvestment) GetPortfolio(ctx context.Context, filter *entity.PortfolioFilter) (*entity.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, filter)
+	ret0, _ := ret[0].(*entity.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockInvestmentMockRecorder) GetPortfolio(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockInvestment)(nil).GetPortfolio), ctx, filter)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockInvestment) UpdateStatus(ctx context.Context, filter *entity.InvestmentFilter, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, filter, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockInvestmentMockRecorder) UpdateStatus(ctx, filter, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockInvestment)(nil).UpdateStatus), ctx, filter, status)
+}
+
+// MockInstallment is a mock of Installment interface.
+type MockInstallment struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstallmentMockRecorder
+}
+
+// MockInstallmentMockRecorder is the mock recorder for MockInstallment.
+type MockInstallmentMockRecorder struct {
+	mock *MockInstallment
+}
+
+// NewMockInstallment creates a new mock instance.
+func NewMockInstallment(ctrl *gomock.Controller) *MockInstallment {
+	mock := &MockInstallment{ctrl: ctrl}
+	mock.recorder = &MockInstallmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstallment) EXPECT() *MockInstallmentMockRecorder {
+	return m.recorder
+}
+
+// CreateBulk mocks base method.
+func (m *MockInstallment) CreateBulk(ctx context.Context, models []*entity.Installment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBulk", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBulk indicates an expected call of CreateBulk.
+func (mr *MockInstallmentMockRecorder) CreateBulk(ctx, models interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBulk", reflect.TypeOf((*MockInstallment)(nil).CreateBulk), ctx, models)
+}
+
+// Get mocks base method.
+func (m *MockInstallment) Get(ctx context.Context, filter *entity.InstallmentFilter) ([]*entity.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInstallmentMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInstallment)(nil).Get), ctx, filter)
+}
+
+// UpdatePenalty mocks base method.
+func (m *MockInstallment) UpdatePenalty(ctx context.Context, models []*entity.Installment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePenalty", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePenalty indicates an expected call of UpdatePenalty.
+func (mr *MockInstallmentMockRecorder) UpdatePenalty(ctx, models interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePenalty", reflect.TypeOf((*MockInstallment)(nil).UpdatePenalty), ctx, models)
+}
+
+// MockRepayment is a mock of Repayment interface.
+type MockRepayment struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepaymentMockRecorder
+}
+
+// MockRepaymentMockRecorder is the mock recorder for MockRepayment.
+type MockRepaymentMockRecorder struct {
+	mock *MockRepayment
+}
+
+// NewMockRepayment creates a new mock instance.
+func NewMockRepayment(ctrl *gomock.Controller) *MockRepayment {
+	mock := &MockRepayment{ctrl: ctrl}
+	mock.recorder = &MockRepaymentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepayment) EXPECT() *MockRepaymentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepayment) Create(ctx context.Context, model *entity.Repayment, installments []*entity.Installment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model, installments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepaymentMockRecorder) Create(ctx, model, installments interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepayment)(nil).Create), ctx, model, installments)
+}
+
+// MockPayout is a mock of Payout interface.
+type MockPayout struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayoutMockRecorder
+}
+
+// MockPayoutMockRecorder is the mock recorder for MockPayout.
+type MockPayoutMockRecorder struct {
+	mock *MockPayout
+}
+
+// NewMockPayout creates a new mock instance.
+func NewMockPayout(ctrl *gomock.Controller) *MockPayout {
+	mock := &MockPayout{ctrl: ctrl}
+	mock.recorder = &MockPayoutMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayout) EXPECT() *MockPayoutMockRecorder {
+	return m.recorder
+}
+
+// CreateBulk mocks base method.
+func (m *MockPayout) CreateBulk(ctx context.Context, models []*entity.Payout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBulk", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBulk indicates an expected call of CreateBulk.
+func (mr *MockPayoutMockRecorder) CreateBulk(ctx, models interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBulk", reflect.TypeOf((*MockPayout)(nil).CreateBulk), ctx, models)
+}
+
+// Get mocks base method.
+func (m *MockPayout) Get(ctx context.Context, filter *entity.PayoutFilter) ([]*entity.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPayoutMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPayout)(nil).Get), ctx, filter)
+}
+
+// MockBorrower is a mock of Borrower interface.
+type MockBorrower struct {
+	ctrl     *gomock.Controller
+	recorder *MockBorrowerMockRecorder
+}
+
+// MockBorrowerMockRecorder is the mock recorder for MockBorrower.
+type MockBorrowerMockRecorder struct {
+	mock *MockBorrower
+}
+
+// NewMockBorrower creates a new mock instance.
+func NewMockBorrower(ctrl *gomock.Controller) *MockBorrower {
+	mock := &MockBorrower{ctrl: ctrl}
+	mock.recorder = &MockBorrowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBorrower) EXPECT() *MockBorrowerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBorrower) Create(ctx context.Context, model *entity.Borrower) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBorrowerMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBorrower)(nil).Create), ctx, model)
+}
+
+// Get mocks base method.
+func (m *MockBorrower) Get(ctx context.Context, filter *entity.BorrowerFilter) ([]*entity.Borrower, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Borrower)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBorrowerMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBorrower)(nil).Get), ctx, filter)
+}
+
+// GetDetail mocks base method.
+func (m *MockBorrower) GetDetail(ctx context.Context, id int64) (*entity.Borrower, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetail", ctx, id)
+	ret0, _ := ret[0].(*entity.Borrower)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetail indicates an expected call of GetDetail.
+func (mr *MockBorrowerMockRecorder) GetDetail(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockBorrower)(nil).GetDetail), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockBorrower) Update(ctx context.Context, model *entity.Borrower) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBorrowerMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBorrower)(nil).Update), ctx, model)
+}
+
 // MockInvestor is a mock of Investor interface.
 type MockInvestor struct {
 	ctrl     *gomock.Controller
@@ -183,6 +604,35 @@ func (m *MockInvestor) EXPECT() *MockInvestorMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockInvestor) Create(ctx context.Context, model *entity.Investor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvestorMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvestor)(nil).Create), ctx, model)
+}
+
+// Get mocks base method.
+func (m *MockInvestor) Get(ctx context.Context, filter *entity.InvestorFilter) ([]*entity.Investor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Investor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInvestorMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInvestor)(nil).Get), ctx, filter)
+}
+
 // GetDetail mocks base method.
 func (m *MockInvestor) GetDetail(ctx context.Context, id int64) (*entity.Investor, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +648,138 @@ func (mr *MockInvestorMockRecorder) GetDetail(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockInvestor)(nil).GetDetail), ctx, id)
 }
 
+// Update mocks base method.
+func (m *MockInvestor) Update(ctx context.Context, model *entity.Investor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockInvestorMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInvestor)(nil).Update), ctx, model)
+}
+
+// MockWallet is a mock of Wallet interface.
+type MockWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletMockRecorder
+}
+
+// MockWalletMockRecorder is the mock recorder for MockWallet.
+type MockWalletMockRecorder struct {
+	mock *MockWallet
+}
+
+// NewMockWallet creates a new mock instance.
+func NewMockWallet(ctrl *gomock.Controller) *MockWallet {
+	mock := &MockWallet{ctrl: ctrl}
+	mock.recorder = &MockWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWallet) EXPECT() *MockWalletMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWallet) Create(ctx context.Context, model *entity.Wallet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWalletMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWallet)(nil).Create), ctx, model)
+}
+
+// Get mocks base method.
+func (m *MockWallet) Get(ctx context.Context, filter *entity.WalletFilter) ([]*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWalletMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWallet)(nil).Get), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockWallet) Update(ctx context.Context, model *entity.Wallet, transaction *entity.WalletTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model, transaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWalletMockRecorder) Update(ctx, model, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWallet)(nil).Update), ctx, model, transaction)
+}
+
+// MockLedger is a mock of Ledger interface.
+type MockLedger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerMockRecorder
+}
+
+// MockLedgerMockRecorder is the mock recorder for MockLedger.
+type MockLedgerMockRecorder struct {
+	mock *MockLedger
+}
+
+// NewMockLedger creates a new mock instance.
+func NewMockLedger(ctrl *gomock.Controller) *MockLedger {
+	mock := &MockLedger{ctrl: ctrl}
+	mock.recorder = &MockLedgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedger) EXPECT() *MockLedgerMockRecorder {
+	return m.recorder
+}
+
+// CreateJournal mocks base method.
+func (m *MockLedger) CreateJournal(ctx context.Context, model *entity.LedgerJournal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJournal", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJournal indicates an expected call of CreateJournal.
+func (mr *MockLedgerMockRecorder) CreateJournal(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournal", reflect.TypeOf((*MockLedger)(nil).CreateJournal), ctx, model)
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockLedger) GetTrialBalance(ctx context.Context, filter *entity.TrialBalanceFilter) ([]*entity.TrialBalanceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, filter)
+	ret0, _ := ret[0].([]*entity.TrialBalanceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockLedgerMockRecorder) GetTrialBalance(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockLedger)(nil).GetTrialBalance), ctx, filter)
+}
+
 // MockUpload is a mock of Upload interface.
 type MockUpload struct {
 	ctrl     *gomock.Controller
@@ -272,3 +854,69 @@ func (mr *MockNotifierMockRecorder) Notify(ctx, model interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), ctx, model)
 }
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOutbox) Create(ctx context.Context, model *entity.Outbox) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutbox)(nil).Create), ctx, model)
+}
+
+// Get mocks base method.
+func (m *MockOutbox) Get(ctx context.Context, filter *entity.OutboxFilter) ([]*entity.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOutboxMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOutbox)(nil).Get), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockOutbox) Update(ctx context.Context, model *entity.Outbox) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOutboxMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOutbox)(nil).Update), ctx, model)
+}
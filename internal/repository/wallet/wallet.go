@@ -0,0 +1,254 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/sqlbuilder"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// repoImpl implements Wallet interface
+	repoImpl struct {
+		client database.DB
+	}
+)
+
+// New creates a new instance of repoImpl
+func New(client database.DB) repository.Wallet {
+	return &repoImpl{
+		client: client,
+	}
+}
+
+// Get will return wallet data based on filter, ordered by ID
+func (r *repoImpl) Get(
+	ctx context.Context,
+	filter *entity.WalletFilter,
+) ([]*entity.Wallet, error) {
+	var (
+		result  = []*entity.Wallet{}
+		builder = sqlbuilder.NewBuilder()
+		err     error
+	)
+
+	if filter.InvestorID > 0 {
+		builder.AddWhereClause("investor_id", "=", filter.InvestorID)
+	}
+
+	if filter.Currency != "" {
+		builder.AddWhereClause("currency", "=", filter.Currency)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			investor_id,
+			currency,
+			available,
+			held,
+			committed,
+			created_at,
+			COALESCE(updated_at, '0001-01-01 00:00:00'::timestamp)
+		FROM
+			wallet
+		WHERE
+			1 = 1
+			%s
+		ORDER BY
+			id`,
+		builder.WhereClause(),
+	)
+
+	if filter.ForUpdate {
+		query = fmt.Sprintf("%s FOR UPDATE", query)
+	}
+
+	var rows pgx.Rows
+	rows, err = r.client.Query(ctx, query, builder.Args()...)
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wallet = &entity.Wallet{}
+		err = rows.Scan(
+			&wallet.ID,
+			&wallet.InvestorID,
+			&wallet.Currency,
+			&wallet.Available,
+			&wallet.Held,
+			&wallet.Committed,
+			&wallet.CreatedAt,
+			&wallet.UpdatedAt,
+		)
+		if err != nil {
+			return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+
+		result = append(result, wallet)
+	}
+	err = rows.Err()
+	if err != nil {
+		return result, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return result, nil
+}
+
+// Create will insert an empty wallet unless the investor already has one in the same currency
+func (r *repoImpl) Create(
+	ctx context.Context,
+	model *entity.Wallet,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO wallet (
+			investor_id,
+			currency,
+			available,
+			held,
+			committed,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			0,
+			0,
+			0,
+			NOW()
+		)
+		ON CONFLICT (investor_id, currency) DO NOTHING
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.InvestorID,
+		model.Currency,
+	)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+// Update will update wallet balances based on its ID
+// the balance movement is recorded within the same transaction
+func (r *repoImpl) Update(
+	ctx context.Context,
+	model *entity.Wallet,
+	transaction *entity.WalletTransaction,
+) error {
+	var (
+		err error
+	)
+
+	tx, err := r.client.Begin(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE
+			wallet
+		SET
+			available = $1,
+			held = $2,
+			committed = $3,
+			updated_at = NOW()
+		WHERE
+			id = $4
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		model.Available,
+		model.Held,
+		model.Committed,
+		model.ID,
+	)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	if transaction != nil {
+		err = r.createTransaction(ctx, tx, transaction)
+		if err != nil {
+			return errorwrapper.E(err, errorwrapper.CodeInternal)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return nil
+}
+
+func (r *repoImpl) createTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	model *entity.WalletTransaction,
+) error {
+	query := `
+		INSERT INTO wallet_transaction (
+			wallet_id,
+			loan_id,
+			type,
+			amount,
+			created_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			NOW()
+		)
+	`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		model.WalletID,
+		model.LoanID,
+		model.Type,
+		model.Amount,
+	)
+
+	return err
+}
@@ -0,0 +1,128 @@
+package borrower
+
+import (
+	"context"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type BorrowerImpl struct {
+	repo repository.Borrower
+}
+
+func NewBorrowerImpl(
+	repo repository.Borrower,
+) service.Borrower {
+	return &BorrowerImpl{
+		repo: repo,
+	}
+}
+
+// Get will return borrower data based on filter
+func (b *BorrowerImpl) Get(
+	ctx context.Context,
+	filter *entity.BorrowerFilter,
+) ([]*entity.Borrower, error) {
+	return b.repo.Get(ctx, filter)
+}
+
+// GetDetail will return borrower data based on ID
+func (b *BorrowerImpl) GetDetail(
+	ctx context.Context,
+	id int64,
+) (*entity.Borrower, error) {
+	if id <= 0 {
+		return nil, errorwrapper.E("invalid borrower ID", errorwrapper.CodeInvalid)
+	}
+
+	return b.repo.GetDetail(ctx, id)
+}
+
+// Create will register an active borrower with unique identification number
+func (b *BorrowerImpl) Create(
+	ctx context.Context,
+	model *entity.Borrower,
+) error {
+	model.Normalize()
+	if !model.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+
+	err := b.validateUniqueness(ctx, model)
+	if err != nil {
+		return err
+	}
+	model.Status = constant.GeneralStatusActive
+
+	return b.repo.Create(ctx, model)
+}
+
+// Update will update borrower data, status is left untouched
+// so borrowers are only activated or deactivated through UpdateStatus
+func (b *BorrowerImpl) Update(
+	ctx context.Context,
+	model *entity.Borrower,
+) error {
+	model.Normalize()
+	if !model.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+
+	existing, err := b.GetDetail(ctx, model.ID)
+	if err != nil {
+		return err
+	}
+
+	err = b.validateUniqueness(ctx, model)
+	if err != nil {
+		return err
+	}
+	model.Status = existing.Status
+	model.CreatedAt = existing.CreatedAt
+
+	return b.repo.Update(ctx, model)
+}
+
+// UpdateStatus will activate or deactivate borrower,
+// inactive borrowers can't submit new loans while their existing loans are left untouched
+func (b *BorrowerImpl) UpdateStatus(
+	ctx context.Context,
+	id int64,
+	status int,
+) error {
+	if status != constant.GeneralStatusActive && status != constant.GeneralStatusInactive {
+		return errorwrapper.E("invalid borrower status", errorwrapper.CodeInvalid)
+	}
+
+	existing, err := b.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+	existing.Status = status
+
+	return b.repo.Update(ctx, existing)
+}
+
+// validateUniqueness makes sure no other borrower is registered with the same identification number
+func (b *BorrowerImpl) validateUniqueness(
+	ctx context.Context,
+	model *entity.Borrower,
+) error {
+	list, err := b.repo.Get(ctx, &entity.BorrowerFilter{
+		IdentificationNumber: model.IdentificationNumber,
+	})
+	if err != nil {
+		return err
+	}
+	for _, borrower := range list {
+		if borrower.ID != model.ID {
+			return errorwrapper.E("identification number is already registered", errorwrapper.CodeInvalid)
+		}
+	}
+
+	return nil
+}
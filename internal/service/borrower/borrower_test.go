@@ -0,0 +1,641 @@
+package borrower
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBorrowerImpl(t *testing.T) {
+	type args struct {
+		repo repository.Borrower
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Borrower
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &BorrowerImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewBorrowerImpl(tt.args.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBorrowerImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrowerImpl_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Borrower
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.BorrowerFilter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Borrower
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.BorrowerFilter{
+							Status: constant.GeneralStatusActive,
+						}).
+						Return([]*entity.Borrower{
+							{
+								ID:                   1,
+								IdentificationNumber: "1234567890123451",
+								Name:                 "Borrower A",
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.BorrowerFilter{
+					Status: constant.GeneralStatusActive,
+				},
+			},
+			want: []*entity.Borrower{
+				{
+					ID:                   1,
+					IdentificationNumber: "1234567890123451",
+					Name:                 "Borrower A",
+				},
+			},
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.BorrowerFilter{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BorrowerImpl{
+				repo: tt.fields.repo,
+			}
+			got, err := b.Get(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BorrowerImpl.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BorrowerImpl.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrowerImpl_GetDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Borrower
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.Borrower
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Borrower{
+							ID:                   1,
+							IdentificationNumber: "1234567890123451",
+							Name:                 "Borrower A",
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want: &entity.Borrower{
+				ID:                   1,
+				IdentificationNumber: "1234567890123451",
+				Name:                 "Borrower A",
+			},
+		},
+		{
+			name:   "invalid id",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error on get detail",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BorrowerImpl{
+				repo: tt.fields.repo,
+			}
+			got, err := b.GetDetail(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BorrowerImpl.GetDetail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BorrowerImpl.GetDetail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBorrowerImpl_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Borrower
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Borrower
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					want := &entity.Borrower{
+						IdentificationNumber: "1234567890123451",
+						Name:                 "Borrower A",
+						Status:               constant.GeneralStatusActive,
+					}
+
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.BorrowerFilter{
+							IdentificationNumber: "1234567890123451",
+						}).
+						Return([]*entity.Borrower{}, nil)
+					mock.EXPECT().
+						Create(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					IdentificationNumber: "1234567890123451",
+					Name:                 " Borrower A ",
+				},
+			},
+		},
+		{
+			name:   "invalid param",
+			fields: fields{},
+			args: args{
+				ctx:   context.Background(),
+				model: &entity.Borrower{Name: "Borrower A"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "identification number already registered",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Borrower{
+							{
+								ID:                   1,
+								IdentificationNumber: "1234567890123451",
+								Name:                 "Borrower A",
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					IdentificationNumber: "1234567890123451",
+					Name:                 " Borrower A ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					IdentificationNumber: "1234567890123451",
+					Name:                 " Borrower A ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on create",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Borrower{}, nil)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					IdentificationNumber: "1234567890123451",
+					Name:                 " Borrower A ",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BorrowerImpl{
+				repo: tt.fields.repo,
+			}
+			if err := b.Create(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("BorrowerImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBorrowerImpl_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Borrower
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Borrower
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					existing := &entity.Borrower{
+						ID:                   1,
+						IdentificationNumber: "1234567890123451",
+						Name:                 "Borrower A",
+						Status:               constant.GeneralStatusInactive,
+						CreatedAt:            time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+					}
+					want := &entity.Borrower{
+						ID:                   1,
+						IdentificationNumber: "1234567890123451",
+						Name:                 "Borrower A",
+						Status:               constant.GeneralStatusInactive,
+						CreatedAt:            existing.CreatedAt,
+					}
+
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(existing, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Borrower{existing}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					ID:                   1,
+					IdentificationNumber: "1234567890123451",
+					Name:                 "Borrower A",
+					Status:               constant.GeneralStatusActive,
+				},
+			},
+		},
+		{
+			name:   "invalid param",
+			fields: fields{},
+			args: args{
+				ctx:   context.Background(),
+				model: &entity.Borrower{ID: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get detail",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					ID:                   1,
+					IdentificationNumber: "1234567890123451",
+					Name:                 "Borrower A",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "identification number already registered",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					other := &entity.Borrower{
+						ID:                   2,
+						IdentificationNumber: "1234567890123451",
+						Name:                 "Borrower A",
+					}
+
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Borrower{
+							ID:                   1,
+							IdentificationNumber: "1234567890123451",
+							Name:                 "Borrower A",
+						}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Borrower{other}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					ID:                   1,
+					IdentificationNumber: "1234567890123451",
+					Name:                 "Borrower A",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Borrower{
+							ID:                   1,
+							IdentificationNumber: "1234567890123451",
+							Name:                 "Borrower A",
+						}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Borrower{}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Borrower{
+					ID:                   1,
+					IdentificationNumber: "1234567890123451",
+					Name:                 "Borrower A",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BorrowerImpl{
+				repo: tt.fields.repo,
+			}
+			if err := b.Update(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("BorrowerImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBorrowerImpl_UpdateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Borrower
+	}
+	type args struct {
+		ctx    context.Context
+		id     int64
+		status int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					existing := &entity.Borrower{
+						ID:                   1,
+						IdentificationNumber: "1234567890123451",
+						Name:                 "Borrower A",
+						Status:               constant.GeneralStatusActive,
+					}
+					want := &entity.Borrower{
+						ID:                   1,
+						IdentificationNumber: "1234567890123451",
+						Name:                 "Borrower A",
+						Status:               constant.GeneralStatusInactive,
+					}
+
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(existing, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: constant.GeneralStatusInactive,
+			},
+		},
+		{
+			name:   "invalid status",
+			fields: fields{},
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: 3,
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid id",
+			fields: fields{},
+			args: args{
+				ctx:    context.Background(),
+				status: constant.GeneralStatusActive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repo: func() *repository.MockBorrower {
+					mock := repository.NewMockBorrower(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Borrower{
+							ID:                   1,
+							IdentificationNumber: "1234567890123451",
+							Name:                 "Borrower A",
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: constant.GeneralStatusActive,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BorrowerImpl{
+				repo: tt.fields.repo,
+			}
+			if err := b.UpdateStatus(tt.args.ctx, tt.args.id, tt.args.status); (err != nil) != tt.wantErr {
+				t.Errorf("BorrowerImpl.UpdateStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
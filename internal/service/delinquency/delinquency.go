@@ -0,0 +1,158 @@
+package delinquency
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+)
+
+type DelinquencyImpl struct {
+	config          *config.Config
+	repoLoan        repository.Loan
+	repoInstallment repository.Installment
+	serviceLoan     service.Loan
+}
+
+func NewDelinquencyImpl(
+	config *config.Config,
+	repoLoan repository.Loan,
+	repoInstallment repository.Installment,
+	serviceLoan service.Loan,
+) service.Delinquency {
+	return &DelinquencyImpl{
+		config:          config,
+		repoLoan:        repoLoan,
+		repoInstallment: repoInstallment,
+		serviceLoan:     serviceLoan,
+	}
+}
+
+// Evaluate will scan repayable loans for overdue installments, accrue late penalty
+// and default loans which have been overdue past the configured threshold
+func (d *DelinquencyImpl) Evaluate(
+	ctx context.Context,
+	now time.Time,
+) (entity.DelinquencyResult, error) {
+	var (
+		result = entity.DelinquencyResult{
+			Buckets: map[string]int{},
+		}
+		today = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	)
+
+	// defaulted loans are evaluated first so loans defaulted within this run are not evaluated twice
+	for _, status := range []constant.LoanStatus{constant.StatusDefaulted, constant.StatusDisbursed} {
+		loans, err := d.repoLoan.Get(ctx, &entity.LoanFilter{
+			DataTable: entity.DataTableFilter{
+				Pagination: entity.DataTablePagination{
+					DisablePagination: true,
+				},
+			},
+			Status: status,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		for _, loan := range loans.List {
+			defaulted, err := d.evaluateLoan(ctx, loan, now, today)
+			if err != nil {
+				log.Println("error evaluating loan delinquency", loan.ID, err)
+				continue
+			}
+
+			result.Evaluated++
+			result.Buckets[loan.DelinquencyBucket()]++
+			if defaulted {
+				result.Defaulted++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// evaluateLoan accrues late penalty of every overdue installment, stores the loan's days past due
+// and proceeds the loan to defaulted state once it reaches the configured threshold
+func (d *DelinquencyImpl) evaluateLoan(
+	ctx context.Context,
+	loan *entity.Loan,
+	now time.Time,
+	today time.Time,
+) (bool, error) {
+	installments, err := d.repoInstallment.Get(ctx, &entity.InstallmentFilter{
+		LoanID:     loan.ID,
+		Status:     constant.InstallmentStatusUnpaid,
+		DueDateEnd: today.AddDate(0, 0, -1),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		daysPastDue int
+		penalized   = []*entity.Installment{}
+	)
+	for _, installment := range installments {
+		overdueDays := getDaysPastDue(installment.DueDate, today)
+		if overdueDays > daysPastDue {
+			daysPastDue = overdueDays
+		}
+
+		// penalty is recalculated from the overdue amount and never decreased,
+		// so running the evaluation multiple times a day doesn't accrue it twice
+		penalty := (installment.PrincipalDue() + installment.InterestDue()).
+			Percent(d.config.Worker.Delinquency.PenaltyRate * float64(overdueDays))
+		if penalty > installment.Penalty {
+			installment.Penalty = penalty
+			penalized = append(penalized, installment)
+		}
+	}
+
+	if len(penalized) > 0 {
+		err = d.repoInstallment.UpdatePenalty(ctx, penalized)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if daysPastDue != loan.DaysPastDue {
+		err = d.repoLoan.UpdateDaysPastDue(ctx, loan.ID, daysPastDue)
+		if err != nil {
+			return false, err
+		}
+		loan.DaysPastDue = daysPastDue
+	}
+
+	threshold := d.config.Worker.Delinquency.DefaultThresholdDays
+	if loan.Status != constant.StatusDisbursed || threshold <= 0 || daysPastDue < threshold {
+		return false, nil
+	}
+
+	loan.DefaultedAt = now
+	err = d.serviceLoan.Proceed(ctx, &entity.LoanProceed{
+		Action: constant.ActionDefault,
+		Data:   loan,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// getDaysPastDue returns the number of days passed since the due date
+func getDaysPastDue(dueDate time.Time, today time.Time) int {
+	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, today.Location())
+	if !today.After(due) {
+		return 0
+	}
+
+	return int(today.Sub(due).Hours() / 24)
+}
@@ -0,0 +1,332 @@
+package delinquency
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDelinquencyImpl(t *testing.T) {
+	type args struct {
+		config          *config.Config
+		repoLoan        repository.Loan
+		repoInstallment repository.Installment
+		serviceLoan     service.Loan
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Delinquency
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &DelinquencyImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDelinquencyImpl(tt.args.config, tt.args.repoLoan, tt.args.repoInstallment, tt.args.serviceLoan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewDelinquencyImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelinquencyImpl_Evaluate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config          *config.Config
+		repoLoan        repository.Loan
+		repoInstallment repository.Installment
+		serviceLoan     service.Loan
+	}
+	type args struct {
+		ctx context.Context
+		now time.Time
+	}
+	var (
+		now       = time.Date(2024, 8, 17, 10, 0, 0, 0, time.Local)
+		yesterday = time.Date(2024, 8, 16, 0, 0, 0, 0, time.Local)
+	)
+	defaultConfig := &config.Config{
+		Worker: config.Worker{
+			Delinquency: config.DelinquencyConfig{
+				PenaltyRate:          0.1,
+				DefaultThresholdDays: 90,
+			},
+		},
+	}
+	loanFilter := func(status constant.LoanStatus) *entity.LoanFilter {
+		return &entity.LoanFilter{
+			DataTable: entity.DataTableFilter{
+				Pagination: entity.DataTablePagination{
+					DisablePagination: true,
+				},
+			},
+			Status: status,
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    entity.DelinquencyResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config: defaultConfig,
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), loanFilter(constant.StatusDefaulted)).
+						Return(entity.LoanResult{
+							List: []*entity.Loan{
+								{
+									ID:          1,
+									Status:      constant.StatusDefaulted,
+									DaysPastDue: 120,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), loanFilter(constant.StatusDisbursed)).
+						Return(entity.LoanResult{
+							List: []*entity.Loan{
+								{
+									ID:     2,
+									Status: constant.StatusDisbursed,
+								},
+								{
+									ID:          3,
+									Status:      constant.StatusDisbursed,
+									DaysPastDue: 10,
+								},
+								{
+									ID:     4,
+									Status: constant.StatusDisbursed,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateDaysPastDue(gomock.Any(), int64(1), 121).
+						Return(nil)
+					mock.EXPECT().
+						UpdateDaysPastDue(gomock.Any(), int64(3), 0).
+						Return(nil)
+					mock.EXPECT().
+						UpdateDaysPastDue(gomock.Any(), int64(4), 92).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInstallment: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InstallmentFilter{
+							LoanID:     1,
+							Status:     constant.InstallmentStatusUnpaid,
+							DueDateEnd: yesterday,
+						}).
+						Return([]*entity.Installment{
+							{
+								ID:        1,
+								DueDate:   time.Date(2024, 4, 18, 0, 0, 0, 0, time.Local),
+								Principal: currency.FromMajor(100000),
+								Interest:  currency.FromMajor(2000),
+								Penalty:   currency.FromMajor(12240),
+							},
+						}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InstallmentFilter{
+							LoanID:     2,
+							Status:     constant.InstallmentStatusUnpaid,
+							DueDateEnd: yesterday,
+						}).
+						Return([]*entity.Installment{}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InstallmentFilter{
+							LoanID:     3,
+							Status:     constant.InstallmentStatusUnpaid,
+							DueDateEnd: yesterday,
+						}).
+						Return([]*entity.Installment{}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InstallmentFilter{
+							LoanID:     4,
+							Status:     constant.InstallmentStatusUnpaid,
+							DueDateEnd: yesterday,
+						}).
+						Return([]*entity.Installment{
+							{
+								ID:        2,
+								DueDate:   time.Date(2024, 5, 17, 0, 0, 0, 0, time.Local),
+								Principal: currency.FromMajor(100000),
+								Interest:  currency.FromMajor(2000),
+							},
+							{
+								ID:            3,
+								DueDate:       time.Date(2024, 6, 17, 0, 0, 0, 0, time.Local),
+								Principal:     currency.FromMajor(100000),
+								Interest:      currency.FromMajor(2000),
+								PrincipalPaid: currency.FromMajor(50000),
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdatePenalty(gomock.Any(), []*entity.Installment{
+							{
+								ID:        1,
+								DueDate:   time.Date(2024, 4, 18, 0, 0, 0, 0, time.Local),
+								Principal: currency.FromMajor(100000),
+								Interest:  currency.FromMajor(2000),
+								Penalty:   currency.FromMajor(12342),
+							},
+						}).
+						Return(nil)
+					mock.EXPECT().
+						UpdatePenalty(gomock.Any(), []*entity.Installment{
+							{
+								ID:        2,
+								DueDate:   time.Date(2024, 5, 17, 0, 0, 0, 0, time.Local),
+								Principal: currency.FromMajor(100000),
+								Interest:  currency.FromMajor(2000),
+								Penalty:   currency.FromMajor(9384),
+							},
+							{
+								ID:            3,
+								DueDate:       time.Date(2024, 6, 17, 0, 0, 0, 0, time.Local),
+								Principal:     currency.FromMajor(100000),
+								Interest:      currency.FromMajor(2000),
+								PrincipalPaid: currency.FromMajor(50000),
+								Penalty:       currency.FromMajor(3172),
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLoan: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Proceed(gomock.Any(), &entity.LoanProceed{
+							Action: constant.ActionDefault,
+							Data: &entity.Loan{
+								ID:          4,
+								Status:      constant.StatusDisbursed,
+								DaysPastDue: 92,
+								DefaultedAt: now,
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			want: entity.DelinquencyResult{
+				Evaluated: 4,
+				Defaulted: 1,
+				Buckets: map[string]int{
+					constant.DelinquencyBucketCurrent: 2,
+					constant.DelinquencyBucketOver90:  2,
+				},
+			},
+		},
+		{
+			name: "success with error on evaluating loan",
+			fields: fields{
+				config: defaultConfig,
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), loanFilter(constant.StatusDefaulted)).
+						Return(entity.LoanResult{}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), loanFilter(constant.StatusDisbursed)).
+						Return(entity.LoanResult{
+							List: []*entity.Loan{
+								{
+									ID:     4,
+									Status: constant.StatusDisbursed,
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				repoInstallment: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			want: entity.DelinquencyResult{
+				Buckets: map[string]int{},
+			},
+		},
+		{
+			name: "error on getting loan",
+			fields: fields{
+				config: defaultConfig,
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.LoanResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			want: entity.DelinquencyResult{
+				Buckets: map[string]int{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DelinquencyImpl{
+				config:          tt.fields.config,
+				repoLoan:        tt.fields.repoLoan,
+				repoInstallment: tt.fields.repoInstallment,
+				serviceLoan:     tt.fields.serviceLoan,
+			}
+			got, err := d.Evaluate(tt.args.ctx, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DelinquencyImpl.Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DelinquencyImpl.Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
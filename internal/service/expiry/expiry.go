@@ -0,0 +1,69 @@
+package expiry
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+)
+
+type ExpiryImpl struct {
+	repoLoan    repository.Loan
+	serviceLoan service.Loan
+}
+
+func NewExpiryImpl(
+	repoLoan repository.Loan,
+	serviceLoan service.Loan,
+) service.Expiry {
+	return &ExpiryImpl{
+		repoLoan:    repoLoan,
+		serviceLoan: serviceLoan,
+	}
+}
+
+// Expire will scan approved loans which funding deadline has passed
+// and proceed them to expired state
+func (e *ExpiryImpl) Expire(
+	ctx context.Context,
+	now time.Time,
+) (entity.ExpiryResult, error) {
+	var (
+		result = entity.ExpiryResult{}
+	)
+
+	loans, err := e.repoLoan.Get(ctx, &entity.LoanFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		Status:             constant.StatusApproved,
+		FundingDeadlineEnd: now,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, loan := range loans.List {
+		result.Evaluated++
+
+		loan.ExpiredAt = now
+		err = e.serviceLoan.Proceed(ctx, &entity.LoanProceed{
+			Action: constant.ActionExpire,
+			Data:   loan,
+		})
+		if err != nil {
+			log.Println("error expiring loan", loan.ID, err)
+			continue
+		}
+
+		result.Expired++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package expiry
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExpiryImpl(t *testing.T) {
+	type args struct {
+		repoLoan    repository.Loan
+		serviceLoan service.Loan
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Expiry
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &ExpiryImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewExpiryImpl(tt.args.repoLoan, tt.args.serviceLoan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewExpiryImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpiryImpl_Expire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoLoan    repository.Loan
+		serviceLoan service.Loan
+	}
+	type args struct {
+		ctx context.Context
+		now time.Time
+	}
+	var (
+		now      = time.Date(2024, 9, 17, 10, 0, 0, 0, time.Local)
+		deadline = time.Date(2024, 9, 16, 13, 58, 0, 0, time.Local)
+	)
+	loanFilter := &entity.LoanFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		Status:             constant.StatusApproved,
+		FundingDeadlineEnd: now,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    entity.ExpiryResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), loanFilter).
+						Return(entity.LoanResult{
+							List: []*entity.Loan{
+								{
+									ID:              1,
+									Status:          constant.StatusApproved,
+									FundingDeadline: deadline,
+								},
+								{
+									ID:              2,
+									Status:          constant.StatusApproved,
+									FundingDeadline: deadline,
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				serviceLoan: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Proceed(gomock.Any(), &entity.LoanProceed{
+							Action: constant.ActionExpire,
+							Data: &entity.Loan{
+								ID:              1,
+								Status:          constant.StatusApproved,
+								FundingDeadline: deadline,
+								ExpiredAt:       now,
+							},
+						}).
+						Return(nil)
+					mock.EXPECT().
+						Proceed(gomock.Any(), &entity.LoanProceed{
+							Action: constant.ActionExpire,
+							Data: &entity.Loan{
+								ID:              2,
+								Status:          constant.StatusApproved,
+								FundingDeadline: deadline,
+								ExpiredAt:       now,
+							},
+						}).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			want: entity.ExpiryResult{
+				Evaluated: 2,
+				Expired:   1,
+			},
+		},
+		{
+			name: "error on getting loan",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), loanFilter).
+						Return(entity.LoanResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			want:    entity.ExpiryResult{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ExpiryImpl{
+				repoLoan:    tt.fields.repoLoan,
+				serviceLoan: tt.fields.serviceLoan,
+			}
+			got, err := e.Expire(tt.args.ctx, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpiryImpl.Expire() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpiryImpl.Expire() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package installment
+
+import (
+	"context"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/interest"
+)
+
+type InstallmentImpl struct {
+	config *config.Config
+	repo   repository.Installment
+}
+
+func NewInstallmentImpl(
+	config *config.Config,
+	repo repository.Installment,
+) service.Installment {
+	return &InstallmentImpl{
+		config: config,
+		repo:   repo,
+	}
+}
+
+// Generate will build and store the full repayment schedule of a disbursed loan
+func (i *InstallmentImpl) Generate(
+	ctx context.Context,
+	loan *entity.Loan,
+) error {
+	if loan.ID <= 0 || loan.Amount <= 0 || loan.Tenor <= 0 {
+		return errorwrapper.E("invalid loan for repayment schedule", errorwrapper.CodeInvalid)
+	}
+	if loan.DisbursedAt.IsZero() {
+		return errorwrapper.E("invalid disbursement timestamp for repayment schedule", errorwrapper.CodeInvalid)
+	}
+
+	schedule, err := i.Calculate(ctx, loan)
+	if err != nil {
+		return err
+	}
+
+	return i.repo.CreateBulk(ctx, schedule)
+}
+
+// GetSchedule will return the repayment schedule of a loan
+func (i *InstallmentImpl) GetSchedule(
+	ctx context.Context,
+	loanID int64,
+) ([]*entity.Installment, error) {
+	if loanID <= 0 {
+		return nil, errorwrapper.E("invalid loan ID", errorwrapper.CodeInvalid)
+	}
+
+	return i.repo.Get(ctx, &entity.InstallmentFilter{
+		LoanID: loanID,
+	})
+}
+
+// Calculate will build the repayment schedule of a loan without storing it
+func (i *InstallmentImpl) Calculate(
+	ctx context.Context,
+	loan *entity.Loan,
+) ([]*entity.Installment, error) {
+	if loan.Amount <= 0 || loan.Tenor <= 0 {
+		return nil, errorwrapper.E("invalid loan for repayment schedule", errorwrapper.CodeInvalid)
+	}
+
+	return buildSchedule(loan, i.config.Vendor.Interest)
+}
+
+// CalculateReturn will return the projected return of an investment over the tenor of its loan,
+// the return is the interest the invested amount earns at the investment ROI with the configured interest method
+// less the configured platform service fee and withholding tax
+func (i *InstallmentImpl) CalculateReturn(
+	ctx context.Context,
+	investment *entity.Investment,
+	loan *entity.Loan,
+) (*entity.InvestmentReturn, error) {
+	if investment.Amount <= 0 || loan.Tenor <= 0 {
+		return nil, errorwrapper.E("invalid investment for projected return", errorwrapper.CodeInvalid)
+	}
+
+	investmentReturn, err := interest.TotalInterest(interest.Terms{
+		Principal: investment.Amount,
+		Rate:      investment.ROI,
+		Tenor:     loan.Tenor,
+		Method:    interest.Method(i.config.Vendor.Interest.Method),
+		DayCount:  interest.DayCount(i.config.Vendor.Interest.DayCount),
+		StartDate: investment.CreatedAt,
+	})
+	if err != nil {
+		return nil, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	return entity.NewInvestmentReturn(
+		investment.Amount,
+		investmentReturn,
+		i.config.Vendor.Fee.ServiceFee,
+		i.config.Vendor.Fee.WithholdingTax,
+	), nil
+}
+
+// buildSchedule calculates the repayment schedule of the loan with the configured interest method,
+// where loan rate is the annual interest rate
+func buildSchedule(loan *entity.Loan, cfg config.InterestConfig) ([]*entity.Installment, error) {
+	periods, err := interest.Schedule(interest.Terms{
+		Principal: loan.Amount,
+		Rate:      loan.Rate,
+		Tenor:     loan.Tenor,
+		Method:    interest.Method(cfg.Method),
+		DayCount:  interest.DayCount(cfg.DayCount),
+		StartDate: loan.DisbursedAt,
+	})
+	if err != nil {
+		return nil, errorwrapper.E(err, errorwrapper.CodeInternal)
+	}
+
+	schedule := make([]*entity.Installment, 0, len(periods))
+	for _, period := range periods {
+		schedule = append(schedule, &entity.Installment{
+			LoanID:      loan.ID,
+			Sequence:    period.Sequence,
+			DueDate:     period.DueDate,
+			Principal:   period.Principal,
+			Interest:    period.Interest,
+			Amount:      period.Amount,
+			Outstanding: period.Outstanding,
+			Status:      constant.InstallmentStatusUnpaid,
+		})
+	}
+
+	return schedule, nil
+}
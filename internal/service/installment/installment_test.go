@@ -0,0 +1,586 @@
+package installment
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInstallmentImpl(t *testing.T) {
+	type args struct {
+		config *config.Config
+		repo   repository.Installment
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Installment
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &InstallmentImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewInstallmentImpl(tt.args.config, tt.args.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewInstallmentImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstallmentImpl_Generate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config *config.Config
+		repo   repository.Installment
+	}
+	type args struct {
+		ctx  context.Context
+		loan *entity.Loan
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	defaultArgs := args{
+		ctx: context.Background(),
+		loan: &entity.Loan{
+			ID:          4,
+			Amount:      currency.FromMajor(1000000),
+			Rate:        12,
+			Tenor:       3,
+			DisbursedAt: defaultDate,
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config: &config.Config{},
+				repo: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						CreateBulk(gomock.Any(), []*entity.Installment{
+							{
+								LoanID:      4,
+								Sequence:    1,
+								DueDate:     defaultDate.AddDate(0, 1, 0),
+								Principal:   currency.Amount(33002211),
+								Interest:    currency.FromMajor(10000),
+								Amount:      currency.Amount(34002211),
+								Outstanding: currency.Amount(66997789),
+								Status:      constant.InstallmentStatusUnpaid,
+							},
+							{
+								LoanID:      4,
+								Sequence:    2,
+								DueDate:     defaultDate.AddDate(0, 2, 0),
+								Principal:   currency.Amount(33332233),
+								Interest:    currency.Amount(669978),
+								Amount:      currency.Amount(34002211),
+								Outstanding: currency.Amount(33665556),
+								Status:      constant.InstallmentStatusUnpaid,
+							},
+							{
+								LoanID:      4,
+								Sequence:    3,
+								DueDate:     defaultDate.AddDate(0, 3, 0),
+								Principal:   currency.Amount(33665556),
+								Interest:    currency.Amount(336656),
+								Amount:      currency.Amount(34002212),
+								Outstanding: currency.FromMajor(0),
+								Status:      constant.InstallmentStatusUnpaid,
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name:   "invalid tenor",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				loan: &entity.Loan{
+					ID:          4,
+					Amount:      currency.FromMajor(1000000),
+					Rate:        12,
+					DisbursedAt: defaultDate,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid disbursed at",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				loan: &entity.Loan{
+					ID:     4,
+					Amount: currency.FromMajor(1000000),
+					Rate:   12,
+					Tenor:  3,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported interest method",
+			fields: fields{
+				config: &config.Config{
+					Vendor: config.Vendor{
+						Interest: config.InterestConfig{
+							Method: "compound",
+						},
+					},
+				},
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on create",
+			fields: fields{
+				config: &config.Config{},
+				repo: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						CreateBulk(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InstallmentImpl{
+				config: tt.fields.config,
+				repo:   tt.fields.repo,
+			}
+			if err := i.Generate(tt.args.ctx, tt.args.loan); (err != nil) != tt.wantErr {
+				t.Errorf("InstallmentImpl.Generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInstallmentImpl_GetSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Installment
+	}
+	type args struct {
+		ctx    context.Context
+		loanID int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Installment
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InstallmentFilter{
+							LoanID: 4,
+						}).
+						Return([]*entity.Installment{
+							{
+								ID:     1,
+								LoanID: 4,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				loanID: 4,
+			},
+			want: []*entity.Installment{
+				{
+					ID:     1,
+					LoanID: 4,
+				},
+			},
+		},
+		{
+			name:   "invalid loan ID",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repo: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				loanID: 4,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InstallmentImpl{
+				repo: tt.fields.repo,
+			}
+			got, err := i.GetSchedule(tt.args.ctx, tt.args.loanID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InstallmentImpl.GetSchedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstallmentImpl.GetSchedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstallmentImpl_Calculate(t *testing.T) {
+	type fields struct {
+		config *config.Config
+	}
+	type args struct {
+		ctx  context.Context
+		loan *entity.Loan
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Installment
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config: &config.Config{
+					Vendor: config.Vendor{
+						Interest: config.InterestConfig{
+							Method: "flat",
+						},
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				loan: &entity.Loan{
+					Amount:      currency.FromMajor(1000000),
+					Rate:        12,
+					Tenor:       3,
+					DisbursedAt: defaultDate,
+				},
+			},
+			want: []*entity.Installment{
+				{
+					Sequence:    1,
+					DueDate:     defaultDate.AddDate(0, 1, 0),
+					Principal:   currency.Amount(33333333),
+					Interest:    currency.FromMajor(10000),
+					Amount:      currency.Amount(34333333),
+					Outstanding: currency.Amount(66666667),
+					Status:      constant.InstallmentStatusUnpaid,
+				},
+				{
+					Sequence:    2,
+					DueDate:     defaultDate.AddDate(0, 2, 0),
+					Principal:   currency.Amount(33333333),
+					Interest:    currency.FromMajor(10000),
+					Amount:      currency.Amount(34333333),
+					Outstanding: currency.Amount(33333334),
+					Status:      constant.InstallmentStatusUnpaid,
+				},
+				{
+					Sequence:    3,
+					DueDate:     defaultDate.AddDate(0, 3, 0),
+					Principal:   currency.Amount(33333334),
+					Interest:    currency.FromMajor(10000),
+					Amount:      currency.Amount(34333334),
+					Outstanding: currency.FromMajor(0),
+					Status:      constant.InstallmentStatusUnpaid,
+				},
+			},
+		},
+		{
+			name:   "invalid amount",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				loan: &entity.Loan{
+					Tenor: 3,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported interest method",
+			fields: fields{
+				config: &config.Config{
+					Vendor: config.Vendor{
+						Interest: config.InterestConfig{
+							Method: "compound",
+						},
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				loan: &entity.Loan{
+					Amount: currency.FromMajor(1000000),
+					Rate:   12,
+					Tenor:  3,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InstallmentImpl{
+				config: tt.fields.config,
+			}
+			got, err := i.Calculate(tt.args.ctx, tt.args.loan)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InstallmentImpl.Calculate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstallmentImpl.Calculate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstallmentImpl_CalculateReturn(t *testing.T) {
+	type fields struct {
+		config *config.Config
+	}
+	type args struct {
+		ctx        context.Context
+		investment *entity.Investment
+		loan       *entity.Loan
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.InvestmentReturn
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config: &config.Config{
+					Vendor: config.Vendor{
+						Interest: config.InterestConfig{
+							Method: "flat",
+						},
+						Fee: config.FeeConfig{
+							ServiceFee:     10,
+							WithholdingTax: 15,
+						},
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				investment: &entity.Investment{
+					Amount: currency.FromMajor(1000000),
+					ROI:    12,
+				},
+				loan: &entity.Loan{
+					Tenor: 3,
+				},
+			},
+			want: &entity.InvestmentReturn{
+				Amount:      currency.FromMajor(1000000),
+				Return:      currency.FromMajor(30000),
+				ServiceFee:  currency.FromMajor(3000),
+				Tax:         currency.FromMajor(4500),
+				NetReturn:   currency.FromMajor(22500),
+				ROI:         2.25,
+				FinalAmount: currency.FromMajor(1022500),
+			},
+		},
+		{
+			name:   "invalid tenor",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				investment: &entity.Investment{
+					Amount: currency.FromMajor(1000000),
+					ROI:    12,
+				},
+				loan: &entity.Loan{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "start date required by day count",
+			fields: fields{
+				config: &config.Config{
+					Vendor: config.Vendor{
+						Interest: config.InterestConfig{
+							DayCount: "actual/365",
+						},
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				investment: &entity.Investment{
+					Amount: currency.FromMajor(1000000),
+					ROI:    12,
+				},
+				loan: &entity.Loan{
+					Tenor: 3,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InstallmentImpl{
+				config: tt.fields.config,
+			}
+			got, err := i.CalculateReturn(tt.args.ctx, tt.args.investment, tt.args.loan)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InstallmentImpl.CalculateReturn() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstallmentImpl.CalculateReturn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildSchedule(t *testing.T) {
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	tests := []struct {
+		name            string
+		loan            *entity.Loan
+		cfg             config.InterestConfig
+		wantPrincipal   currency.Amount
+		wantInterest    currency.Amount
+		wantLastBalance currency.Amount
+	}{
+		{
+			name: "annuity",
+			loan: &entity.Loan{
+				ID:     1,
+				Amount: currency.FromMajor(350000000),
+				Rate:   8,
+				Tenor:  12,
+			},
+			wantPrincipal:   currency.FromMajor(350000000),
+			wantInterest:    currency.Amount(1535140216),
+			wantLastBalance: 0,
+		},
+		{
+			name: "zero rate",
+			loan: &entity.Loan{
+				ID:     1,
+				Amount: currency.FromMajor(1000000),
+				Tenor:  3,
+			},
+			wantPrincipal:   currency.FromMajor(1000000),
+			wantInterest:    0,
+			wantLastBalance: 0,
+		},
+		{
+			name: "flat",
+			loan: &entity.Loan{
+				ID:     1,
+				Amount: currency.FromMajor(1000000),
+				Rate:   12,
+				Tenor:  3,
+			},
+			cfg: config.InterestConfig{
+				Method: "flat",
+			},
+			wantPrincipal:   currency.FromMajor(1000000),
+			wantInterest:    currency.FromMajor(30000),
+			wantLastBalance: 0,
+		},
+		{
+			name: "effective with actual day count",
+			loan: &entity.Loan{
+				ID:          1,
+				Amount:      currency.FromMajor(3650000),
+				Rate:        12,
+				Tenor:       3,
+				DisbursedAt: defaultDate,
+			},
+			cfg: config.InterestConfig{
+				Method:   "effective",
+				DayCount: "actual/365",
+			},
+			wantPrincipal:   currency.FromMajor(3650000),
+			wantInterest:    currency.FromMajor(73600),
+			wantLastBalance: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildSchedule(tt.loan, tt.cfg)
+			assert.NoError(t, err)
+			assert.Len(t, got, tt.loan.Tenor)
+
+			var principal, interest currency.Amount
+			for _, val := range got {
+				principal += val.Principal
+				interest += val.Interest
+			}
+			assert.Equal(t, tt.wantPrincipal, principal)
+			assert.Equal(t, tt.wantInterest, interest)
+			assert.Equal(t, tt.wantLastBalance, got[len(got)-1].Outstanding)
+		})
+	}
+}
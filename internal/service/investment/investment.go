@@ -10,28 +10,49 @@ import (
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
 	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 	"github.com/ecintiawan/loan-service/pkg/lock"
 )
 
 type InvestmentImpl struct {
-	repoInvestment repository.Investment
-	repoLoan       repository.Loan
-	serviceLoan    service.Loan
-	lock           lock.Lock
+	config             *config.Config
+	repoInvestment     repository.Investment
+	repoLoan           repository.Loan
+	repoInvestor       repository.Investor
+	serviceLoan        service.Loan
+	serviceInstallment service.Installment
+	serviceWallet      service.Wallet
+	serviceLedger      service.Ledger
+	lock               lock.Lock
+	db                 database.DB
 }
 
 func NewInvestmentImpl(
+	config *config.Config,
 	repoInvestment repository.Investment,
 	repoLoan repository.Loan,
+	repoInvestor repository.Investor,
 	serviceLoan service.Loan,
+	serviceInstallment service.Installment,
+	serviceWallet service.Wallet,
+	serviceLedger service.Ledger,
 	lock lock.Lock,
+	db database.DB,
 ) service.Investment {
 	return &InvestmentImpl{
-		repoInvestment: repoInvestment,
-		repoLoan:       repoLoan,
-		serviceLoan:    serviceLoan,
-		lock:           lock,
+		config:             config,
+		repoInvestment:     repoInvestment,
+		repoLoan:           repoLoan,
+		repoInvestor:       repoInvestor,
+		serviceLoan:        serviceLoan,
+		serviceInstallment: serviceInstallment,
+		serviceWallet:      serviceWallet,
+		serviceLedger:      serviceLedger,
+		lock:               lock,
+		db:                 db,
 	}
 }
 
@@ -45,28 +66,121 @@ func (i *InvestmentImpl) Get(
 	return i.repoInvestment.Get(ctx, filter)
 }
 
-// Invest will insert initial investment data
+// GetPortfolio will return the aggregated active investments of an investor in a currency,
+// every figure is aggregated by the database so the investments are never loaded one by one
+func (i *InvestmentImpl) GetPortfolio(
+	ctx context.Context,
+	filter *entity.PortfolioFilter,
+) (*entity.Portfolio, error) {
+	if filter.InvestorID <= 0 {
+		return nil, errorwrapper.E("invalid investor ID", errorwrapper.CodeInvalid)
+	}
+	if filter.Currency == "" {
+		filter.Currency = currency.IDR
+	}
+	if !currency.IsSupported(filter.Currency) {
+		return nil, errorwrapper.E("unsupported currency", errorwrapper.CodeInvalid)
+	}
+
+	portfolio, err := i.repoInvestment.GetPortfolio(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio.Exposures, err = i.repoInvestment.GetExposure(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	concentrations, err := i.repoInvestment.GetConcentration(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	portfolio.SetConcentrations(concentrations)
+
+	return portfolio, nil
+}
+
+// Invest will insert initial investment data and hold its amount from the investor wallet
+// admission check, insertion and the transition to invested state are done within a single transaction
+// which holds the loan row lock, so concurrent investments on the same loan can't over-fund it
+// regardless of how many service instances are running
 func (i *InvestmentImpl) Invest(
 	ctx context.Context,
 	req *entity.Investment,
 ) error {
-	// locking to prevent racing investment case on the same loan data
-	lockKey := getLockKey(req.LoanID)
-	i.lock.Lock(lockKey)
-	defer i.lock.Unlock(lockKey)
-
 	if !req.IsValid() {
 		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
 	}
 
+	err := i.validateInvestor(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return i.db.WithTx(ctx, func(ctx context.Context) error {
+		// locking to prevent racing investment case on the same loan data
+		lockKey := getLockKey(req.LoanID)
+		err := i.lock.Lock(ctx, lockKey)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			errUnlock := i.lock.Unlock(ctx, lockKey)
+			if errUnlock != nil {
+				log.Println("error releasing investment lock", errUnlock)
+			}
+		}()
+
+		return i.admit(ctx, req)
+	})
+}
+
+// validateInvestor makes sure the investment is made by a registered and active investor
+func (i *InvestmentImpl) validateInvestor(
+	ctx context.Context,
+	req *entity.Investment,
+) error {
+	investor, err := i.repoInvestor.GetDetail(ctx, req.InvestorID)
+	if err != nil {
+		errx, ok := err.(*errorwrapper.Error)
+		if ok && errx.Code == errorwrapper.CodeNotFound {
+			return errorwrapper.E("investor_id refers to a non-existent investor", errorwrapper.CodeInvalid)
+		}
+		return err
+	}
+	if investor.Status != constant.GeneralStatusActive {
+		return errorwrapper.E("investor_id refers to an inactive investor", errorwrapper.CodeInvalid)
+	}
+
+	return nil
+}
+
+// admit validates and inserts the investment, it must be called within a transaction
+func (i *InvestmentImpl) admit(
+	ctx context.Context,
+	req *entity.Investment,
+) error {
 	// validate loan status
-	loan, err := i.repoLoan.GetDetail(ctx, req.LoanID)
+	// the loan row stays locked until the transaction ends
+	loan, err := i.repoLoan.GetDetailForUpdate(ctx, req.LoanID)
 	if err != nil {
 		return err
 	}
 	if loan.Status != constant.StatusApproved {
 		return errorwrapper.E("loan status must be approved", errorwrapper.CodeInvalid)
 	}
+	if !loan.FundingDeadline.IsZero() && time.Now().After(loan.FundingDeadline) {
+		return errorwrapper.E("loan funding deadline has passed", errorwrapper.CodeInvalid)
+	}
+
+	// investment is denominated in the loan's currency unless stated otherwise
+	if req.Currency == "" {
+		req.Currency = loan.Currency
+	}
+	if req.Currency != loan.Currency {
+		return errorwrapper.E("investment currency must match loan currency", errorwrapper.CodeInvalid)
+	}
 
 	// validate if the additional amount will exceed the principle amount
 	amountSum, err := i.repoInvestment.GetAmountSum(ctx, &entity.InvestmentFilter{
@@ -80,28 +194,169 @@ func (i *InvestmentImpl) Invest(
 		return errorwrapper.E("investment amount exceeds remaining principle amount", errorwrapper.CodeInvalid)
 	}
 
+	// the investment completing the funding goes on to commit the wallets of every investor of the loan,
+	// so all of them are locked in the order of investor ID before the wallet of this investment is held alone
+	fullyFunded := req.Amount+amountSum == loan.Amount
+	if fullyFunded {
+		err = i.lockWallets(ctx, req)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the invested amount is held from the investor wallet until the loan is fully funded
+	err = i.serviceWallet.Hold(ctx, req)
+	if err != nil {
+		return err
+	}
+
 	req.ROI = loan.Rate
 	req.Status = constant.GeneralStatusActive
+	req.CreatedAt = time.Now()
+
+	// store the projected return along with the fee and tax deducted from it
+	investmentReturn, err := i.serviceInstallment.CalculateReturn(ctx, req, loan)
+	if err != nil {
+		return err
+	}
+	req.ExpectedReturn = investmentReturn.Return
+	req.ServiceFee = investmentReturn.ServiceFee
+	req.Tax = investmentReturn.Tax
+
 	err = i.repoInvestment.Create(ctx, req)
 	if err != nil {
 		return err
 	}
 
+	// the invested amount moves from the investor wallet into the escrow of the loan
+	err = i.serviceLedger.Post(ctx, entity.NewFundingJournal(req))
+	if err != nil {
+		return err
+	}
+
 	// trigger loan to proceed to invested state
-	if req.Amount+amountSum == loan.Amount {
+	if fullyFunded {
 		loan.InvestedAt = time.Now()
-		errProceed := i.serviceLoan.Proceed(ctx, &entity.LoanProceed{
+		return i.serviceLoan.Proceed(ctx, &entity.LoanProceed{
 			Action: constant.ActionInvest,
 			Data:   loan,
 		})
-		if errProceed != nil {
-			log.Println("error proceeding loan to invested", err)
-		}
 	}
 
 	return nil
 }
 
+// lockWallets locks the wallets of the investor of the request and of every active investor of the loan
+func (i *InvestmentImpl) lockWallets(
+	ctx context.Context,
+	req *entity.Investment,
+) error {
+	investment, err := i.repoInvestment.Get(ctx, &entity.InvestmentFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		LoanID: req.LoanID,
+		Status: constant.GeneralStatusActive,
+	})
+	if err != nil {
+		return err
+	}
+
+	investorIDs := []int64{req.InvestorID}
+	for _, existing := range investment.List {
+		investorIDs = append(investorIDs, existing.InvestorID)
+	}
+
+	return i.serviceWallet.Lock(ctx, investorIDs, req.Currency)
+}
+
+// Cancel will deactivate an investment within the configured cooling-off window
+// while its loan is still approved, so the released amount is open for other investors.
+// it holds the same loan row lock as Invest, so the amount sum seen by concurrent investments stays consistent
+func (i *InvestmentImpl) Cancel(
+	ctx context.Context,
+	id int64,
+) error {
+	if id <= 0 {
+		return errorwrapper.E("invalid investment ID", errorwrapper.CodeInvalid)
+	}
+
+	var window time.Duration
+	if cfg := i.config.Vendor.Funding.CancellationWindow; cfg != "" {
+		val, err := time.ParseDuration(cfg)
+		if err != nil || val < 0 {
+			return errorwrapper.E(fmt.Sprintf("invalid cancellation window: %q", cfg), errorwrapper.CodeInternal)
+		}
+		window = val
+	}
+
+	// loan of the investment never changes, so it is safe to look it up before locking
+	existing, err := i.getDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return i.db.WithTx(ctx, func(ctx context.Context) error {
+		loan, err := i.repoLoan.GetDetailForUpdate(ctx, existing.LoanID)
+		if err != nil {
+			return err
+		}
+		if loan.Status != constant.StatusApproved {
+			return errorwrapper.E("loan status must be approved", errorwrapper.CodeInvalid)
+		}
+
+		// read the investment again now that concurrent cancellations of the loan are blocked
+		investment, err := i.getDetail(ctx, id)
+		if err != nil {
+			return err
+		}
+		if investment.Status != constant.GeneralStatusActive {
+			return errorwrapper.E("investment is not active", errorwrapper.CodeInvalid)
+		}
+		if window > 0 && time.Since(investment.CreatedAt) > window {
+			return errorwrapper.E("investment cancellation window has passed", errorwrapper.CodeInvalid)
+		}
+
+		err = i.repoInvestment.UpdateStatus(ctx, &entity.InvestmentFilter{
+			ID: investment.ID,
+		}, constant.GeneralStatusInactive)
+		if err != nil {
+			return err
+		}
+
+		err = i.serviceWallet.Release(ctx, investment)
+		if err != nil {
+			return err
+		}
+
+		return i.serviceLedger.Post(ctx, entity.NewFundingReversalJournal(investment))
+	})
+}
+
+func (i *InvestmentImpl) getDetail(
+	ctx context.Context,
+	id int64,
+) (*entity.Investment, error) {
+	result, err := i.repoInvestment.Get(ctx, &entity.InvestmentFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		ID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.List) <= 0 {
+		return nil, errorwrapper.E("data does not exist", errorwrapper.CodeNotFound)
+	}
+
+	return result.List[0], nil
+}
+
 func getLockKey(loanID int64) string {
 	return fmt.Sprintf("investment:invest:%d", loanID)
 }
@@ -4,11 +4,16 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 	"github.com/ecintiawan/loan-service/pkg/lock"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -16,10 +21,16 @@ import (
 
 func TestNewInvestmentImpl(t *testing.T) {
 	type args struct {
-		repoInvestment repository.Investment
-		repoLoan       repository.Loan
-		serviceLoan    service.Loan
-		lock           lock.Lock
+		config             *config.Config
+		repoInvestment     repository.Investment
+		repoLoan           repository.Loan
+		repoInvestor       repository.Investor
+		serviceLoan        service.Loan
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
+		lock               lock.Lock
+		db                 database.DB
 	}
 	tests := []struct {
 		name string
@@ -34,7 +45,7 @@ func TestNewInvestmentImpl(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewInvestmentImpl(tt.args.repoInvestment, tt.args.repoLoan, tt.args.serviceLoan, tt.args.lock); !reflect.DeepEqual(got, tt.want) {
+			if got := NewInvestmentImpl(tt.args.config, tt.args.repoInvestment, tt.args.repoLoan, tt.args.repoInvestor, tt.args.serviceLoan, tt.args.serviceInstallment, tt.args.serviceWallet, tt.args.serviceLedger, tt.args.lock, tt.args.db); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewInvestmentImpl() = %v, want %v", got, tt.want)
 			}
 		})
@@ -49,7 +60,7 @@ func TestInvestmentImpl_Get(t *testing.T) {
 		repoInvestment repository.Investment
 		repoLoan       repository.Loan
 		serviceLoan    service.Loan
-		lock           lock.Lock
+		db             database.DB
 	}
 	type args struct {
 		ctx    context.Context
@@ -79,7 +90,7 @@ func TestInvestmentImpl_Get(t *testing.T) {
 							List: []*entity.Investment{
 								{
 									ID:     1,
-									Amount: 10000,
+									Amount: currency.FromMajor(10000),
 								},
 							},
 							Pagination: entity.Pagination{
@@ -95,7 +106,7 @@ func TestInvestmentImpl_Get(t *testing.T) {
 				List: []*entity.Investment{
 					{
 						ID:     1,
-						Amount: 10000,
+						Amount: currency.FromMajor(10000),
 					},
 				},
 				Pagination: entity.Pagination{
@@ -134,7 +145,7 @@ func TestInvestmentImpl_Get(t *testing.T) {
 				repoInvestment: tt.fields.repoInvestment,
 				repoLoan:       tt.fields.repoLoan,
 				serviceLoan:    tt.fields.serviceLoan,
-				lock:           tt.fields.lock,
+				db:             tt.fields.db,
 			}
 			got, err := i.Get(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
@@ -148,32 +159,34 @@ func TestInvestmentImpl_Get(t *testing.T) {
 	}
 }
 
-func TestInvestmentImpl_Invest(t *testing.T) {
+func TestInvestmentImpl_GetPortfolio(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	type fields struct {
 		repoInvestment repository.Investment
-		repoLoan       repository.Loan
-		serviceLoan    service.Loan
-		lock           lock.Lock
 	}
 	type args struct {
-		ctx context.Context
-		req *entity.Investment
+		ctx    context.Context
+		filter *entity.PortfolioFilter
 	}
-	defaultArgs := args{
-		ctx: context.Background(),
-		req: &entity.Investment{
-			InvestorID: 1,
-			LoanID:     3,
-			Amount:     200000,
-		},
+	defaultFilter := &entity.PortfolioFilter{
+		InvestorID: 1,
+		Currency:   currency.IDR,
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			filter: &entity.PortfolioFilter{
+				InvestorID: 1,
+			},
+		}
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
+		want    *entity.Portfolio
 		wantErr bool
 	}{
 		{
@@ -182,251 +195,1180 @@ func TestInvestmentImpl_Invest(t *testing.T) {
 				repoInvestment: func() *repository.MockInvestment {
 					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
-						GetAmountSum(gomock.Any(), gomock.Any()).
-						Return(float64(1000000), nil)
-					mock.EXPECT().
-						Create(gomock.Any(), &entity.Investment{
-							InvestorID: 1,
-							LoanID:     3,
-							Amount:     200000,
-							ROI:        10,
-							Status:     constant.GeneralStatusActive,
-						}).
-						Return(nil)
-
-					return mock
-				}(),
-				repoLoan: func() *repository.MockLoan {
-					mock := repository.NewMockLoan(ctrl)
-					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
-						Return(&entity.Loan{
-							ID:     3,
-							Amount: 1200000,
-							Rate:   10,
-							Status: constant.StatusApproved,
+						GetPortfolio(gomock.Any(), defaultFilter).
+						Return(&entity.Portfolio{
+							InvestorID:      1,
+							Currency:        currency.IDR,
+							InvestmentCount: 3,
+							TotalInvested:   currency.FromMajor(4000000),
+							Outstanding:     currency.FromMajor(4000000),
 						}, nil)
-
-					return mock
-				}(),
-				serviceLoan: func() *service.MockLoan {
-					mock := service.NewMockLoan(ctrl)
-					mock.EXPECT().
-						Proceed(gomock.Any(), gomock.Any()).
-						Return(nil)
-
-					return mock
-				}(),
-				lock: func() *lock.MockLock {
-					mock := lock.NewMockLock(ctrl)
 					mock.EXPECT().
-						Lock(getLockKey(3))
+						GetExposure(gomock.Any(), defaultFilter).
+						Return([]*entity.PortfolioExposure{
+							{
+								LoanStatus:      constant.StatusDisbursed,
+								InvestmentCount: 3,
+								Outstanding:     currency.FromMajor(4000000),
+							},
+						}, nil)
 					mock.EXPECT().
-						Unlock(getLockKey(3))
+						GetConcentration(gomock.Any(), defaultFilter).
+						Return([]*entity.PortfolioConcentration{
+							{
+								BorrowerID:      4,
+								InvestmentCount: 2,
+								Outstanding:     currency.FromMajor(3000000),
+							},
+							{
+								BorrowerID:      3,
+								InvestmentCount: 1,
+								Outstanding:     currency.FromMajor(1000000),
+							},
+						}, nil)
 
 					return mock
 				}(),
 			},
-			args: defaultArgs,
+			args: defaultArgs(),
+			want: &entity.Portfolio{
+				InvestorID:      1,
+				Currency:        currency.IDR,
+				InvestmentCount: 3,
+				TotalInvested:   currency.FromMajor(4000000),
+				Outstanding:     currency.FromMajor(4000000),
+				Exposures: []*entity.PortfolioExposure{
+					{
+						LoanStatus:      constant.StatusDisbursed,
+						InvestmentCount: 3,
+						Outstanding:     currency.FromMajor(4000000),
+					},
+				},
+				Concentrations: []*entity.PortfolioConcentration{
+					{
+						BorrowerID:      4,
+						InvestmentCount: 2,
+						Outstanding:     currency.FromMajor(3000000),
+						Share:           75,
+					},
+					{
+						BorrowerID:      3,
+						InvestmentCount: 1,
+						Outstanding:     currency.FromMajor(1000000),
+						Share:           25,
+					},
+				},
+			},
 		},
 		{
-			name: "invalid request",
-			fields: fields{
-				lock: func() *lock.MockLock {
-					mock := lock.NewMockLock(ctrl)
-					mock.EXPECT().
-						Lock(gomock.Any())
-					mock.EXPECT().
-						Unlock(gomock.Any())
-
-					return mock
-				}(),
+			name:   "invalid investor ID",
+			fields: fields{},
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.PortfolioFilter{},
 			},
+			wantErr: true,
+		},
+		{
+			name:   "unsupported currency",
+			fields: fields{},
 			args: args{
 				ctx: context.Background(),
-				req: &entity.Investment{},
+				filter: &entity.PortfolioFilter{
+					InvestorID: 1,
+					Currency:   "XYZ",
+				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "error get loan detail",
+			name: "error on get portfolio",
 			fields: fields{
-				repoLoan: func() *repository.MockLoan {
-					mock := repository.NewMockLoan(ctrl)
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
+						GetPortfolio(gomock.Any(), gomock.Any()).
 						Return(nil, assert.AnError)
 
 					return mock
 				}(),
-				lock: func() *lock.MockLock {
-					mock := lock.NewMockLock(ctrl)
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on get exposure",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
-						Lock(getLockKey(3))
+						GetPortfolio(gomock.Any(), gomock.Any()).
+						Return(&entity.Portfolio{}, nil)
 					mock.EXPECT().
-						Unlock(getLockKey(3))
+						GetExposure(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
 
 					return mock
 				}(),
 			},
-			args:    defaultArgs,
+			args:    defaultArgs(),
 			wantErr: true,
 		},
 		{
-			name: "invalid loan detail status",
+			name: "error on get concentration",
 			fields: fields{
-				repoLoan: func() *repository.MockLoan {
-					mock := repository.NewMockLoan(ctrl)
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
-						Return(&entity.Loan{
-							ID:     3,
-							Amount: 1200000,
-							Rate:   10,
-							Status: constant.StatusProposed,
-						}, nil)
-
-					return mock
-				}(),
-				lock: func() *lock.MockLock {
-					mock := lock.NewMockLock(ctrl)
+						GetPortfolio(gomock.Any(), gomock.Any()).
+						Return(&entity.Portfolio{}, nil)
 					mock.EXPECT().
-						Lock(getLockKey(3))
+						GetExposure(gomock.Any(), gomock.Any()).
+						Return([]*entity.PortfolioExposure{}, nil)
 					mock.EXPECT().
-						Unlock(getLockKey(3))
+						GetConcentration(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
 
 					return mock
 				}(),
 			},
-			args:    defaultArgs,
+			args:    defaultArgs(),
 			wantErr: true,
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestmentImpl{
+				repoInvestment: tt.fields.repoInvestment,
+			}
+			got, err := i.GetPortfolio(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InvestmentImpl.GetPortfolio() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InvestmentImpl.GetPortfolio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestmentImpl_Invest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoInvestment     repository.Investment
+		repoLoan           repository.Loan
+		repoInvestor       repository.Investor
+		serviceLoan        service.Loan
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
+		lock               lock.Lock
+		db                 database.DB
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.Investment
+	}
+	activeInvestor := func() *repository.MockInvestor {
+		mock := repository.NewMockInvestor(ctrl)
+		mock.EXPECT().
+			GetDetail(gomock.Any(), int64(1)).
+			Return(&entity.Investor{
+				ID:     1,
+				Status: constant.GeneralStatusActive,
+			}, nil)
+
+		return mock
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	newLock := func() *lock.MockLock {
+		mock := lock.NewMockLock(ctrl)
+		mock.EXPECT().
+			Lock(gomock.Any(), getLockKey(3)).
+			Return(nil)
+		mock.EXPECT().
+			Unlock(gomock.Any(), getLockKey(3)).
+			Return(nil)
+
+		return mock
+	}
+	approvedLoan := func() *repository.MockLoan {
+		mock := repository.NewMockLoan(ctrl)
+		mock.EXPECT().
+			GetDetailForUpdate(gomock.Any(), int64(3)).
+			Return(&entity.Loan{
+				ID:       3,
+				Currency: currency.IDR,
+				Amount:   currency.FromMajor(1200000),
+				Rate:     10,
+				Status:   constant.StatusApproved,
+			}, nil)
+
+		return mock
+	}
+	projectedReturn := func() *service.MockInstallment {
+		mock := service.NewMockInstallment(ctrl)
+		mock.EXPECT().
+			CalculateReturn(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(entity.NewInvestmentReturn(currency.FromMajor(200000), currency.FromMajor(10000), 10, 15), nil)
+
+		return mock
+	}
+	heldWallet := func() *service.MockWallet {
+		mock := service.NewMockWallet(ctrl)
+		mock.EXPECT().
+			Hold(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		return mock
+	}
+	fundedWallet := func(code string) *service.MockWallet {
+		mock := service.NewMockWallet(ctrl)
+		gomock.InOrder(
+			mock.EXPECT().
+				Lock(gomock.Any(), []int64{1, 2}, code).
+				Return(nil),
+			mock.EXPECT().
+				Hold(gomock.Any(), gomock.Any()).
+				Return(nil),
+		)
+
+		return mock
+	}
+	postedLedger := func() *service.MockLedger {
+		mock := service.NewMockLedger(ctrl)
+		mock.EXPECT().
+			Post(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		return mock
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.Investment{
+				InvestorID: 1,
+				LoanID:     3,
+				Amount:     currency.FromMajor(200000),
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
 		{
-			name: "error get amount sum",
+			name: "success",
 			fields: fields{
 				repoInvestment: func() *repository.MockInvestment {
 					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
 						GetAmountSum(gomock.Any(), gomock.Any()).
-						Return(float64(0), assert.AnError)
+						Return(currency.FromMajor(1000000), nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{ID: 5, InvestorID: 2, LoanID: 3},
+							},
+						}, nil)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, model *entity.Investment) error {
+							assert.False(t, model.CreatedAt.IsZero())
+							model.CreatedAt = time.Time{}
+							assert.Equal(t, &entity.Investment{
+								InvestorID:     1,
+								LoanID:         3,
+								Currency:       currency.IDR,
+								Amount:         currency.FromMajor(200000),
+								ROI:            10,
+								ExpectedReturn: currency.FromMajor(10000),
+								ServiceFee:     currency.FromMajor(1000),
+								Tax:            currency.FromMajor(1500),
+								Status:         constant.GeneralStatusActive,
+							}, model)
+							return nil
+						})
 
 					return mock
 				}(),
 				repoLoan: func() *repository.MockLoan {
 					mock := repository.NewMockLoan(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
 						Return(&entity.Loan{
-							ID:     3,
-							Amount: 1200000,
-							Rate:   10,
-							Status: constant.StatusApproved,
+							ID:       3,
+							Currency: currency.IDR,
+							Amount:   currency.FromMajor(1200000),
+							Rate:     10,
+							Status:   constant.StatusApproved,
 						}, nil)
 
 					return mock
 				}(),
-				lock: func() *lock.MockLock {
-					mock := lock.NewMockLock(ctrl)
-					mock.EXPECT().
-						Lock(getLockKey(3))
+				serviceLoan: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
 					mock.EXPECT().
-						Unlock(getLockKey(3))
+						Proceed(gomock.Any(), gomock.Any()).
+						Return(nil)
 
 					return mock
 				}(),
+				serviceInstallment: projectedReturn(),
+				serviceWallet:      fundedWallet(currency.IDR),
+				serviceLedger:      postedLedger(),
+				repoInvestor:       activeInvestor(),
+				lock:               newLock(),
+				db:                 newDB(),
 			},
-			args:    defaultArgs,
-			wantErr: true,
+			args: defaultArgs(),
 		},
 		{
-			name: "investment amount exceeded",
+			name: "success without proceeding loan",
 			fields: fields{
 				repoInvestment: func() *repository.MockInvestment {
 					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
 						GetAmountSum(gomock.Any(), gomock.Any()).
-						Return(float64(1200000), nil)
+						Return(currency.FromMajor(500000), nil)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil)
 
 					return mock
 				}(),
-				repoLoan: func() *repository.MockLoan {
-					mock := repository.NewMockLoan(ctrl)
+				repoLoan:           approvedLoan(),
+				serviceInstallment: projectedReturn(),
+				serviceWallet:      heldWallet(),
+				serviceLedger:      postedLedger(),
+				repoInvestor:       activeInvestor(),
+				lock:               newLock(),
+				db:                 newDB(),
+			},
+			args: defaultArgs(),
+		},
+		{
+			name: "currency mismatch",
+			fields: fields{
+				repoLoan:     approvedLoan(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.Investment{
+					InvestorID: 1,
+					LoanID:     3,
+					Currency:   currency.USD,
+					Amount:     currency.FromMajor(200000),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on proceeding loan",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
-						Return(&entity.Loan{
-							ID:     3,
-							Amount: 1200000,
-							Rate:   10,
-							Status: constant.StatusApproved,
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(1000000), nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{ID: 5, InvestorID: 2, LoanID: 3},
+							},
 						}, nil)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil)
 
 					return mock
 				}(),
-				lock: func() *lock.MockLock {
-					mock := lock.NewMockLock(ctrl)
-					mock.EXPECT().
-						Lock(getLockKey(3))
+				repoLoan: approvedLoan(),
+				serviceLoan: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
 					mock.EXPECT().
-						Unlock(getLockKey(3))
+						Proceed(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
 
 					return mock
 				}(),
+				serviceInstallment: projectedReturn(),
+				serviceWallet:      fundedWallet(currency.IDR),
+				serviceLedger:      postedLedger(),
+				repoInvestor:       activeInvestor(),
+				lock:               newLock(),
+				db:                 newDB(),
 			},
-			args:    defaultArgs,
+			args:    defaultArgs(),
 			wantErr: true,
 		},
 		{
-			name: "error create",
+			name: "error on calculating return",
 			fields: fields{
 				repoInvestment: func() *repository.MockInvestment {
 					mock := repository.NewMockInvestment(ctrl)
 					mock.EXPECT().
 						GetAmountSum(gomock.Any(), gomock.Any()).
-						Return(float64(1000000), nil)
+						Return(currency.FromMajor(1000000), nil)
 					mock.EXPECT().
-						Create(gomock.Any(), gomock.Any()).
-						Return(assert.AnError)
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{ID: 5, InvestorID: 2, LoanID: 3},
+							},
+						}, nil)
 
 					return mock
 				}(),
-				repoLoan: func() *repository.MockLoan {
-					mock := repository.NewMockLoan(ctrl)
+				repoLoan:      approvedLoan(),
+				serviceWallet: fundedWallet(currency.IDR),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
-						Return(&entity.Loan{
-							ID:     3,
-							Amount: 1200000,
-							Rate:   10,
-							Status: constant.StatusApproved,
-						}, nil)
+						CalculateReturn(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on transaction",
+			fields: fields{
+				repoInvestor: activeInvestor(),
+				db: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						WithTx(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
 
 					return mock
 				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error acquiring lock",
+			fields: fields{
+				repoInvestor: activeInvestor(),
 				lock: func() *lock.MockLock {
 					mock := lock.NewMockLock(ctrl)
 					mock.EXPECT().
-						Lock(getLockKey(3))
-					mock.EXPECT().
-						Unlock(getLockKey(3))
+						Lock(gomock.Any(), getLockKey(3)).
+						Return(assert.AnError)
 
 					return mock
 				}(),
+				db: newDB(),
 			},
-			args:    defaultArgs,
+			args:    defaultArgs(),
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			i := &InvestmentImpl{
-				repoInvestment: tt.fields.repoInvestment,
-				repoLoan:       tt.fields.repoLoan,
-				serviceLoan:    tt.fields.serviceLoan,
-				lock:           tt.fields.lock,
-			}
+		{
+			name:   "invalid request",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.Investment{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown investor",
+			fields: fields{
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, errorwrapper.E("data does not exist", errorwrapper.CodeNotFound))
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "inactive investor",
+			fields: fields{
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Investor{
+							ID:     1,
+							Status: constant.GeneralStatusInactive,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error get investor detail",
+			fields: fields{
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error get loan detail",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "invalid loan detail status",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(&entity.Loan{
+							ID:     3,
+							Amount: currency.FromMajor(1200000),
+							Rate:   10,
+							Status: constant.StatusProposed,
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "funding deadline has passed",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(&entity.Loan{
+							ID:              3,
+							Amount:          currency.FromMajor(1200000),
+							Rate:            10,
+							Status:          constant.StatusApproved,
+							FundingDeadline: time.Date(2024, 9, 16, 13, 58, 0, 0, time.Local),
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error get amount sum",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(0), assert.AnError)
+
+					return mock
+				}(),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(&entity.Loan{
+							ID:     3,
+							Amount: currency.FromMajor(1200000),
+							Rate:   10,
+							Status: constant.StatusApproved,
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "investment amount exceeded",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(1200000), nil)
+
+					return mock
+				}(),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(&entity.Loan{
+							ID:     3,
+							Amount: currency.FromMajor(1200000),
+							Rate:   10,
+							Status: constant.StatusApproved,
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on getting investments",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(1000000), nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, assert.AnError)
+
+					return mock
+				}(),
+				repoLoan:     approvedLoan(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on locking wallets",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(1000000), nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, nil)
+
+					return mock
+				}(),
+				repoLoan: approvedLoan(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Lock(gomock.Any(), []int64{1}, currency.IDR).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "insufficient wallet balance",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(500000), nil)
+
+					return mock
+				}(),
+				repoLoan: approvedLoan(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Hold(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error create",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(1000000), nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{ID: 5, InvestorID: 2, LoanID: 3},
+							},
+						}, nil)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(&entity.Loan{
+							ID:     3,
+							Amount: currency.FromMajor(1200000),
+							Rate:   10,
+							Status: constant.StatusApproved,
+						}, nil)
+
+					return mock
+				}(),
+				serviceInstallment: projectedReturn(),
+				serviceWallet:      fundedWallet(""),
+				repoInvestor:       activeInvestor(),
+				lock:               newLock(),
+				db:                 newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on posting ledger",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(500000), nil)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoLoan:           approvedLoan(),
+				serviceInstallment: projectedReturn(),
+				serviceWallet:      heldWallet(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				lock:         newLock(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestmentImpl{
+				repoInvestment:     tt.fields.repoInvestment,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestor:       tt.fields.repoInvestor,
+				serviceLoan:        tt.fields.serviceLoan,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
+				lock:               tt.fields.lock,
+				db:                 tt.fields.db,
+			}
 			if err := i.Invest(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
 				t.Errorf("InvestmentImpl.Invest() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestInvestmentImpl_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config         *config.Config
+		repoInvestment repository.Investment
+		repoLoan       repository.Loan
+		serviceWallet  service.Wallet
+		serviceLedger  service.Ledger
+		db             database.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	newConfig := func(window string) *config.Config {
+		return &config.Config{
+			Vendor: config.Vendor{
+				Funding: config.FundingConfig{
+					CancellationWindow: window,
+				},
+			},
+		}
+	}
+	newLoan := func(status constant.LoanStatus) *repository.MockLoan {
+		mock := repository.NewMockLoan(ctrl)
+		mock.EXPECT().
+			GetDetailForUpdate(gomock.Any(), int64(3)).
+			Return(&entity.Loan{
+				ID:     3,
+				Status: status,
+			}, nil)
+
+		return mock
+	}
+	newInvestment := func(times int, status int, createdAt time.Time) *repository.MockInvestment {
+		mock := repository.NewMockInvestment(ctrl)
+		mock.EXPECT().
+			Get(gomock.Any(), &entity.InvestmentFilter{
+				DataTable: entity.DataTableFilter{
+					Pagination: entity.DataTablePagination{
+						DisablePagination: true,
+					},
+				},
+				ID: 1,
+			}).
+			Return(entity.InvestmentResult{
+				List: []*entity.Investment{
+					{
+						ID:        1,
+						LoanID:    3,
+						Status:    status,
+						CreatedAt: createdAt,
+					},
+				},
+			}, nil).
+			Times(times)
+
+		return mock
+	}
+	releasedWallet := func() *service.MockWallet {
+		mock := service.NewMockWallet(ctrl)
+		mock.EXPECT().
+			Release(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		return mock
+	}
+	postedLedger := func() *service.MockLedger {
+		mock := service.NewMockLedger(ctrl)
+		mock.EXPECT().
+			Post(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		return mock
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		id:  1,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config: newConfig("24h"),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := newInvestment(2, constant.GeneralStatusActive, time.Now().Add(-time.Hour))
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), &entity.InvestmentFilter{
+							ID: 1,
+						}, constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				repoLoan:      newLoan(constant.StatusApproved),
+				serviceWallet: releasedWallet(),
+				serviceLedger: postedLedger(),
+				db:            newDB(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "success without cancellation window",
+			fields: fields{
+				config: newConfig(""),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := newInvestment(2, constant.GeneralStatusActive, time.Now().AddDate(0, -1, 0))
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				repoLoan:      newLoan(constant.StatusApproved),
+				serviceWallet: releasedWallet(),
+				serviceLedger: postedLedger(),
+				db:            newDB(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name:   "invalid investment ID",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid cancellation window",
+			fields: fields{
+				config: newConfig("one day"),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "investment not found",
+			fields: fields{
+				config: newConfig("24h"),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on get investment",
+			fields: fields{
+				config: newConfig("24h"),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on get loan detail",
+			fields: fields{
+				config:         newConfig("24h"),
+				repoInvestment: newInvestment(1, constant.GeneralStatusActive, time.Now()),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "loan is fully funded",
+			fields: fields{
+				config:         newConfig("24h"),
+				repoInvestment: newInvestment(1, constant.GeneralStatusActive, time.Now()),
+				repoLoan:       newLoan(constant.StatusInvested),
+				db:             newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "investment already cancelled",
+			fields: fields{
+				config:         newConfig("24h"),
+				repoInvestment: newInvestment(2, constant.GeneralStatusInactive, time.Now()),
+				repoLoan:       newLoan(constant.StatusApproved),
+				db:             newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "cancellation window has passed",
+			fields: fields{
+				config:         newConfig("24h"),
+				repoInvestment: newInvestment(2, constant.GeneralStatusActive, time.Now().Add(-25*time.Hour)),
+				repoLoan:       newLoan(constant.StatusApproved),
+				db:             newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on releasing wallet",
+			fields: fields{
+				config: newConfig("24h"),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := newInvestment(2, constant.GeneralStatusActive, time.Now())
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoLoan: newLoan(constant.StatusApproved),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Release(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on posting ledger",
+			fields: fields{
+				config: newConfig("24h"),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := newInvestment(2, constant.GeneralStatusActive, time.Now())
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoLoan:      newLoan(constant.StatusApproved),
+				serviceWallet: releasedWallet(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on update status",
+			fields: fields{
+				config: newConfig("24h"),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := newInvestment(2, constant.GeneralStatusActive, time.Now())
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoLoan: newLoan(constant.StatusApproved),
+				db:       newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestmentImpl{
+				config:         tt.fields.config,
+				repoInvestment: tt.fields.repoInvestment,
+				repoLoan:       tt.fields.repoLoan,
+				serviceWallet:  tt.fields.serviceWallet,
+				serviceLedger:  tt.fields.serviceLedger,
+				db:             tt.fields.db,
+			}
+			if err := i.Cancel(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("InvestmentImpl.Cancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
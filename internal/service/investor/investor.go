@@ -0,0 +1,140 @@
+package investor
+
+import (
+	"context"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type InvestorImpl struct {
+	repo repository.Investor
+}
+
+func NewInvestorImpl(
+	repo repository.Investor,
+) service.Investor {
+	return &InvestorImpl{
+		repo: repo,
+	}
+}
+
+// Get will return investor data based on filter
+func (i *InvestorImpl) Get(
+	ctx context.Context,
+	filter *entity.InvestorFilter,
+) ([]*entity.Investor, error) {
+	return i.repo.Get(ctx, filter)
+}
+
+// GetDetail will return investor data based on ID
+func (i *InvestorImpl) GetDetail(
+	ctx context.Context,
+	id int64,
+) (*entity.Investor, error) {
+	if id <= 0 {
+		return nil, errorwrapper.E("invalid investor ID", errorwrapper.CodeInvalid)
+	}
+
+	return i.repo.GetDetail(ctx, id)
+}
+
+// Create will register an active investor with unique email and identification number
+func (i *InvestorImpl) Create(
+	ctx context.Context,
+	model *entity.Investor,
+) error {
+	model.Normalize()
+	if !model.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+
+	err := i.validateUniqueness(ctx, model)
+	if err != nil {
+		return err
+	}
+	model.Status = constant.GeneralStatusActive
+
+	return i.repo.Create(ctx, model)
+}
+
+// Update will update investor data, status is left untouched
+// so investors are only activated or deactivated through UpdateStatus
+func (i *InvestorImpl) Update(
+	ctx context.Context,
+	model *entity.Investor,
+) error {
+	model.Normalize()
+	if !model.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+
+	existing, err := i.GetDetail(ctx, model.ID)
+	if err != nil {
+		return err
+	}
+
+	err = i.validateUniqueness(ctx, model)
+	if err != nil {
+		return err
+	}
+	model.Status = existing.Status
+	model.CreatedAt = existing.CreatedAt
+
+	return i.repo.Update(ctx, model)
+}
+
+// UpdateStatus will activate or deactivate investor,
+// inactive investors can't make new investments while their existing investments are left untouched
+func (i *InvestorImpl) UpdateStatus(
+	ctx context.Context,
+	id int64,
+	status int,
+) error {
+	if status != constant.GeneralStatusActive && status != constant.GeneralStatusInactive {
+		return errorwrapper.E("invalid investor status", errorwrapper.CodeInvalid)
+	}
+
+	existing, err := i.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+	existing.Status = status
+
+	return i.repo.Update(ctx, existing)
+}
+
+// validateUniqueness makes sure no other investor is registered with the same email or identification number
+func (i *InvestorImpl) validateUniqueness(
+	ctx context.Context,
+	model *entity.Investor,
+) error {
+	list, err := i.repo.Get(ctx, &entity.InvestorFilter{
+		Email: model.Email,
+	})
+	if err != nil {
+		return err
+	}
+	for _, investor := range list {
+		if investor.ID != model.ID {
+			return errorwrapper.E("email is already registered", errorwrapper.CodeInvalid)
+		}
+	}
+
+	list, err = i.repo.Get(ctx, &entity.InvestorFilter{
+		IdentificationNumber: model.IdentificationNumber,
+	})
+	if err != nil {
+		return err
+	}
+	for _, investor := range list {
+		if investor.ID != model.ID {
+			return errorwrapper.E("identification number is already registered", errorwrapper.CodeInvalid)
+		}
+	}
+
+	return nil
+}
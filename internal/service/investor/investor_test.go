@@ -0,0 +1,700 @@
+package investor
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInvestorImpl(t *testing.T) {
+	type args struct {
+		repo repository.Investor
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Investor
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &InvestorImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewInvestorImpl(tt.args.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewInvestorImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestorImpl_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Investor
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.InvestorFilter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Investor
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InvestorFilter{
+							Status: constant.GeneralStatusActive,
+						}).
+						Return([]*entity.Investor{
+							{
+								ID:                   1,
+								IdentificationNumber: "1234567890123454",
+								Name:                 "Investor A",
+								Email:                "investor.a@example.com",
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.InvestorFilter{
+					Status: constant.GeneralStatusActive,
+				},
+			},
+			want: []*entity.Investor{
+				{
+					ID:                   1,
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                "investor.a@example.com",
+				},
+			},
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.InvestorFilter{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestorImpl{
+				repo: tt.fields.repo,
+			}
+			got, err := i.Get(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InvestorImpl.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InvestorImpl.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestorImpl_GetDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Investor
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.Investor
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Investor{
+							ID:                   1,
+							IdentificationNumber: "1234567890123454",
+							Name:                 "Investor A",
+							Email:                "investor.a@example.com",
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want: &entity.Investor{
+				ID:                   1,
+				IdentificationNumber: "1234567890123454",
+				Name:                 "Investor A",
+				Email:                "investor.a@example.com",
+			},
+		},
+		{
+			name:   "invalid id",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error on get detail",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestorImpl{
+				repo: tt.fields.repo,
+			}
+			got, err := i.GetDetail(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InvestorImpl.GetDetail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InvestorImpl.GetDetail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvestorImpl_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Investor
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Investor
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					want := &entity.Investor{
+						IdentificationNumber: "1234567890123454",
+						Name:                 "Investor A",
+						Email:                "investor.a@example.com",
+						Status:               constant.GeneralStatusActive,
+					}
+
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InvestorFilter{
+							Email: "investor.a@example.com",
+						}).
+						Return([]*entity.Investor{}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.InvestorFilter{
+							IdentificationNumber: "1234567890123454",
+						}).
+						Return([]*entity.Investor{}, nil)
+					mock.EXPECT().
+						Create(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                " Investor.A@Example.com ",
+				},
+			},
+		},
+		{
+			name:   "invalid param",
+			fields: fields{},
+			args: args{
+				ctx:   context.Background(),
+				model: &entity.Investor{Name: "Investor A"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "email already registered",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Investor{
+							{
+								ID:                   1,
+								IdentificationNumber: "1234567890123454",
+								Name:                 "Investor A",
+								Email:                "investor.a@example.com",
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                " Investor.A@Example.com ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "identification number already registered",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Investor{}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Investor{
+							{
+								ID:                   1,
+								IdentificationNumber: "1234567890123454",
+								Name:                 "Investor A",
+								Email:                "investor.a@example.com",
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                " Investor.A@Example.com ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                " Investor.A@Example.com ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on create",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Investor{}, nil).
+						Times(2)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                " Investor.A@Example.com ",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestorImpl{
+				repo: tt.fields.repo,
+			}
+			if err := i.Create(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("InvestorImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInvestorImpl_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Investor
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.Investor
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					existing := &entity.Investor{
+						ID:                   1,
+						IdentificationNumber: "1234567890123454",
+						Name:                 "Investor A",
+						Email:                "investor.a@example.com",
+						Status:               constant.GeneralStatusInactive,
+					}
+					want := &entity.Investor{
+						ID:                   1,
+						IdentificationNumber: "1234567890123454",
+						Name:                 "Investor A",
+						Email:                "investor.a@example.com",
+						Status:               constant.GeneralStatusInactive,
+					}
+
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(existing, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Investor{existing}, nil).
+						Times(2)
+					mock.EXPECT().
+						Update(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					ID:                   1,
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                "investor.a@example.com",
+					Status:               constant.GeneralStatusActive,
+				},
+			},
+		},
+		{
+			name:   "invalid param",
+			fields: fields{},
+			args: args{
+				ctx:   context.Background(),
+				model: &entity.Investor{ID: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get detail",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					ID:                   1,
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                "investor.a@example.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "email already registered",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					other := &entity.Investor{
+						ID:                   2,
+						IdentificationNumber: "1234567890123454",
+						Name:                 "Investor A",
+						Email:                "investor.a@example.com",
+					}
+
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Investor{
+							ID:                   1,
+							IdentificationNumber: "1234567890123454",
+							Name:                 "Investor A",
+							Email:                "investor.a@example.com",
+						}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Investor{other}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					ID:                   1,
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                "investor.a@example.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Investor{
+							ID:                   1,
+							IdentificationNumber: "1234567890123454",
+							Name:                 "Investor A",
+							Email:                "investor.a@example.com",
+						}, nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Investor{}, nil).
+						Times(2)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Investor{
+					ID:                   1,
+					IdentificationNumber: "1234567890123454",
+					Name:                 "Investor A",
+					Email:                "investor.a@example.com",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestorImpl{
+				repo: tt.fields.repo,
+			}
+			if err := i.Update(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("InvestorImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInvestorImpl_UpdateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.Investor
+	}
+	type args struct {
+		ctx    context.Context
+		id     int64
+		status int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					existing := &entity.Investor{
+						ID:                   1,
+						IdentificationNumber: "1234567890123454",
+						Name:                 "Investor A",
+						Email:                "investor.a@example.com",
+						Status:               constant.GeneralStatusActive,
+					}
+					want := &entity.Investor{
+						ID:                   1,
+						IdentificationNumber: "1234567890123454",
+						Name:                 "Investor A",
+						Email:                "investor.a@example.com",
+						Status:               constant.GeneralStatusInactive,
+					}
+
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(existing, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: constant.GeneralStatusInactive,
+			},
+		},
+		{
+			name:   "invalid status",
+			fields: fields{},
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: 3,
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid id",
+			fields: fields{},
+			args: args{
+				ctx:    context.Background(),
+				status: constant.GeneralStatusActive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repo: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Investor{
+							ID:                   1,
+							IdentificationNumber: "1234567890123454",
+							Name:                 "Investor A",
+							Email:                "investor.a@example.com",
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     1,
+				status: constant.GeneralStatusActive,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &InvestorImpl{
+				repo: tt.fields.repo,
+			}
+			if err := i.UpdateStatus(tt.args.ctx, tt.args.id, tt.args.status); (err != nil) != tt.wantErr {
+				t.Errorf("InvestorImpl.UpdateStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type LedgerImpl struct {
+	repoLedger repository.Ledger
+}
+
+func NewLedgerImpl(
+	repoLedger repository.Ledger,
+) service.Ledger {
+	return &LedgerImpl{
+		repoLedger: repoLedger,
+	}
+}
+
+// Post will record a journal of a money movement,
+// it joins the transaction carried by the context so the journal is written if and only if the movement is committed
+func (l *LedgerImpl) Post(
+	ctx context.Context,
+	journal *entity.LedgerJournal,
+) error {
+	if !currency.IsSupported(journal.Currency) {
+		return errorwrapper.E("unsupported ledger journal currency", errorwrapper.CodeInternal)
+	}
+	if !journal.IsBalanced() {
+		return errorwrapper.E("ledger journal doesn't balance", errorwrapper.CodeInternal)
+	}
+
+	return l.repoLedger.CreateJournal(ctx, journal)
+}
+
+// GetTrialBalance will return debit and credit totals of every ledger account in a currency up to a point in time,
+// it defaults to rupiah as of now
+func (l *LedgerImpl) GetTrialBalance(
+	ctx context.Context,
+	filter *entity.TrialBalanceFilter,
+) (*entity.TrialBalance, error) {
+	if filter.Currency == "" {
+		filter.Currency = currency.IDR
+	}
+	if !currency.IsSupported(filter.Currency) {
+		return nil, errorwrapper.E("unsupported currency", errorwrapper.CodeInvalid)
+	}
+	if filter.AsOf.IsZero() {
+		filter.AsOf = time.Now()
+	}
+
+	accounts, err := l.repoLedger.GetTrialBalance(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTrialBalance(filter, accounts), nil
+}
@@ -0,0 +1,254 @@
+package ledger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLedgerImpl(t *testing.T) {
+	type args struct {
+		repoLedger repository.Ledger
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Ledger
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &LedgerImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLedgerImpl(tt.args.repoLedger); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewLedgerImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLedgerImpl_Post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoLedger repository.Ledger
+	}
+	type args struct {
+		ctx     context.Context
+		journal *entity.LedgerJournal
+	}
+	newJournal := func(code string, debit, credit currency.Amount) *entity.LedgerJournal {
+		return &entity.LedgerJournal{
+			Currency: code,
+			Entries: []*entity.LedgerEntry{
+				{AccountCode: constant.LedgerAccountBank, Debit: debit},
+				{AccountCode: constant.LedgerAccountInvestorWallet, Credit: credit},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLedger: func() *repository.MockLedger {
+					mock := repository.NewMockLedger(ctrl)
+					mock.EXPECT().
+						CreateJournal(gomock.Any(), newJournal(currency.IDR, currency.FromMajor(1000), currency.FromMajor(1000))).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:     context.Background(),
+				journal: newJournal(currency.IDR, currency.FromMajor(1000), currency.FromMajor(1000)),
+			},
+		},
+		{
+			name:   "unsupported currency",
+			fields: fields{},
+			args: args{
+				ctx:     context.Background(),
+				journal: newJournal("XYZ", currency.FromMajor(1000), currency.FromMajor(1000)),
+			},
+			wantErr: true,
+		},
+		{
+			name:   "unbalanced journal",
+			fields: fields{},
+			args: args{
+				ctx:     context.Background(),
+				journal: newJournal(currency.IDR, currency.FromMajor(1000), currency.FromMajor(999)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on create journal",
+			fields: fields{
+				repoLedger: func() *repository.MockLedger {
+					mock := repository.NewMockLedger(ctrl)
+					mock.EXPECT().
+						CreateJournal(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:     context.Background(),
+				journal: newJournal(currency.IDR, currency.FromMajor(1000), currency.FromMajor(1000)),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LedgerImpl{
+				repoLedger: tt.fields.repoLedger,
+			}
+			if err := l.Post(tt.args.ctx, tt.args.journal); (err != nil) != tt.wantErr {
+				t.Errorf("LedgerImpl.Post() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLedgerImpl_GetTrialBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoLedger repository.Ledger
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.TrialBalanceFilter
+	}
+	asOf := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.TrialBalance
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLedger: func() *repository.MockLedger {
+					mock := repository.NewMockLedger(ctrl)
+					mock.EXPECT().
+						GetTrialBalance(gomock.Any(), &entity.TrialBalanceFilter{
+							Currency: currency.IDR,
+							AsOf:     asOf,
+						}).
+						Return([]*entity.TrialBalanceAccount{
+							{
+								Code:   constant.LedgerAccountBank,
+								Type:   constant.LedgerAccountTypeAsset,
+								Debit:  currency.FromMajor(1000),
+								Credit: currency.FromMajor(200),
+							},
+							{
+								Code:   constant.LedgerAccountInvestorWallet,
+								Type:   constant.LedgerAccountTypeLiability,
+								Credit: currency.FromMajor(800),
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.TrialBalanceFilter{
+					AsOf: asOf,
+				},
+			},
+			want: &entity.TrialBalance{
+				Currency: currency.IDR,
+				AsOf:     asOf,
+				Accounts: []*entity.TrialBalanceAccount{
+					{
+						Code:    constant.LedgerAccountBank,
+						Type:    constant.LedgerAccountTypeAsset,
+						Debit:   currency.FromMajor(1000),
+						Credit:  currency.FromMajor(200),
+						Balance: currency.FromMajor(800),
+					},
+					{
+						Code:    constant.LedgerAccountInvestorWallet,
+						Type:    constant.LedgerAccountTypeLiability,
+						Credit:  currency.FromMajor(800),
+						Balance: currency.FromMajor(800),
+					},
+				},
+				TotalDebit:  currency.FromMajor(1000),
+				TotalCredit: currency.FromMajor(1000),
+				IsBalanced:  true,
+			},
+		},
+		{
+			name:   "unsupported currency",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.TrialBalanceFilter{
+					Currency: "XYZ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get trial balance",
+			fields: fields{
+				repoLedger: func() *repository.MockLedger {
+					mock := repository.NewMockLedger(ctrl)
+					mock.EXPECT().
+						GetTrialBalance(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.TrialBalanceFilter{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LedgerImpl{
+				repoLedger: tt.fields.repoLedger,
+			}
+			got, err := l.GetTrialBalance(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LedgerImpl.GetTrialBalance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LedgerImpl.GetTrialBalance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
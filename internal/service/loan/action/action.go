@@ -3,8 +3,8 @@ package action
 import (
 	"context"
 	"fmt"
-	"log"
-	"sync"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
@@ -19,33 +19,45 @@ import (
 
 type (
 	LoanActionImpl struct {
-		config         *config.Config
-		repoLoan       repository.Loan
-		repoInvestment repository.Investment
-		repoInvestor   repository.Investor
-		repoUpload     repository.Upload
-		repoNotifier   repository.Notifier
-		pdfGenerator   file.PDFGenerator
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoProduct        repository.LoanProduct
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
 	}
 )
 
 func NewLoanActionImpl(
 	config *config.Config,
 	repoLoan repository.Loan,
+	repoProduct repository.LoanProduct,
 	repoInvestment repository.Investment,
 	repoInvestor repository.Investor,
 	repoUpload repository.Upload,
-	repoNotifier repository.Notifier,
+	repoOutbox repository.Outbox,
 	pdfGenerator file.PDFGenerator,
+	serviceInstallment service.Installment,
+	serviceWallet service.Wallet,
+	serviceLedger service.Ledger,
 ) service.LoanAction {
 	return &LoanActionImpl{
-		config:         config,
-		repoLoan:       repoLoan,
-		repoInvestment: repoInvestment,
-		repoInvestor:   repoInvestor,
-		repoUpload:     repoUpload,
-		repoNotifier:   repoNotifier,
-		pdfGenerator:   pdfGenerator,
+		config:             config,
+		repoLoan:           repoLoan,
+		repoProduct:        repoProduct,
+		repoInvestment:     repoInvestment,
+		repoInvestor:       repoInvestor,
+		repoUpload:         repoUpload,
+		repoOutbox:         repoOutbox,
+		pdfGenerator:       pdfGenerator,
+		serviceInstallment: serviceInstallment,
+		serviceWallet:      serviceWallet,
+		serviceLedger:      serviceLedger,
 	}
 }
 
@@ -61,6 +73,15 @@ func (a *LoanActionImpl) Approve(ctx context.Context, req *entity.LoanProceed) e
 		return errorwrapper.E("invalid approver ID", errorwrapper.CodeInvalid)
 	}
 
+	// approved loan is only open for investment until its funding deadline
+	// fallback to the configured default duration if no deadline is given, zero duration means no deadline
+	if req.Data.FundingDeadline.IsZero() && a.config.Vendor.Funding.DeadlineDays > 0 {
+		req.Data.FundingDeadline = req.Data.ApprovedAt.AddDate(0, 0, a.config.Vendor.Funding.DeadlineDays)
+	}
+	if !req.Data.FundingDeadline.IsZero() && !req.Data.FundingDeadline.After(req.Data.ApprovedAt) {
+		return errorwrapper.E("funding deadline must be after approval timestamp", errorwrapper.CodeInvalid)
+	}
+
 	// process upload
 	// simulate upload approval proof file
 	// this will upload to our local path instead of a dedicated upload engine
@@ -77,9 +98,7 @@ func (a *LoanActionImpl) Approve(ctx context.Context, req *entity.LoanProceed) e
 		return err
 	}
 
-	req.Data.Status = constant.StatusApproved
-
-	return a.repoLoan.Update(ctx, req.Data)
+	return a.updateStatus(ctx, req, req.Data.ApprovedBy)
 }
 
 func (a *LoanActionImpl) Invest(ctx context.Context, req *entity.LoanProceed) error {
@@ -104,9 +123,7 @@ func (a *LoanActionImpl) Invest(ctx context.Context, req *entity.LoanProceed) er
 		return errorwrapper.E("investment sum doesn't equal the loan's principle amount", errorwrapper.CodeInvalid)
 	}
 
-	req.Data.Status = constant.StatusInvested
-
-	err = a.repoLoan.Update(ctx, req.Data)
+	err = a.updateStatus(ctx, req, 0)
 	if err != nil {
 		return err
 	}
@@ -125,9 +142,13 @@ func (a *LoanActionImpl) Invest(ctx context.Context, req *entity.LoanProceed) er
 	if err != nil {
 		return err
 	}
-	a.notifyBulkInvestor(investment.List, req.Data)
 
-	return nil
+	// funds held for the investments are now committed to the fully funded loan
+	err = a.settleWallets(ctx, investment.List, a.serviceWallet.Commit)
+	if err != nil {
+		return err
+	}
+	return a.notifyBulkInvestor(ctx, investment.List, req.Data, a.newAgreementNotifier)
 }
 
 func (a *LoanActionImpl) Disburse(ctx context.Context, req *entity.LoanProceed) error {
@@ -158,42 +179,309 @@ func (a *LoanActionImpl) Disburse(ctx context.Context, req *entity.LoanProceed)
 		return err
 	}
 
-	req.Data.Status = constant.StatusDisbursed
+	// fees of the product and the platform origination fee are deducted from the amount transferred to the borrower
+	product, err := a.getProduct(ctx, req.Data)
+	if err != nil {
+		return err
+	}
+	req.Data.ChargeFees(product, a.config.Vendor.Fee.OriginationFee)
+	if req.Data.DisbursedAmount <= 0 {
+		return errorwrapper.E("fees exceed the loan amount", errorwrapper.CodeInvalid)
+	}
+
+	err = a.updateStatus(ctx, req, req.Data.DisbursedBy)
+	if err != nil {
+		return err
+	}
 
-	return a.repoLoan.Update(ctx, req.Data)
+	// the escrow of the loan is paid out to the borrower
+	err = a.serviceLedger.Post(ctx, entity.NewDisbursementJournal(req.Data))
+	if err != nil {
+		return err
+	}
+
+	// generate repayment schedule of the loan, starting from disbursement date
+	return a.serviceInstallment.Generate(ctx, req.Data)
 }
 
-func (a *LoanActionImpl) notifyBulkInvestor(
+func (a *LoanActionImpl) Reject(ctx context.Context, req *entity.LoanProceed) error {
+	if req.Data.RejectedAt.IsZero() {
+		return errorwrapper.E("invalid rejection timestamp", errorwrapper.CodeInvalid)
+	}
+	if req.Data.RejectedBy <= 0 {
+		return errorwrapper.E("invalid rejector ID", errorwrapper.CodeInvalid)
+	}
+	if strings.TrimSpace(req.Data.Reason) == "" {
+		return errorwrapper.E("invalid rejection reason", errorwrapper.CodeInvalid)
+	}
+
+	return a.updateStatus(ctx, req, req.Data.RejectedBy)
+}
+
+func (a *LoanActionImpl) Cancel(ctx context.Context, req *entity.LoanProceed) error {
+	var (
+		err error
+	)
+
+	if req.Data.CancelledAt.IsZero() {
+		return errorwrapper.E("invalid cancellation timestamp", errorwrapper.CodeInvalid)
+	}
+	if req.Data.CancelledBy <= 0 {
+		return errorwrapper.E("invalid canceller ID", errorwrapper.CodeInvalid)
+	}
+	if strings.TrimSpace(req.Data.Reason) == "" {
+		return errorwrapper.E("invalid cancellation reason", errorwrapper.CodeInvalid)
+	}
+
+	err = a.updateStatus(ctx, req, req.Data.CancelledBy)
+	if err != nil {
+		return err
+	}
+
+	// release all existing investments of the loan
+	// first, get all related investors data before they are moved to inactive
+	investment, err := a.repoInvestment.Get(ctx, &entity.InvestmentFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		LoanID: req.Data.ID,
+		Status: constant.GeneralStatusActive,
+	})
+	if err != nil {
+		return err
+	}
+	if len(investment.List) <= 0 {
+		return nil
+	}
+
+	err = a.repoInvestment.UpdateStatus(ctx, &entity.InvestmentFilter{
+		LoanID: req.Data.ID,
+		Status: constant.GeneralStatusActive,
+	}, constant.GeneralStatusInactive)
+	if err != nil {
+		return err
+	}
+
+	// funds held for the investments are returned to the available balance of their investors
+	err = a.settleWallets(ctx, investment.List, a.releaseFunds)
+	if err != nil {
+		return err
+	}
+	return a.notifyBulkInvestor(ctx, investment.List, req.Data, a.newCancellationNotifier)
+}
+
+func (a *LoanActionImpl) Repay(ctx context.Context, req *entity.LoanProceed) error {
+	if req.Data.RepaidAt.IsZero() {
+		return errorwrapper.E("invalid repayment timestamp", errorwrapper.CodeInvalid)
+	}
+
+	// validate outstanding balance
+	// if there is any unpaid installment left, return error
+	schedule, err := a.serviceInstallment.GetSchedule(ctx, req.Data.ID)
+	if err != nil {
+		return err
+	}
+	if len(schedule) <= 0 {
+		return errorwrapper.E("loan doesn't have any repayment schedule", errorwrapper.CodeInvalid)
+	}
+	for _, installment := range schedule {
+		if installment.Status != constant.InstallmentStatusPaid {
+			return errorwrapper.E("loan still has outstanding balance", errorwrapper.CodeInvalid)
+		}
+	}
+
+	return a.updateStatus(ctx, req, 0)
+}
+
+func (a *LoanActionImpl) Default(ctx context.Context, req *entity.LoanProceed) error {
+	var (
+		err error
+	)
+
+	if req.Data.DefaultedAt.IsZero() {
+		return errorwrapper.E("invalid default timestamp", errorwrapper.CodeInvalid)
+	}
+
+	err = a.updateStatus(ctx, req, 0)
+	if err != nil {
+		return err
+	}
+
+	// notify all related investors about the defaulted loan
+	investment, err := a.repoInvestment.Get(ctx, &entity.InvestmentFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		LoanID: req.Data.ID,
+		Status: constant.GeneralStatusActive,
+	})
+	if err != nil {
+		return err
+	}
+	return a.notifyBulkInvestor(ctx, investment.List, req.Data, a.newDefaultNotifier)
+}
+
+func (a *LoanActionImpl) Expire(ctx context.Context, req *entity.LoanProceed) error {
+	var (
+		err error
+	)
+
+	if req.Data.ExpiredAt.IsZero() {
+		return errorwrapper.E("invalid expiry timestamp", errorwrapper.CodeInvalid)
+	}
+	if req.Data.FundingDeadline.IsZero() || req.Data.ExpiredAt.Before(req.Data.FundingDeadline) {
+		return errorwrapper.E("loan funding deadline has not passed yet", errorwrapper.CodeInvalid)
+	}
+
+	err = a.updateStatus(ctx, req, 0)
+	if err != nil {
+		return err
+	}
+
+	// release all existing investments of the loan
+	// first, get all related investors data before they are moved to inactive
+	investment, err := a.repoInvestment.Get(ctx, &entity.InvestmentFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		LoanID: req.Data.ID,
+		Status: constant.GeneralStatusActive,
+	})
+	if err != nil {
+		return err
+	}
+	if len(investment.List) <= 0 {
+		return nil
+	}
+
+	err = a.repoInvestment.UpdateStatus(ctx, &entity.InvestmentFilter{
+		LoanID: req.Data.ID,
+		Status: constant.GeneralStatusActive,
+	}, constant.GeneralStatusInactive)
+	if err != nil {
+		return err
+	}
+
+	// funds held for the investments are returned to the available balance of their investors
+	err = a.settleWallets(ctx, investment.List, a.releaseFunds)
+	if err != nil {
+		return err
+	}
+	return a.notifyBulkInvestor(ctx, investment.List, req.Data, a.newExpiryNotifier)
+}
+
+// getProduct returns the product the loan is created under, loans created before products existed have none
+func (a *LoanActionImpl) getProduct(ctx context.Context, loan *entity.Loan) (*entity.LoanProduct, error) {
+	if loan.ProductID <= 0 {
+		return nil, nil
+	}
+
+	return a.repoProduct.GetDetail(ctx, loan.ProductID)
+}
+
+// updateStatus moves the loan to the next status determined by the state machine
+// and records the transition along with the actor
+func (a *LoanActionImpl) updateStatus(
+	ctx context.Context,
+	req *entity.LoanProceed,
+	actorID int64,
+) error {
+	if req.NextStatus <= 0 {
+		return errorwrapper.E("invalid next status", errorwrapper.CodeInternal)
+	}
+
+	history := &entity.LoanStateHistory{
+		LoanID:     req.Data.ID,
+		FromStatus: req.Data.Status,
+		ToStatus:   req.NextStatus,
+		Action:     req.Action,
+		ActorID:    actorID,
+		Metadata:   req.Metadata,
+	}
+	req.Data.Status = req.NextStatus
+
+	return a.repoLoan.Update(ctx, req.Data, history)
+}
+
+// settleWallets applies the balance movement of every investment on the wallet of its investor,
+// wallets are locked in the order of investor ID as every transaction moving more than one wallet does
+func (a *LoanActionImpl) settleWallets(
+	ctx context.Context,
 	investments []*entity.Investment,
-	loan *entity.Loan,
-) {
-	var wg sync.WaitGroup
+	settle func(context.Context, *entity.Investment) error,
+) error {
+	sorted := make([]*entity.Investment, len(investments))
+	copy(sorted, investments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].InvestorID < sorted[j].InvestorID
+	})
 
-	for _, val := range investments {
-		wg.Add(1)
+	for _, investment := range sorted {
+		err := settle(ctx, investment)
+		if err != nil {
+			return err
+		}
+	}
 
-		go func(investment *entity.Investment) {
-			defer wg.Done()
+	return nil
+}
 
-			investor, err := a.repoInvestor.GetDetail(context.TODO(), investment.InvestorID)
-			if err != nil {
-				log.Println("error getting investor detail", err)
-				return
-			}
+// releaseFunds returns the held funds of the investment to its investor
+// and reverses the funding of the loan in the ledger
+func (a *LoanActionImpl) releaseFunds(
+	ctx context.Context,
+	investment *entity.Investment,
+) error {
+	err := a.serviceWallet.Release(ctx, investment)
+	if err != nil {
+		return err
+	}
 
-			if err = a.notifyInvestor(investor, investment, loan); err != nil {
-				log.Println("error notifying investor", err)
-			}
-		}(val)
+	return a.serviceLedger.Post(ctx, entity.NewFundingReversalJournal(investment))
+}
+
+// notifyBulkInvestor writes the notification of every investor into the outbox,
+// it joins the transaction of the state change so the notifications are written if and only if the state change is committed
+func (a *LoanActionImpl) notifyBulkInvestor(
+	ctx context.Context,
+	investments []*entity.Investment,
+	loan *entity.Loan,
+	newNotifier func(context.Context, *entity.Investor, *entity.Investment, *entity.Loan) (*entity.Notifier, error),
+) error {
+	for _, investment := range investments {
+		investor, err := a.repoInvestor.GetDetail(ctx, investment.InvestorID)
+		if err != nil {
+			return err
+		}
+
+		notifier, err := newNotifier(ctx, investor, investment, loan)
+		if err != nil {
+			return err
+		}
+
+		err = a.repoOutbox.Create(ctx, entity.NewOutbox(notifier))
+		if err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-func (a *LoanActionImpl) notifyInvestor(
+func (a *LoanActionImpl) newAgreementNotifier(
+	ctx context.Context,
 	investor *entity.Investor,
 	investment *entity.Investment,
 	loan *entity.Loan,
-) error {
-	finalInvestedAmount := investment.Amount + (investment.Amount * investment.ROI / 100)
+) (*entity.Notifier, error) {
+	// the letter states the return the investor was shown when investing, not one recalculated on the current config
+	investmentReturn := investment.ProjectedReturn()
 
 	pdfContent := fmt.Sprintf(
 		agreementLetterFormat,
@@ -202,27 +490,33 @@ func (a *LoanActionImpl) notifyInvestor(
 		investor.Name,
 		loan.ID,
 		loan.ID,
-		currency.ToRupiahFormat(loan.Amount),
-		currency.ToRupiahFormat(investment.Amount),
-		fmt.Sprintf("%.2f%%", loan.Rate),
-		fmt.Sprintf("%.2f%%", investment.ROI),
-		currency.ToRupiahFormat(finalInvestedAmount),
+		currency.Format(loan.Amount, loan.Currency),
+		currency.Format(investment.Amount, loan.Currency),
+		fmt.Sprintf("%.2f%% p.a.", loan.Rate),
+		loan.Tenor,
+		currency.Format(investmentReturn.Return, loan.Currency),
+		currency.Format(investmentReturn.ServiceFee, loan.Currency),
+		currency.Format(investmentReturn.Tax, loan.Currency),
+		fmt.Sprintf("%.2f%%", investmentReturn.ROI),
+		currency.Format(investmentReturn.FinalAmount, loan.Currency),
 		investment.CreatedAt.Format(constant.DateBeautifyFormat),
 	)
 	fileBytes, err := a.pdfGenerator.Generate(pdfContent)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	emailBodyContent := fmt.Sprintf(agreementEmailFormat,
 		investor.Name,
 		loan.ID,
 		investment.CreatedAt.Format(constant.DateBeautifyFormat),
-		currency.ToRupiahFormat(investment.Amount),
-		fmt.Sprintf("%.2f%%", investment.ROI),
-		currency.ToRupiahFormat(finalInvestedAmount),
+		currency.Format(investment.Amount, loan.Currency),
+		currency.Format(investmentReturn.ServiceFee, loan.Currency),
+		currency.Format(investmentReturn.Tax, loan.Currency),
+		fmt.Sprintf("%.2f%%", investmentReturn.ROI),
+		currency.Format(investmentReturn.FinalAmount, loan.Currency),
 	)
-	return a.repoNotifier.Notify(context.TODO(), &entity.Notifier{
+	return &entity.Notifier{
 		To:      []string{investor.Email},
 		Subject: fmt.Sprintf("Agreement Letter - Loan ID %d", loan.ID),
 		Body:    emailBodyContent,
@@ -230,5 +524,65 @@ func (a *LoanActionImpl) notifyInvestor(
 			File:     fileBytes,
 			FileName: fmt.Sprintf(a.config.Vendor.DefaultAgreementLetter.DestFileName, investor.Name),
 		},
-	})
+	}, nil
+}
+
+func (a *LoanActionImpl) newCancellationNotifier(
+	ctx context.Context,
+	investor *entity.Investor,
+	investment *entity.Investment,
+	loan *entity.Loan,
+) (*entity.Notifier, error) {
+	emailBodyContent := fmt.Sprintf(cancellationEmailFormat,
+		investor.Name,
+		loan.ID,
+		loan.Reason,
+		investment.CreatedAt.Format(constant.DateBeautifyFormat),
+		currency.Format(investment.Amount, loan.Currency),
+	)
+	return &entity.Notifier{
+		To:      []string{investor.Email},
+		Subject: fmt.Sprintf("Loan Cancellation - Loan ID %d", loan.ID),
+		Body:    emailBodyContent,
+	}, nil
+}
+
+func (a *LoanActionImpl) newDefaultNotifier(
+	ctx context.Context,
+	investor *entity.Investor,
+	investment *entity.Investment,
+	loan *entity.Loan,
+) (*entity.Notifier, error) {
+	emailBodyContent := fmt.Sprintf(defaultEmailFormat,
+		investor.Name,
+		loan.ID,
+		loan.DaysPastDue,
+		investment.CreatedAt.Format(constant.DateBeautifyFormat),
+		currency.Format(investment.Amount, loan.Currency),
+	)
+	return &entity.Notifier{
+		To:      []string{investor.Email},
+		Subject: fmt.Sprintf("Loan Default - Loan ID %d", loan.ID),
+		Body:    emailBodyContent,
+	}, nil
+}
+
+func (a *LoanActionImpl) newExpiryNotifier(
+	ctx context.Context,
+	investor *entity.Investor,
+	investment *entity.Investment,
+	loan *entity.Loan,
+) (*entity.Notifier, error) {
+	emailBodyContent := fmt.Sprintf(expiryEmailFormat,
+		investor.Name,
+		loan.ID,
+		loan.FundingDeadline.Format(constant.DateBeautifyFormat),
+		investment.CreatedAt.Format(constant.DateBeautifyFormat),
+		currency.Format(investment.Amount, loan.Currency),
+	)
+	return &entity.Notifier{
+		To:      []string{investor.Email},
+		Subject: fmt.Sprintf("Loan Expiry - Loan ID %d", loan.ID),
+		Body:    emailBodyContent,
+	}, nil
 }
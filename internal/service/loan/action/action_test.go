@@ -2,6 +2,7 @@ package action
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/ecintiawan/loan-service/internal/repository"
 	"github.com/ecintiawan/loan-service/internal/service"
 	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
 	"github.com/ecintiawan/loan-service/pkg/file"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -18,13 +20,17 @@ import (
 
 func TestNewLoanActionImpl(t *testing.T) {
 	type args struct {
-		config         *config.Config
-		repoLoan       repository.Loan
-		repoInvestment repository.Investment
-		repoInvestor   repository.Investor
-		repoUpload     repository.Upload
-		repoNotifier   repository.Notifier
-		pdfGenerator   file.PDFGenerator
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoProduct        repository.LoanProduct
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
 	}
 	tests := []struct {
 		name string
@@ -39,7 +45,7 @@ func TestNewLoanActionImpl(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewLoanActionImpl(tt.args.config, tt.args.repoLoan, tt.args.repoInvestment, tt.args.repoInvestor, tt.args.repoUpload, tt.args.repoNotifier, tt.args.pdfGenerator); !reflect.DeepEqual(got, tt.want) {
+			if got := NewLoanActionImpl(tt.args.config, tt.args.repoLoan, tt.args.repoProduct, tt.args.repoInvestment, tt.args.repoInvestor, tt.args.repoUpload, tt.args.repoOutbox, tt.args.pdfGenerator, tt.args.serviceInstallment, tt.args.serviceWallet, tt.args.serviceLedger); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewLoanActionImpl() = %v, want %v", got, tt.want)
 			}
 		})
@@ -51,13 +57,16 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		config         *config.Config
-		repoLoan       repository.Loan
-		repoInvestment repository.Investment
-		repoInvestor   repository.Investor
-		repoUpload     repository.Upload
-		repoNotifier   repository.Notifier
-		pdfGenerator   file.PDFGenerator
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
 	}
 	type args struct {
 		ctx context.Context
@@ -66,6 +75,7 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 	defaultArgs := args{
 		ctx: context.Background(),
 		req: &entity.LoanProceed{
+			NextStatus: constant.StatusApproved,
 			ApprovalProof: entity.File{
 				File:    []byte{123},
 				FileExt: ".png",
@@ -77,6 +87,8 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 			},
 		},
 	}
+	defaultConfig := &config.Config{}
+	defaultConfig.Vendor.Funding.DeadlineDays = 30
 	tests := []struct {
 		name    string
 		fields  fields
@@ -86,6 +98,7 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
+				config: defaultConfig,
 				repoUpload: func() *repository.MockUpload {
 					mock := repository.NewMockUpload(ctrl)
 					mock.EXPECT().
@@ -107,6 +120,11 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 							Status:           constant.StatusApproved,
 							ApprovedBy:       2,
 							ApprovedAt:       time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+							FundingDeadline:  time.Date(2024, 9, 16, 13, 58, 0, 0, time.Local),
+						}, &entity.LoanStateHistory{
+							LoanID:   3,
+							ToStatus: constant.StatusApproved,
+							ActorID:  2,
 						}).
 						Return(nil)
 
@@ -115,13 +133,57 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 			},
 			args: defaultArgs,
 		},
+		{
+			name: "success without default funding deadline",
+			fields: fields{
+				config: &config.Config{},
+				repoUpload: func() *repository.MockUpload {
+					mock := repository.NewMockUpload(ctrl)
+					mock.EXPECT().
+						Upload(gomock.Any(), gomock.Any()).
+						Return("http://127.0.0.1:8080/approval_proof_3.png", nil)
+
+					return mock
+				}(),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Loan{
+							ID:               3,
+							ApprovalProofURL: "http://127.0.0.1:8080/approval_proof_3.png",
+							Status:           constant.StatusApproved,
+							ApprovedBy:       2,
+							ApprovedAt:       time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusApproved,
+					ApprovalProof: entity.File{
+						File:    []byte{123},
+						FileExt: ".png",
+					},
+					Data: &entity.Loan{
+						ID:         3,
+						ApprovedBy: 2,
+						ApprovedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+					},
+				},
+			},
+		},
 		{
 			name:   "invalid approved at",
 			fields: fields{},
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
-					Data: &entity.Loan{},
+					NextStatus: constant.StatusApproved,
+					Data:       &entity.Loan{},
 				},
 			},
 			wantErr: true,
@@ -132,6 +194,7 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
+					NextStatus: constant.StatusApproved,
 					Data: &entity.Loan{
 						ApprovedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
 					},
@@ -140,11 +203,28 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:   "invalid file content",
+			name:   "invalid funding deadline",
 			fields: fields{},
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
+					NextStatus: constant.StatusApproved,
+					Data: &entity.Loan{
+						ApprovedAt:      time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						ApprovedBy:      2,
+						FundingDeadline: time.Date(2024, 8, 16, 13, 58, 0, 0, time.Local),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid file content",
+			fields: fields{config: defaultConfig},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus:    constant.StatusApproved,
 					ApprovalProof: entity.File{},
 					Data: &entity.Loan{
 						ApprovedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
@@ -156,10 +236,11 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 		},
 		{
 			name:   "invalid file extension",
-			fields: fields{},
+			fields: fields{config: defaultConfig},
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
+					NextStatus: constant.StatusApproved,
 					ApprovalProof: entity.File{
 						File:    []byte{123},
 						FileExt: ".pdf",
@@ -175,6 +256,7 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 		{
 			name: "error on upload",
 			fields: fields{
+				config: defaultConfig,
 				repoUpload: func() *repository.MockUpload {
 					mock := repository.NewMockUpload(ctrl)
 					mock.EXPECT().
@@ -194,6 +276,7 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 		{
 			name: "error on update",
 			fields: fields{
+				config: defaultConfig,
 				repoUpload: func() *repository.MockUpload {
 					mock := repository.NewMockUpload(ctrl)
 					mock.EXPECT().
@@ -215,7 +298,8 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 							Status:           constant.StatusApproved,
 							ApprovedBy:       2,
 							ApprovedAt:       time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
-						}).
+							FundingDeadline:  time.Date(2024, 9, 16, 13, 58, 0, 0, time.Local),
+						}, gomock.Any()).
 						Return(assert.AnError)
 
 					return mock
@@ -228,13 +312,16 @@ func TestLoanActionImpl_Approve(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := &LoanActionImpl{
-				config:         tt.fields.config,
-				repoLoan:       tt.fields.repoLoan,
-				repoInvestment: tt.fields.repoInvestment,
-				repoInvestor:   tt.fields.repoInvestor,
-				repoUpload:     tt.fields.repoUpload,
-				repoNotifier:   tt.fields.repoNotifier,
-				pdfGenerator:   tt.fields.pdfGenerator,
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
 			}
 			if err := a.Approve(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
 				t.Errorf("LoanActionImpl.Approve() error = %v, wantErr %v", err, tt.wantErr)
@@ -248,13 +335,16 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		config         *config.Config
-		repoLoan       repository.Loan
-		repoInvestment repository.Investment
-		repoInvestor   repository.Investor
-		repoUpload     repository.Upload
-		repoNotifier   repository.Notifier
-		pdfGenerator   file.PDFGenerator
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
 	}
 	type args struct {
 		ctx context.Context
@@ -263,9 +353,13 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 	defaultArgs := args{
 		ctx: context.Background(),
 		req: &entity.LoanProceed{
+			NextStatus: constant.StatusInvested,
 			Data: &entity.Loan{
 				ID:         3,
-				Amount:     2000000,
+				Currency:   currency.IDR,
+				Amount:     currency.FromMajor(2000000),
+				Rate:       10,
+				Tenor:      12,
 				InvestedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
 			},
 		},
@@ -279,16 +373,25 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				config: &config.Config{},
+				config: &config.Config{
+					Vendor: config.Vendor{
+						DefaultAgreementLetter: config.DefaultFileConfig{
+							DestFileName: "agreement_letter_%s.pdf",
+						},
+					},
+				},
 				repoLoan: func() *repository.MockLoan {
 					mock := repository.NewMockLoan(ctrl)
 					mock.EXPECT().
 						Update(gomock.Any(), &entity.Loan{
 							ID:         3,
-							Amount:     2000000,
+							Currency:   currency.IDR,
+							Amount:     currency.FromMajor(2000000),
+							Rate:       10,
+							Tenor:      12,
 							Status:     constant.StatusInvested,
 							InvestedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
-						}).
+						}, gomock.Any()).
 						Return(nil)
 
 					return mock
@@ -300,14 +403,20 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 							LoanID: 3,
 							Status: constant.GeneralStatusActive,
 						}).
-						Return(float64(2000000), nil)
+						Return(currency.FromMajor(2000000), nil)
 					mock.EXPECT().
 						Get(gomock.Any(), gomock.Any()).
 						Return(entity.InvestmentResult{
 							List: []*entity.Investment{
 								{
-									ID:     1,
-									Amount: 2000000,
+									ID:             1,
+									InvestorID:     1,
+									Currency:       currency.IDR,
+									Amount:         currency.FromMajor(2000000),
+									ROI:            10,
+									ExpectedReturn: currency.Amount(10998131),
+									ServiceFee:     currency.Amount(1099813),
+									Tax:            currency.Amount(1649720),
 								},
 							},
 						}, nil)
@@ -326,11 +435,28 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 
 					return mock
 				}(),
-				repoNotifier: func() *repository.MockNotifier {
-					mock := repository.NewMockNotifier(ctrl)
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					// annuity return of 10% p.a. over 12 months less 10% service fee and 15% withholding tax
 					mock.EXPECT().
-						Notify(gomock.Any(), gomock.Any()).
-						Return(nil).AnyTimes()
+						Create(gomock.Any(), &entity.Outbox{
+							Recipients: []string{"test@gmail.com"},
+							Subject:    "Agreement Letter - Loan ID 3",
+							Body: fmt.Sprintf(agreementEmailFormat,
+								"ole",
+								3,
+								time.Time{}.Format(constant.DateBeautifyFormat),
+								currency.Format(currency.FromMajor(2000000), currency.IDR),
+								currency.Format(currency.Amount(1099813), currency.IDR),
+								currency.Format(currency.Amount(1649720), currency.IDR),
+								"4.12%",
+								currency.Format(currency.Amount(208248598), currency.IDR),
+							),
+							AttachmentName: "agreement_letter_ole.pdf",
+							Attachment:     []byte{123},
+							Status:         constant.OutboxStatusPending,
+						}).
+						Return(nil)
 
 					return mock
 				}(),
@@ -342,16 +468,166 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 
 					return mock
 				}(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Commit(gomock.Any(), &entity.Investment{
+							ID:             1,
+							InvestorID:     1,
+							Currency:       currency.IDR,
+							Amount:         currency.FromMajor(2000000),
+							ROI:            10,
+							ExpectedReturn: currency.Amount(10998131),
+							ServiceFee:     currency.Amount(1099813),
+							Tax:            currency.Amount(1649720),
+						}).
+						Return(nil)
+
+					return mock
+				}(),
 			},
 			args: defaultArgs,
 		},
+		{
+			name: "error on generating agreement letter",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(2000000), nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID:             1,
+									InvestorID:     1,
+									Currency:       currency.IDR,
+									Amount:         currency.FromMajor(2000000),
+									ROI:            10,
+									ExpectedReturn: currency.Amount(10998131),
+									ServiceFee:     currency.Amount(1099813),
+									Tax:            currency.Amount(1649720),
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(&entity.Investor{
+							ID:    1,
+							Name:  "ole",
+							Email: "test@gmail.com",
+						}, nil)
+
+					return mock
+				}(),
+				pdfGenerator: func() *file.MockPDFGenerator {
+					mock := file.NewMockPDFGenerator(ctrl)
+					mock.EXPECT().
+						Generate(gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Commit(gomock.Any(), &entity.Investment{
+							ID:             1,
+							InvestorID:     1,
+							Currency:       currency.IDR,
+							Amount:         currency.FromMajor(2000000),
+							ROI:            10,
+							ExpectedReturn: currency.Amount(10998131),
+							ServiceFee:     currency.Amount(1099813),
+							Tax:            currency.Amount(1649720),
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on committing wallet",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						GetAmountSum(gomock.Any(), gomock.Any()).
+						Return(currency.FromMajor(2000000), nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID:             1,
+									InvestorID:     1,
+									Currency:       currency.IDR,
+									Amount:         currency.FromMajor(2000000),
+									ROI:            10,
+									ExpectedReturn: currency.Amount(10998131),
+									ServiceFee:     currency.Amount(1099813),
+									Tax:            currency.Amount(1649720),
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Commit(gomock.Any(), &entity.Investment{
+							ID:             1,
+							InvestorID:     1,
+							Currency:       currency.IDR,
+							Amount:         currency.FromMajor(2000000),
+							ROI:            10,
+							ExpectedReturn: currency.Amount(10998131),
+							ServiceFee:     currency.Amount(1099813),
+							Tax:            currency.Amount(1649720),
+						}).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
 		{
 			name:   "invalid param",
 			fields: fields{},
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
-					Data: &entity.Loan{},
+					NextStatus: constant.StatusInvested,
+					Data:       &entity.Loan{},
 				},
 			},
 			wantErr: true,
@@ -366,7 +642,7 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 							LoanID: 3,
 							Status: constant.GeneralStatusActive,
 						}).
-						Return(float64(0), assert.AnError)
+						Return(currency.FromMajor(0), assert.AnError)
 
 					return mock
 				}(),
@@ -385,7 +661,7 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 							LoanID: 3,
 							Status: constant.GeneralStatusActive,
 						}).
-						Return(float64(20000000), nil)
+						Return(currency.FromMajor(20000000), nil)
 
 					return mock
 				}(),
@@ -402,10 +678,13 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 					mock.EXPECT().
 						Update(gomock.Any(), &entity.Loan{
 							ID:         3,
-							Amount:     2000000,
+							Currency:   currency.IDR,
+							Amount:     currency.FromMajor(2000000),
+							Rate:       10,
+							Tenor:      12,
 							Status:     constant.StatusInvested,
 							InvestedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
-						}).
+						}, gomock.Any()).
 						Return(assert.AnError)
 
 					return mock
@@ -417,7 +696,7 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 							LoanID: 3,
 							Status: constant.GeneralStatusActive,
 						}).
-						Return(float64(2000000), nil)
+						Return(currency.FromMajor(2000000), nil)
 
 					return mock
 				}(),
@@ -434,10 +713,13 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 					mock.EXPECT().
 						Update(gomock.Any(), &entity.Loan{
 							ID:         3,
-							Amount:     2000000,
+							Currency:   currency.IDR,
+							Amount:     currency.FromMajor(2000000),
+							Rate:       10,
+							Tenor:      12,
 							Status:     constant.StatusInvested,
 							InvestedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
-						}).
+						}, gomock.Any()).
 						Return(nil)
 
 					return mock
@@ -449,7 +731,7 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 							LoanID: 3,
 							Status: constant.GeneralStatusActive,
 						}).
-						Return(float64(2000000), nil)
+						Return(currency.FromMajor(2000000), nil)
 					mock.EXPECT().
 						Get(gomock.Any(), gomock.Any()).
 						Return(entity.InvestmentResult{}, assert.AnError)
@@ -464,13 +746,16 @@ func TestLoanActionImpl_Invest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := &LoanActionImpl{
-				config:         tt.fields.config,
-				repoLoan:       tt.fields.repoLoan,
-				repoInvestment: tt.fields.repoInvestment,
-				repoInvestor:   tt.fields.repoInvestor,
-				repoUpload:     tt.fields.repoUpload,
-				repoNotifier:   tt.fields.repoNotifier,
-				pdfGenerator:   tt.fields.pdfGenerator,
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
 			}
 			if err := a.Invest(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
 				t.Errorf("LoanActionImpl.Invest() error = %v, wantErr %v", err, tt.wantErr)
@@ -484,13 +769,17 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		config         *config.Config
-		repoLoan       repository.Loan
-		repoInvestment repository.Investment
-		repoInvestor   repository.Investor
-		repoUpload     repository.Upload
-		repoNotifier   repository.Notifier
-		pdfGenerator   file.PDFGenerator
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoProduct        repository.LoanProduct
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
 	}
 	type args struct {
 		ctx context.Context
@@ -499,17 +788,54 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 	defaultArgs := args{
 		ctx: context.Background(),
 		req: &entity.LoanProceed{
+			NextStatus: constant.StatusDisbursed,
 			AgreementLetter: entity.File{
 				File:    []byte{123},
 				FileExt: ".pdf",
 			},
 			Data: &entity.Loan{
 				ID:          3,
+				Amount:      currency.FromMajor(2000000),
 				DisbursedBy: 2,
 				DisbursedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
 			},
 		},
 	}
+	productArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.LoanProceed{
+				NextStatus: constant.StatusDisbursed,
+				AgreementLetter: entity.File{
+					File:    []byte{123},
+					FileExt: ".pdf",
+				},
+				Data: &entity.Loan{
+					ID:          3,
+					ProductID:   7,
+					Currency:    currency.IDR,
+					Amount:      currency.FromMajor(2000000),
+					DisbursedBy: 2,
+					DisbursedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+				},
+			},
+		}
+	}
+	postedLedger := func() *service.MockLedger {
+		mock := service.NewMockLedger(ctrl)
+		mock.EXPECT().
+			Post(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		return mock
+	}
+	defaultConfig := &config.Config{
+		Vendor: config.Vendor{
+			Fee: config.FeeConfig{
+				OriginationFee: 1,
+			},
+		},
+	}
 	tests := []struct {
 		name    string
 		fields  fields
@@ -519,6 +845,7 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
+				config: defaultConfig,
 				repoUpload: func() *repository.MockUpload {
 					mock := repository.NewMockUpload(ctrl)
 					mock.EXPECT().
@@ -536,10 +863,38 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 					mock.EXPECT().
 						Update(gomock.Any(), &entity.Loan{
 							ID:                 3,
+							Amount:             currency.FromMajor(2000000),
 							AgreementLetterURL: "http://127.0.0.1:8080/agreement_letter_3.pdf",
 							Status:             constant.StatusDisbursed,
 							DisbursedBy:        2,
+							OriginationFee:     currency.FromMajor(20000),
+							DisbursedAmount:    currency.FromMajor(1980000),
 							DisbursedAt:        time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Generate(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), &entity.LedgerJournal{
+							Reference:   "disbursement:3",
+							LoanID:      3,
+							Description: "loan disbursement",
+							Entries: []*entity.LedgerEntry{
+								{AccountCode: constant.LedgerAccountLoanEscrow, Debit: currency.FromMajor(2000000)},
+								{AccountCode: constant.LedgerAccountBank, Credit: currency.FromMajor(1980000)},
+								{AccountCode: constant.LedgerAccountOriginationFeeRevenue, Credit: currency.FromMajor(20000)},
+							},
 						}).
 						Return(nil)
 
@@ -548,13 +903,114 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 			},
 			args: defaultArgs,
 		},
+		{
+			name: "success with product fees",
+			fields: fields{
+				config: defaultConfig,
+				repoUpload: func() *repository.MockUpload {
+					mock := repository.NewMockUpload(ctrl)
+					mock.EXPECT().
+						Upload(gomock.Any(), gomock.Any()).
+						Return("http://127.0.0.1:8080/agreement_letter_3.pdf", nil)
+
+					return mock
+				}(),
+				repoProduct: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(7)).
+						Return(&entity.LoanProduct{
+							ID:           7,
+							AdminFee:     currency.FromMajor(25000),
+							ProvisionFee: 1,
+						}, nil)
+
+					return mock
+				}(),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Loan{
+							ID:                 3,
+							ProductID:          7,
+							Currency:           currency.IDR,
+							Amount:             currency.FromMajor(2000000),
+							AgreementLetterURL: "http://127.0.0.1:8080/agreement_letter_3.pdf",
+							Status:             constant.StatusDisbursed,
+							DisbursedBy:        2,
+							AdminFee:           currency.FromMajor(25000),
+							ProvisionFee:       currency.FromMajor(20000),
+							OriginationFee:     currency.FromMajor(20000),
+							DisbursedAmount:    currency.FromMajor(1935000),
+							DisbursedAt:        time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Generate(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), &entity.LedgerJournal{
+							Reference:   "disbursement:3",
+							Currency:    currency.IDR,
+							LoanID:      3,
+							Description: "loan disbursement",
+							Entries: []*entity.LedgerEntry{
+								{AccountCode: constant.LedgerAccountLoanEscrow, Debit: currency.FromMajor(2000000)},
+								{AccountCode: constant.LedgerAccountBank, Credit: currency.FromMajor(1935000)},
+								{AccountCode: constant.LedgerAccountAdminFeeRevenue, Credit: currency.FromMajor(25000)},
+								{AccountCode: constant.LedgerAccountProvisionFeeRevenue, Credit: currency.FromMajor(20000)},
+								{AccountCode: constant.LedgerAccountOriginationFeeRevenue, Credit: currency.FromMajor(20000)},
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: productArgs(),
+		},
+		{
+			name: "error get product",
+			fields: fields{
+				config: defaultConfig,
+				repoUpload: func() *repository.MockUpload {
+					mock := repository.NewMockUpload(ctrl)
+					mock.EXPECT().
+						Upload(gomock.Any(), gomock.Any()).
+						Return("http://127.0.0.1:8080/agreement_letter_3.pdf", nil)
+
+					return mock
+				}(),
+				repoProduct: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(7)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    productArgs(),
+			wantErr: true,
+		},
 		{
 			name:   "invalid disbursed at",
 			fields: fields{},
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
-					Data: &entity.Loan{},
+					NextStatus: constant.StatusDisbursed,
+					Data:       &entity.Loan{},
 				},
 			},
 			wantErr: true,
@@ -565,6 +1021,7 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
+					NextStatus: constant.StatusDisbursed,
 					Data: &entity.Loan{
 						DisbursedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
 					},
@@ -578,6 +1035,7 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
+					NextStatus:      constant.StatusDisbursed,
 					AgreementLetter: entity.File{},
 					Data: &entity.Loan{
 						DisbursedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
@@ -593,6 +1051,7 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &entity.LoanProceed{
+					NextStatus: constant.StatusDisbursed,
 					AgreementLetter: entity.File{
 						File:    []byte{123},
 						FileExt: ".png",
@@ -624,9 +1083,32 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 			args:    defaultArgs,
 			wantErr: true,
 		},
+		{
+			name: "fees exceed loan amount",
+			fields: fields{
+				config: &config.Config{
+					Vendor: config.Vendor{
+						Fee: config.FeeConfig{
+							OriginationFee: 100,
+						},
+					},
+				},
+				repoUpload: func() *repository.MockUpload {
+					mock := repository.NewMockUpload(ctrl)
+					mock.EXPECT().
+						Upload(gomock.Any(), gomock.Any()).
+						Return("http://127.0.0.1:8080/agreement_letter_3.pdf", nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
 		{
 			name: "error on update",
 			fields: fields{
+				config: defaultConfig,
 				repoUpload: func() *repository.MockUpload {
 					mock := repository.NewMockUpload(ctrl)
 					mock.EXPECT().
@@ -644,11 +1126,14 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 					mock.EXPECT().
 						Update(gomock.Any(), &entity.Loan{
 							ID:                 3,
+							Amount:             currency.FromMajor(2000000),
 							AgreementLetterURL: "http://127.0.0.1:8080/agreement_letter_3.pdf",
 							Status:             constant.StatusDisbursed,
 							DisbursedBy:        2,
+							OriginationFee:     currency.FromMajor(20000),
+							DisbursedAmount:    currency.FromMajor(1980000),
 							DisbursedAt:        time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
-						}).
+						}, gomock.Any()).
 						Return(assert.AnError)
 
 					return mock
@@ -657,20 +1142,1469 @@ func TestLoanActionImpl_Disburse(t *testing.T) {
 			args:    defaultArgs,
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			a := &LoanActionImpl{
-				config:         tt.fields.config,
-				repoLoan:       tt.fields.repoLoan,
-				repoInvestment: tt.fields.repoInvestment,
-				repoInvestor:   tt.fields.repoInvestor,
-				repoUpload:     tt.fields.repoUpload,
-				repoNotifier:   tt.fields.repoNotifier,
-				pdfGenerator:   tt.fields.pdfGenerator,
-			}
-			if err := a.Disburse(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
-				t.Errorf("LoanActionImpl.Disburse() error = %v, wantErr %v", err, tt.wantErr)
+		{
+			name: "error on generating schedule",
+			fields: fields{
+				config: defaultConfig,
+				repoUpload: func() *repository.MockUpload {
+					mock := repository.NewMockUpload(ctrl)
+					mock.EXPECT().
+						Upload(gomock.Any(), gomock.Any()).
+						Return("http://127.0.0.1:8080/agreement_letter_3.pdf", nil)
+
+					return mock
+				}(),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Generate(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				serviceLedger: postedLedger(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on posting ledger",
+			fields: fields{
+				config: defaultConfig,
+				repoUpload: func() *repository.MockUpload {
+					mock := repository.NewMockUpload(ctrl)
+					mock.EXPECT().
+						Upload(gomock.Any(), gomock.Any()).
+						Return("http://127.0.0.1:8080/agreement_letter_3.pdf", nil)
+
+					return mock
+				}(),
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &LoanActionImpl{
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoProduct:        tt.fields.repoProduct,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
+			}
+			if err := a.Disburse(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("LoanActionImpl.Disburse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanActionImpl_Reject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.LoanProceed
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		req: &entity.LoanProceed{
+			NextStatus: constant.StatusRejected,
+			Data: &entity.Loan{
+				ID:         3,
+				RejectedBy: 2,
+				RejectedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+				Reason:     "incomplete documents",
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Loan{
+							ID:         3,
+							Status:     constant.StatusRejected,
+							RejectedBy: 2,
+							RejectedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+							Reason:     "incomplete documents",
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name:   "invalid rejected at",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusRejected,
+					Data:       &entity.Loan{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid rejected by",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusRejected,
+					Data: &entity.Loan{
+						RejectedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid reason",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusRejected,
+					Data: &entity.Loan{
+						RejectedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						RejectedBy: 2,
+						Reason:     " ",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid next status",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{
+						ID:         3,
+						RejectedBy: 2,
+						RejectedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						Reason:     "incomplete documents",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusRejected,
+					Data: &entity.Loan{
+						ID:         3,
+						RejectedBy: 2,
+						RejectedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						Reason:     "incomplete documents",
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &LoanActionImpl{
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
+			}
+			if err := a.Reject(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("LoanActionImpl.Reject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanActionImpl_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.LoanProceed
+	}
+	newArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.LoanProceed{
+				NextStatus: constant.StatusCancelled,
+				Data: &entity.Loan{
+					ID:          4,
+					CancelledBy: 2,
+					CancelledAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+					Reason:      "borrower withdrew",
+				},
+			},
+		}
+	}
+	activeInvestmentFilter := &entity.InvestmentFilter{
+		LoanID: 4,
+		Status: constant.GeneralStatusActive,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Loan{
+							ID:          4,
+							Status:      constant.StatusCancelled,
+							CancelledBy: 2,
+							CancelledAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+							Reason:      "borrower withdrew",
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID:         1,
+									InvestorID: 2,
+									Amount:     currency.FromMajor(15000000),
+								},
+								{
+									ID:         2,
+									InvestorID: 1,
+									Amount:     currency.FromMajor(5000000),
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(&entity.Investor{
+							ID:    1,
+							Name:  "ole",
+							Email: "test@gmail.com",
+						}, nil).AnyTimes()
+
+					return mock
+				}(),
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil).AnyTimes()
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					// wallets are released in the order of investor ID
+					mock := service.NewMockWallet(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().
+							Release(gomock.Any(), &entity.Investment{
+								ID:         2,
+								InvestorID: 1,
+								Amount:     currency.FromMajor(5000000),
+							}).
+							Return(nil),
+						mock.EXPECT().
+							Release(gomock.Any(), &entity.Investment{
+								ID:         1,
+								InvestorID: 2,
+								Amount:     currency.FromMajor(15000000),
+							}).
+							Return(nil),
+					)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(2)
+
+					return mock
+				}(),
+			},
+			args: newArgs(),
+		},
+		{
+			name: "success without investment",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, nil)
+
+					return mock
+				}(),
+			},
+			args: newArgs(),
+		},
+		{
+			name:   "invalid cancelled at",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusCancelled,
+					Data:       &entity.Loan{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid cancelled by",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusCancelled,
+					Data: &entity.Loan{
+						CancelledAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid reason",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusCancelled,
+					Data: &entity.Loan{
+						CancelledAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						CancelledBy: 2,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update loan",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on getting investment",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on updating investment status",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID: 1,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on releasing wallet",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID: 1,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Release(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on posting ledger",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID: 1,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Release(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &LoanActionImpl{
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
+			}
+			if err := a.Cancel(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("LoanActionImpl.Cancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanActionImpl_Repay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.LoanProceed
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.LoanProceed{
+				NextStatus: constant.StatusRepaid,
+				Data: &entity.Loan{
+					ID:       4,
+					RepaidAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Loan{
+							ID:       4,
+							Status:   constant.StatusRepaid,
+							RepaidAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						GetSchedule(gomock.Any(), int64(4)).
+						Return([]*entity.Installment{
+							{
+								Sequence: 1,
+								Status:   constant.InstallmentStatusPaid,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs(),
+		},
+		{
+			name:   "invalid repaid at",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusRepaid,
+					Data:       &entity.Loan{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get schedule",
+			fields: fields{
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						GetSchedule(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "empty schedule",
+			fields: fields{
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						GetSchedule(gomock.Any(), gomock.Any()).
+						Return([]*entity.Installment{}, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "outstanding balance left",
+			fields: fields{
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						GetSchedule(gomock.Any(), gomock.Any()).
+						Return([]*entity.Installment{
+							{
+								Sequence: 1,
+								Status:   constant.InstallmentStatusPaid,
+							},
+							{
+								Sequence: 2,
+								Status:   constant.InstallmentStatusUnpaid,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						GetSchedule(gomock.Any(), gomock.Any()).
+						Return([]*entity.Installment{
+							{
+								Sequence: 1,
+								Status:   constant.InstallmentStatusPaid,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &LoanActionImpl{
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
+			}
+			if err := a.Repay(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("LoanActionImpl.Repay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanActionImpl_Default(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.LoanProceed
+	}
+	newArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.LoanProceed{
+				NextStatus: constant.StatusDefaulted,
+				Data: &entity.Loan{
+					ID:          4,
+					Currency:    currency.IDR,
+					DaysPastDue: 91,
+					DefaultedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Loan{
+							ID:          4,
+							Currency:    currency.IDR,
+							Status:      constant.StatusDefaulted,
+							DaysPastDue: 91,
+							DefaultedAt: time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local),
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID:         1,
+									InvestorID: 1,
+									Amount:     currency.FromMajor(15000000),
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(&entity.Investor{
+							ID:    1,
+							Name:  "ole",
+							Email: "test@gmail.com",
+						}, nil).AnyTimes()
+
+					return mock
+				}(),
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), &entity.Outbox{
+							Recipients: []string{"test@gmail.com"},
+							Subject:    "Loan Default - Loan ID 4",
+							Body: fmt.Sprintf(defaultEmailFormat,
+								"ole",
+								4,
+								91,
+								time.Time{}.Format(constant.DateBeautifyFormat),
+								currency.Format(currency.FromMajor(15000000), currency.IDR),
+							),
+							Status: constant.OutboxStatusPending,
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: newArgs(),
+		},
+		{
+			name:   "invalid defaulted at",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusDefaulted,
+					Data:       &entity.Loan{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update loan",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on getting investment",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on getting investor",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID:         1,
+									InvestorID: 1,
+									Amount:     currency.FromMajor(15000000),
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on writing outbox",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID:         1,
+									InvestorID: 1,
+									Amount:     currency.FromMajor(15000000),
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.Investor{
+							ID:    1,
+							Name:  "ole",
+							Email: "test@gmail.com",
+						}, nil)
+
+					return mock
+				}(),
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &LoanActionImpl{
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
+			}
+			if err := a.Default(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("LoanActionImpl.Default() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanActionImpl_Expire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config             *config.Config
+		repoLoan           repository.Loan
+		repoInvestment     repository.Investment
+		repoInvestor       repository.Investor
+		repoUpload         repository.Upload
+		repoOutbox         repository.Outbox
+		pdfGenerator       file.PDFGenerator
+		serviceInstallment service.Installment
+		serviceWallet      service.Wallet
+		serviceLedger      service.Ledger
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.LoanProceed
+	}
+	newArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.LoanProceed{
+				NextStatus: constant.StatusExpired,
+				Data: &entity.Loan{
+					ID:              4,
+					FundingDeadline: time.Date(2024, 9, 16, 13, 58, 0, 0, time.Local),
+					ExpiredAt:       time.Date(2024, 9, 17, 10, 0, 0, 0, time.Local),
+				},
+			},
+		}
+	}
+	activeInvestmentFilter := &entity.InvestmentFilter{
+		LoanID: 4,
+		Status: constant.GeneralStatusActive,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Loan{
+							ID:              4,
+							Status:          constant.StatusExpired,
+							FundingDeadline: time.Date(2024, 9, 16, 13, 58, 0, 0, time.Local),
+							ExpiredAt:       time.Date(2024, 9, 17, 10, 0, 0, 0, time.Local),
+						}, gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID:         1,
+									InvestorID: 2,
+									Amount:     currency.FromMajor(15000000),
+								},
+								{
+									ID:         2,
+									InvestorID: 1,
+									Amount:     currency.FromMajor(5000000),
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(&entity.Investor{
+							ID:    1,
+							Name:  "ole",
+							Email: "test@gmail.com",
+						}, nil).AnyTimes()
+
+					return mock
+				}(),
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil).AnyTimes()
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					// wallets are released in the order of investor ID
+					mock := service.NewMockWallet(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().
+							Release(gomock.Any(), &entity.Investment{
+								ID:         2,
+								InvestorID: 1,
+								Amount:     currency.FromMajor(5000000),
+							}).
+							Return(nil),
+						mock.EXPECT().
+							Release(gomock.Any(), &entity.Investment{
+								ID:         1,
+								InvestorID: 2,
+								Amount:     currency.FromMajor(15000000),
+							}).
+							Return(nil),
+					)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(2)
+
+					return mock
+				}(),
+			},
+			args: newArgs(),
+		},
+		{
+			name: "success without investment",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, nil)
+
+					return mock
+				}(),
+			},
+			args: newArgs(),
+		},
+		{
+			name:   "invalid expired at",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusExpired,
+					Data:       &entity.Loan{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "funding deadline has not passed",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusExpired,
+					Data: &entity.Loan{
+						FundingDeadline: time.Date(2024, 9, 16, 13, 58, 0, 0, time.Local),
+						ExpiredAt:       time.Date(2024, 9, 15, 10, 0, 0, 0, time.Local),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "without funding deadline",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					NextStatus: constant.StatusExpired,
+					Data: &entity.Loan{
+						ExpiredAt: time.Date(2024, 9, 17, 10, 0, 0, 0, time.Local),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update loan",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on getting investment",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on updating investment status",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID: 1,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on releasing wallet",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID: 1,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Release(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on posting ledger",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{
+							List: []*entity.Investment{
+								{
+									ID: 1,
+								},
+							},
+						}, nil)
+					mock.EXPECT().
+						UpdateStatus(gomock.Any(), activeInvestmentFilter, constant.GeneralStatusInactive).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Release(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    newArgs(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &LoanActionImpl{
+				config:             tt.fields.config,
+				repoLoan:           tt.fields.repoLoan,
+				repoInvestment:     tt.fields.repoInvestment,
+				repoInvestor:       tt.fields.repoInvestor,
+				repoUpload:         tt.fields.repoUpload,
+				repoOutbox:         tt.fields.repoOutbox,
+				pdfGenerator:       tt.fields.pdfGenerator,
+				serviceInstallment: tt.fields.serviceInstallment,
+				serviceWallet:      tt.fields.serviceWallet,
+				serviceLedger:      tt.fields.serviceLedger,
+			}
+			if err := a.Expire(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("LoanActionImpl.Expire() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -1,7 +1,7 @@
 package action
 
 const (
-	agreementLetterFormat = `Date: %s|Subject: Agreement Letter for Loan Investment|To:|%s||Dear %s,|We are pleased to confirm your investment in the loan offered by Company A to Loan ID %d. Below are the details of the investment:||- Loan ID: %d|- Principal Amount: %s|- Initial Invested Amount: %s|- Interest Rate: %s|- Return on Investment (ROI): %s|- Final Invested Amount: %s|- Investment Date: %s||Enclosed with this letter, please find the signed agreement letter. Kindly review the attached document and retain it for your records.|Should you have any questions or require further information, please do not hesitate to contact us.||Thank you for your trust and investment in Company A.||Best regards,|Admin|Company A`
+	agreementLetterFormat = `Date: %s|Subject: Agreement Letter for Loan Investment|To:|%s||Dear %s,|We are pleased to confirm your investment in the loan offered by Company A to Loan ID %d. Below are the details of the investment:||- Loan ID: %d|- Principal Amount: %s|- Initial Invested Amount: %s|- Interest Rate: %s|- Tenor: %d months|- Gross Return: %s|- Platform Service Fee: %s|- Withholding Tax: %s|- Net Return on Investment (ROI): %s|- Final Invested Amount: %s|- Investment Date: %s||Enclosed with this letter, please find the signed agreement letter. Kindly review the attached document and retain it for your records.|Should you have any questions or require further information, please do not hesitate to contact us.||Thank you for your trust and investment in Company A.||Best regards,|Admin|Company A`
 	agreementEmailFormat  = `Dear %s,
 
 Attached is the agreement letter for your recent investment in Loan %d. Please review and keep this document for your records.
@@ -9,8 +9,41 @@ Attached is the agreement letter for your recent investment in Loan %d. Please r
 Below is the detail of your investment:
 - Investment Date: %s
 - Initial Investment Amount: %s
-- ROI: %s
+- Platform Service Fee: %s
+- Withholding Tax: %s
+- Net ROI: %s
 - Final Investment Amount: %s
 
+Thank you for your trust in us.`
+	cancellationEmailFormat = `Dear %s,
+
+We regret to inform you that Loan %d has been cancelled and will not proceed to funding.
+
+Reason: %s
+
+Below is the detail of your investment that has been released:
+- Investment Date: %s
+- Investment Amount: %s
+
+Thank you for your trust in us.`
+	defaultEmailFormat = `Dear %s,
+
+We regret to inform you that Loan %d has been declared as defaulted after being overdue for %d days.
+
+Below is the detail of your investment:
+- Investment Date: %s
+- Investment Amount: %s
+
+We will keep pursuing the collection of the outstanding balance and distribute any recovered repayment accordingly.
+
+Thank you for your trust in us.`
+	expiryEmailFormat = `Dear %s,
+
+We regret to inform you that Loan %d has expired after not being fully funded by its funding deadline on %s.
+
+Below is the detail of your investment that has been released:
+- Investment Date: %s
+- Investment Amount: %s
+
 Thank you for your trust in us.`
 )
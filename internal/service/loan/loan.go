@@ -2,27 +2,46 @@ package loan
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
 	"github.com/ecintiawan/loan-service/internal/service"
-	"github.com/ecintiawan/loan-service/internal/service/loan/state"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
 	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 )
 
 type LoanImpl struct {
-	repo   repository.Loan
-	action service.LoanAction
+	config             *config.Config
+	repo               repository.Loan
+	repoProduct        repository.LoanProduct
+	repoBorrower       repository.Borrower
+	machine            service.LoanStateMachine
+	serviceInstallment service.Installment
+	db                 database.DB
 }
 
 func NewLoanImpl(
+	config *config.Config,
 	repo repository.Loan,
-	action service.LoanAction,
+	repoProduct repository.LoanProduct,
+	repoBorrower repository.Borrower,
+	machine service.LoanStateMachine,
+	serviceInstallment service.Installment,
+	db database.DB,
 ) service.Loan {
 	return &LoanImpl{
-		repo:   repo,
-		action: action,
+		config:             config,
+		repo:               repo,
+		repoProduct:        repoProduct,
+		repoBorrower:       repoBorrower,
+		machine:            machine,
+		serviceInstallment: serviceInstallment,
+		db:                 db,
 	}
 }
 
@@ -33,7 +52,19 @@ func (l *LoanImpl) Get(
 ) (entity.LoanResult, error) {
 	filter.Validate()
 
-	return l.repo.Get(ctx, filter)
+	result, err := l.repo.Get(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+
+	for _, loan := range result.List {
+		loan.AllowedActions, err = l.machine.AllowedActions(ctx, loan.Status)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
 }
 
 // Create will insert initial loan data
@@ -44,12 +75,74 @@ func (l *LoanImpl) Create(
 	if !model.IsValid() {
 		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
 	}
+
+	err := l.validateBorrower(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	_, err = l.validateProduct(ctx, model)
+	if err != nil {
+		return err
+	}
 	model.Status = constant.StatusProposed
 
 	return l.repo.Create(ctx, model)
 }
 
+// Simulate will calculate the quote of a hypothetical loan and investment without storing anything
+// the loan is validated against its product and calculated the same way a created and disbursed loan would be,
+// the investment is assumed to earn the loan rate, and both are charged the configured platform fees and tax
+func (l *LoanImpl) Simulate(
+	ctx context.Context,
+	req *entity.LoanSimulation,
+) (*entity.LoanQuote, error) {
+	if !req.IsValid() {
+		return nil, errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+
+	now := time.Now()
+	loan := &entity.Loan{
+		ProductID:   req.ProductID,
+		Currency:    req.Currency,
+		Amount:      req.Amount,
+		Rate:        req.Rate,
+		Tenor:       req.Tenor,
+		DisbursedAt: now,
+	}
+	product, err := l.validateProduct(ctx, loan)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := l.serviceInstallment.Calculate(ctx, loan)
+	if err != nil {
+		return nil, err
+	}
+	loan.ChargeFees(product, l.config.Vendor.Fee.OriginationFee)
+	if loan.DisbursedAmount <= 0 {
+		return nil, errorwrapper.E("fees exceed the loan amount", errorwrapper.CodeInvalid)
+	}
+	quote := entity.NewLoanQuote(loan, schedule)
+
+	if req.InvestmentAmount > 0 {
+		quote.Investment, err = l.serviceInstallment.CalculateReturn(ctx, &entity.Investment{
+			Currency:  loan.Currency,
+			Amount:    req.InvestmentAmount,
+			ROI:       loan.Rate,
+			CreatedAt: now,
+		}, loan)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return quote, nil
+}
+
 // Proceed is an action to go through all loan states for certain loan data
+// the whole transition is done within a single transaction, so the loan and its related rows
+// are either all updated or none of them are
 func (l *LoanImpl) Proceed(
 	ctx context.Context,
 	req *entity.LoanProceed,
@@ -58,15 +151,33 @@ func (l *LoanImpl) Proceed(
 		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
 	}
 
-	existing, err := l.repo.GetDetail(ctx, req.Data.ID)
+	return l.db.WithTx(ctx, func(ctx context.Context) error {
+		return l.proceed(ctx, req)
+	})
+}
+
+// proceed runs the transition of the loan, it must be called within a transaction
+func (l *LoanImpl) proceed(
+	ctx context.Context,
+	req *entity.LoanProceed,
+) error {
+	// the loan row stays locked until the transaction ends, so concurrent transitions of the same loan
+	// are applied one after another on its latest status
+	existing, err := l.repo.GetDetailForUpdate(ctx, req.Data.ID)
 	if err != nil {
 		return err
 	}
 	req.Data.Status = existing.Status
+	req.Data.ProductID = existing.ProductID
+	req.Data.Currency = existing.Currency
 	req.Data.Amount = existing.Amount
 	req.Data.Rate = existing.Rate
+	req.Data.Tenor = existing.Tenor
+	if req.Data.FundingDeadline.IsZero() {
+		req.Data.FundingDeadline = existing.FundingDeadline
+	}
 
-	state, err := state.DetermineState(ctx, existing.Status, l.action)
+	state, err := l.machine.DetermineState(ctx, existing.Status)
 	if err != nil {
 		return err
 	}
@@ -78,7 +189,132 @@ func (l *LoanImpl) Proceed(
 		return state.Invest(ctx, req)
 	case constant.ActionDisburse:
 		return state.Disburse(ctx, req)
+	case constant.ActionReject:
+		return state.Reject(ctx, req)
+	case constant.ActionCancel:
+		return state.Cancel(ctx, req)
+	case constant.ActionRepay:
+		return state.Repay(ctx, req)
+	case constant.ActionDefault:
+		return state.Default(ctx, req)
+	case constant.ActionExpire:
+		return state.Expire(ctx, req)
 	}
 
 	return errorwrapper.E("invalid action", errorwrapper.CodeInvalid)
 }
+
+// GetHistory will return status transition history of a loan
+func (l *LoanImpl) GetHistory(
+	ctx context.Context,
+	id int64,
+) ([]*entity.LoanStateHistory, error) {
+	if id <= 0 {
+		return nil, errorwrapper.E("invalid loan ID", errorwrapper.CodeInvalid)
+	}
+
+	return l.repo.GetHistory(ctx, id)
+}
+
+// GetTransitions will return all allowed status transitions of loan
+func (l *LoanImpl) GetTransitions(
+	ctx context.Context,
+) entity.LoanTransitionGraph {
+	return l.machine.Transitions()
+}
+
+// GetAllowedActions will return all actions which can be proceeded on a loan
+func (l *LoanImpl) GetAllowedActions(
+	ctx context.Context,
+	id int64,
+) ([]*entity.LoanAllowedAction, error) {
+	if id <= 0 {
+		return nil, errorwrapper.E("invalid loan ID", errorwrapper.CodeInvalid)
+	}
+
+	loan, err := l.repo.GetDetail(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.machine.AllowedActions(ctx, loan.Status)
+}
+
+// validateBorrower makes sure the loan is submitted by a registered and active borrower
+func (l *LoanImpl) validateBorrower(
+	ctx context.Context,
+	model *entity.Loan,
+) error {
+	borrower, err := l.repoBorrower.GetDetail(ctx, model.BorrowerID)
+	if err != nil {
+		errx, ok := err.(*errorwrapper.Error)
+		if ok && errx.Code == errorwrapper.CodeNotFound {
+			return errorwrapper.E("borrower_id refers to a non-existent borrower", errorwrapper.CodeInvalid)
+		}
+		return err
+	}
+	if borrower.Status != constant.GeneralStatusActive {
+		return errorwrapper.E("borrower_id refers to an inactive borrower", errorwrapper.CodeInvalid)
+	}
+
+	return nil
+}
+
+// validateProduct checks the loan against the limits of its chosen loan product
+// and returns the product when the loan complies with it
+func (l *LoanImpl) validateProduct(
+	ctx context.Context,
+	model *entity.Loan,
+) (*entity.LoanProduct, error) {
+	if model.ProductID <= 0 {
+		return nil, errorwrapper.E("product_id is required", errorwrapper.CodeInvalid)
+	}
+
+	product, err := l.repoProduct.GetDetail(ctx, model.ProductID)
+	if err != nil {
+		errx, ok := err.(*errorwrapper.Error)
+		if ok && errx.Code == errorwrapper.CodeNotFound {
+			return nil, errorwrapper.E("product_id refers to a non-existent loan product", errorwrapper.CodeInvalid)
+		}
+		return nil, err
+	}
+	if product.Status != constant.GeneralStatusActive {
+		return nil, errorwrapper.E("product_id refers to an inactive loan product", errorwrapper.CodeInvalid)
+	}
+
+	// loan is denominated in its product's currency unless stated otherwise
+	if model.Currency == "" {
+		model.Currency = product.Currency
+	}
+	if model.Currency != product.Currency {
+		return nil, errorwrapper.E(fmt.Sprintf(
+			"currency must be %s",
+			product.Currency,
+		), errorwrapper.CodeInvalid)
+	}
+
+	if model.Amount < product.MinAmount || model.Amount > product.MaxAmount {
+		return nil, errorwrapper.E(fmt.Sprintf(
+			"amount must be between %s and %s",
+			currency.Format(product.MinAmount, product.Currency),
+			currency.Format(product.MaxAmount, product.Currency),
+		), errorwrapper.CodeInvalid)
+	}
+
+	if model.Rate < product.MinRate || model.Rate > product.MaxRate {
+		return nil, errorwrapper.E(fmt.Sprintf(
+			"rate must be between %.2f%% and %.2f%%",
+			product.MinRate,
+			product.MaxRate,
+		), errorwrapper.CodeInvalid)
+	}
+
+	if !product.HasTenor(model.Tenor) {
+		return nil, errorwrapper.E(fmt.Sprintf(
+			"tenor must be one of %v",
+			product.TenorOptions,
+		), errorwrapper.CodeInvalid)
+	}
+
+	return product, nil
+}
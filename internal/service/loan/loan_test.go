@@ -9,14 +9,23 @@ import (
 	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/repository"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewLoanImpl(t *testing.T) {
 	type args struct {
-		repo   repository.Loan
-		action service.LoanAction
+		config             *config.Config
+		repo               repository.Loan
+		repoProduct        repository.LoanProduct
+		repoBorrower       repository.Borrower
+		machine            service.LoanStateMachine
+		serviceInstallment service.Installment
+		db                 database.DB
 	}
 	tests := []struct {
 		name string
@@ -31,7 +40,7 @@ func TestNewLoanImpl(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewLoanImpl(tt.args.repo, tt.args.action); !reflect.DeepEqual(got, tt.want) {
+			if got := NewLoanImpl(tt.args.config, tt.args.repo, tt.args.repoProduct, tt.args.repoBorrower, tt.args.machine, tt.args.serviceInstallment, tt.args.db); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewLoanImpl() = %v, want %v", got, tt.want)
 			}
 		})
@@ -43,8 +52,10 @@ func TestLoanImpl_Get(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		repo   repository.Loan
-		action service.LoanAction
+		repo        repository.Loan
+		repoProduct repository.LoanProduct
+		machine     service.LoanStateMachine
+		db          database.DB
 	}
 	type args struct {
 		ctx    context.Context
@@ -74,7 +85,8 @@ func TestLoanImpl_Get(t *testing.T) {
 							List: []*entity.Loan{
 								{
 									ID:     1,
-									Amount: 1000000,
+									Amount: currency.FromMajor(1000000),
+									Status: constant.StatusProposed,
 								},
 							},
 							Pagination: entity.Pagination{
@@ -84,13 +96,33 @@ func TestLoanImpl_Get(t *testing.T) {
 
 					return mock
 				}(),
+				machine: func() *service.MockLoanStateMachine {
+					mock := service.NewMockLoanStateMachine(ctrl)
+					mock.EXPECT().
+						AllowedActions(gomock.Any(), constant.StatusProposed).
+						Return([]*entity.LoanAllowedAction{
+							{
+								Action: constant.ActionApprove,
+								Name:   "approve",
+							},
+						}, nil)
+
+					return mock
+				}(),
 			},
 			args: defaultArgs,
 			want: entity.LoanResult{
 				List: []*entity.Loan{
 					{
 						ID:     1,
-						Amount: 1000000,
+						Amount: currency.FromMajor(1000000),
+						Status: constant.StatusProposed,
+						AllowedActions: []*entity.LoanAllowedAction{
+							{
+								Action: constant.ActionApprove,
+								Name:   "approve",
+							},
+						},
 					},
 				},
 				Pagination: entity.Pagination{
@@ -122,12 +154,50 @@ func TestLoanImpl_Get(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error on allowed actions",
+			fields: fields{
+				repo: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.LoanResult{
+							List: []*entity.Loan{
+								{
+									ID: 1,
+								},
+							},
+						}, nil)
+
+					return mock
+				}(),
+				machine: func() *service.MockLoanStateMachine {
+					mock := service.NewMockLoanStateMachine(ctrl)
+					mock.EXPECT().
+						AllowedActions(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: entity.LoanResult{
+				List: []*entity.Loan{
+					{
+						ID: 1,
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			l := &LoanImpl{
-				repo:   tt.fields.repo,
-				action: tt.fields.action,
+				repo:        tt.fields.repo,
+				repoProduct: tt.fields.repoProduct,
+				machine:     tt.fields.machine,
+				db:          tt.fields.db,
 			}
 			got, err := l.Get(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
@@ -146,20 +216,57 @@ func TestLoanImpl_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	type fields struct {
-		repo   repository.Loan
-		action service.LoanAction
+		repo         repository.Loan
+		repoProduct  repository.LoanProduct
+		repoBorrower repository.Borrower
+		machine      service.LoanStateMachine
+		db           database.DB
 	}
 	type args struct {
 		ctx   context.Context
 		model *entity.Loan
 	}
-	defaultArgs := args{
-		ctx: context.Background(),
-		model: &entity.Loan{
-			BorrowerID: 1,
-			Amount:     2000000,
-			Rate:       10,
-		},
+	newArgs := func(amount currency.Amount, rate float64, tenor int) args {
+		return args{
+			ctx: context.Background(),
+			model: &entity.Loan{
+				BorrowerID: 1,
+				ProductID:  1,
+				Amount:     amount,
+				Rate:       rate,
+				Tenor:      tenor,
+			},
+		}
+	}
+	newRepoProduct := func(product *entity.LoanProduct, err error) *repository.MockLoanProduct {
+		mock := repository.NewMockLoanProduct(ctrl)
+		mock.EXPECT().
+			GetDetail(gomock.Any(), int64(1)).
+			Return(product, err)
+
+		return mock
+	}
+	newRepoBorrower := func(borrower *entity.Borrower, err error) *repository.MockBorrower {
+		mock := repository.NewMockBorrower(ctrl)
+		mock.EXPECT().
+			GetDetail(gomock.Any(), int64(1)).
+			Return(borrower, err)
+
+		return mock
+	}
+	activeBorrower := &entity.Borrower{
+		ID:     1,
+		Status: constant.GeneralStatusActive,
+	}
+	defaultProduct := &entity.LoanProduct{
+		ID:           1,
+		Currency:     currency.IDR,
+		MinAmount:    currency.FromMajor(1000000),
+		MaxAmount:    currency.FromMajor(50000000),
+		MinRate:      8,
+		MaxRate:      15,
+		TenorOptions: []int{6, 12},
+		Status:       constant.GeneralStatusActive,
 	}
 	tests := []struct {
 		name    string
@@ -175,16 +282,21 @@ func TestLoanImpl_Create(t *testing.T) {
 					mock.EXPECT().
 						Create(gomock.Any(), &entity.Loan{
 							BorrowerID: 1,
-							Amount:     2000000,
+							ProductID:  1,
+							Currency:   currency.IDR,
+							Amount:     currency.FromMajor(2000000),
 							Rate:       10,
+							Tenor:      12,
 							Status:     constant.StatusProposed,
 						}).
 						Return(nil)
 
 					return mock
 				}(),
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(defaultProduct, nil),
 			},
-			args: defaultArgs,
+			args: newArgs(currency.FromMajor(2000000), 10, 12),
 		},
 		{
 			name:   "invalid param",
@@ -195,32 +307,167 @@ func TestLoanImpl_Create(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "borrower not found",
+			fields: fields{
+				repoBorrower: newRepoBorrower(nil, errorwrapper.E("data does not exist", errorwrapper.CodeNotFound)),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 10, 12),
+			wantErr: true,
+		},
+		{
+			name: "error on getting borrower",
+			fields: fields{
+				repoBorrower: newRepoBorrower(nil, assert.AnError),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 10, 12),
+			wantErr: true,
+		},
+		{
+			name: "inactive borrower",
+			fields: fields{
+				repoBorrower: newRepoBorrower(&entity.Borrower{
+					ID:     1,
+					Status: constant.GeneralStatusInactive,
+				}, nil),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 10, 12),
+			wantErr: true,
+		},
+		{
+			name: "missing product",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Loan{
+					BorrowerID: 1,
+					Amount:     currency.FromMajor(2000000),
+					Rate:       10,
+					Tenor:      12,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "product not found",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(nil, errorwrapper.E("data does not exist", errorwrapper.CodeNotFound)),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 10, 12),
+			wantErr: true,
+		},
+		{
+			name: "error on getting product",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(nil, assert.AnError),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 10, 12),
+			wantErr: true,
+		},
+		{
+			name: "inactive product",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct: newRepoProduct(&entity.LoanProduct{
+					ID:     1,
+					Status: constant.GeneralStatusInactive,
+				}, nil),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 10, 12),
+			wantErr: true,
+		},
+		{
+			name: "currency differs from product",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(defaultProduct, nil),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Loan{
+					BorrowerID: 1,
+					ProductID:  1,
+					Currency:   currency.SGD,
+					Amount:     currency.FromMajor(2000000),
+					Rate:       10,
+					Tenor:      12,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "unsupported currency",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.Loan{
+					BorrowerID: 1,
+					ProductID:  1,
+					Currency:   "XYZ",
+					Amount:     currency.FromMajor(2000000),
+					Rate:       10,
+					Tenor:      12,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "amount out of product limit",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(defaultProduct, nil),
+			},
+			args:    newArgs(currency.FromMajor(60000000), 10, 12),
+			wantErr: true,
+		},
+		{
+			name: "rate out of product limit",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(defaultProduct, nil),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 5, 12),
+			wantErr: true,
+		},
+		{
+			name: "tenor out of product options",
+			fields: fields{
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(defaultProduct, nil),
+			},
+			args:    newArgs(currency.FromMajor(2000000), 10, 24),
+			wantErr: true,
+		},
 		{
 			name: "error on create",
 			fields: fields{
 				repo: func() *repository.MockLoan {
 					mock := repository.NewMockLoan(ctrl)
 					mock.EXPECT().
-						Create(gomock.Any(), &entity.Loan{
-							BorrowerID: 1,
-							Amount:     2000000,
-							Rate:       10,
-							Status:     constant.StatusProposed,
-						}).
+						Create(gomock.Any(), gomock.Any()).
 						Return(assert.AnError)
 
 					return mock
 				}(),
+				repoBorrower: newRepoBorrower(activeBorrower, nil),
+				repoProduct:  newRepoProduct(defaultProduct, nil),
 			},
-			args:    defaultArgs,
+			args:    newArgs(currency.FromMajor(2000000), 10, 12),
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			l := &LoanImpl{
-				repo:   tt.fields.repo,
-				action: tt.fields.action,
+				repo:         tt.fields.repo,
+				repoProduct:  tt.fields.repoProduct,
+				repoBorrower: tt.fields.repoBorrower,
+				machine:      tt.fields.machine,
+				db:           tt.fields.db,
 			}
 			if err := l.Create(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
 				t.Errorf("LoanImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
@@ -229,13 +476,264 @@ func TestLoanImpl_Create(t *testing.T) {
 	}
 }
 
+func TestLoanImpl_Simulate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config             *config.Config
+		repoProduct        repository.LoanProduct
+		serviceInstallment service.Installment
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.LoanSimulation
+	}
+	newRepoProduct := func() *repository.MockLoanProduct {
+		mock := repository.NewMockLoanProduct(ctrl)
+		mock.EXPECT().
+			GetDetail(gomock.Any(), int64(1)).
+			Return(&entity.LoanProduct{
+				ID:           1,
+				Currency:     currency.IDR,
+				MinAmount:    currency.FromMajor(1000000),
+				MaxAmount:    currency.FromMajor(50000000),
+				MinRate:      8,
+				MaxRate:      15,
+				TenorOptions: []int{3, 6},
+				AdminFee:     currency.FromMajor(50000),
+				ProvisionFee: 1,
+				Status:       constant.GeneralStatusActive,
+			}, nil)
+
+		return mock
+	}
+	schedule := []*entity.Installment{
+		{
+			Sequence:  1,
+			Principal: currency.FromMajor(1000000),
+			Interest:  currency.FromMajor(30000),
+			Amount:    currency.FromMajor(1030000),
+		},
+		{
+			Sequence:  2,
+			Principal: currency.FromMajor(1000000),
+			Interest:  currency.FromMajor(20000),
+			Amount:    currency.FromMajor(1020000),
+		},
+		{
+			Sequence:  3,
+			Principal: currency.FromMajor(1000000),
+			Interest:  currency.FromMajor(10000),
+			Amount:    currency.FromMajor(1010000),
+		},
+	}
+	defaultConfig := &config.Config{
+		Vendor: config.Vendor{
+			Fee: config.FeeConfig{
+				OriginationFee: 1,
+			},
+		},
+	}
+	investmentReturn := entity.NewInvestmentReturn(currency.FromMajor(1000000), currency.FromMajor(20000), 10, 15)
+	defaultArgs := func(investmentAmount currency.Amount) args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.LoanSimulation{
+				ProductID:        1,
+				Amount:           currency.FromMajor(3000000),
+				Rate:             12,
+				Tenor:            3,
+				InvestmentAmount: investmentAmount,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.LoanQuote
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config:      defaultConfig,
+				repoProduct: newRepoProduct(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Calculate(gomock.Any(), gomock.Any()).
+						Return(schedule, nil)
+					mock.EXPECT().
+						CalculateReturn(gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, investment *entity.Investment, loan *entity.Loan) (*entity.InvestmentReturn, error) {
+							assert.Equal(t, currency.FromMajor(1000000), investment.Amount)
+							assert.Equal(t, loan.Rate, investment.ROI)
+							assert.Equal(t, currency.IDR, investment.Currency)
+							return investmentReturn, nil
+						})
+
+					return mock
+				}(),
+			},
+			args: defaultArgs(currency.FromMajor(1000000)),
+			want: &entity.LoanQuote{
+				ProductID:       1,
+				Currency:        currency.IDR,
+				Amount:          currency.FromMajor(3000000),
+				Rate:            12,
+				Tenor:           3,
+				Installments:    schedule,
+				TotalInterest:   currency.FromMajor(60000),
+				TotalPayment:    currency.FromMajor(3060000),
+				AdminFee:        currency.FromMajor(50000),
+				ProvisionFee:    currency.FromMajor(30000),
+				OriginationFee:  currency.FromMajor(30000),
+				NetDisbursement: currency.FromMajor(2890000),
+				Investment:      investmentReturn,
+			},
+		},
+		{
+			name: "success without investment",
+			fields: fields{
+				config:      defaultConfig,
+				repoProduct: newRepoProduct(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Calculate(gomock.Any(), gomock.Any()).
+						Return(schedule, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs(0),
+			want: &entity.LoanQuote{
+				ProductID:       1,
+				Currency:        currency.IDR,
+				Amount:          currency.FromMajor(3000000),
+				Rate:            12,
+				Tenor:           3,
+				Installments:    schedule,
+				TotalInterest:   currency.FromMajor(60000),
+				TotalPayment:    currency.FromMajor(3060000),
+				AdminFee:        currency.FromMajor(50000),
+				ProvisionFee:    currency.FromMajor(30000),
+				OriginationFee:  currency.FromMajor(30000),
+				NetDisbursement: currency.FromMajor(2890000),
+			},
+		},
+		{
+			name:    "invalid param",
+			fields:  fields{},
+			args:    defaultArgs(currency.FromMajor(4000000)),
+			wantErr: true,
+		},
+		{
+			name: "tenor out of product options",
+			fields: fields{
+				repoProduct: newRepoProduct(),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanSimulation{
+					ProductID: 1,
+					Amount:    currency.FromMajor(3000000),
+					Rate:      12,
+					Tenor:     12,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on calculating schedule",
+			fields: fields{
+				repoProduct: newRepoProduct(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Calculate(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(currency.FromMajor(1000000)),
+			wantErr: true,
+		},
+		{
+			name: "fees exceed loan amount",
+			fields: fields{
+				config: &config.Config{
+					Vendor: config.Vendor{
+						Fee: config.FeeConfig{
+							OriginationFee: 99,
+						},
+					},
+				},
+				repoProduct: newRepoProduct(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Calculate(gomock.Any(), gomock.Any()).
+						Return(schedule, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(0),
+			wantErr: true,
+		},
+		{
+			name: "error on calculating return",
+			fields: fields{
+				config:      defaultConfig,
+				repoProduct: newRepoProduct(),
+				serviceInstallment: func() *service.MockInstallment {
+					mock := service.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Calculate(gomock.Any(), gomock.Any()).
+						Return(schedule, nil)
+					mock.EXPECT().
+						CalculateReturn(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(currency.FromMajor(1000000)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LoanImpl{
+				config:             tt.fields.config,
+				repoProduct:        tt.fields.repoProduct,
+				serviceInstallment: tt.fields.serviceInstallment,
+			}
+			got, err := l.Simulate(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoanImpl.Simulate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoanImpl.Simulate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLoanImpl_Proceed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	type fields struct {
-		repo   repository.Loan
-		action service.LoanAction
+		repo        repository.Loan
+		repoProduct repository.LoanProduct
+		machine     service.LoanStateMachine
+		db          database.DB
 	}
 	type args struct {
 		ctx context.Context
@@ -250,6 +748,36 @@ func TestLoanImpl_Proceed(t *testing.T) {
 			},
 		},
 	}
+	mockRepo := func() *repository.MockLoan {
+		mock := repository.NewMockLoan(ctrl)
+		mock.EXPECT().
+			GetDetailForUpdate(gomock.Any(), gomock.Any()).
+			Return(&entity.Loan{
+				ID:     3,
+				Amount: currency.FromMajor(2000000),
+				Status: constant.StatusProposed,
+			}, nil)
+
+		return mock
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	mockMachine := func(state service.LoanState) *service.MockLoanStateMachine {
+		mock := service.NewMockLoanStateMachine(ctrl)
+		mock.EXPECT().
+			DetermineState(gomock.Any(), constant.StatusProposed).
+			Return(state, nil)
+
+		return mock
+	}
 	tests := []struct {
 		name    string
 		fields  fields
@@ -259,28 +787,82 @@ func TestLoanImpl_Proceed(t *testing.T) {
 		{
 			name: "success",
 			fields: fields{
-				repo: func() *repository.MockLoan {
-					mock := repository.NewMockLoan(ctrl)
+				db:   newDB(),
+				repo: mockRepo(),
+				machine: mockMachine(func() *service.MockLoanState {
+					mock := service.NewMockLoanState(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
-						Return(&entity.Loan{
-							ID:     3,
-							Amount: 2000000,
-							Status: constant.StatusProposed,
-						}, nil)
+						Approve(gomock.Any(), gomock.Any()).
+						Return(nil)
 
 					return mock
-				}(),
-				action: func() *service.MockLoanAction {
-					mock := service.NewMockLoanAction(ctrl)
+				}()),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "success reject",
+			fields: fields{
+				db:   newDB(),
+				repo: mockRepo(),
+				machine: mockMachine(func() *service.MockLoanState {
+					mock := service.NewMockLoanState(ctrl)
 					mock.EXPECT().
-						Approve(gomock.Any(), gomock.Any()).
+						Reject(gomock.Any(), gomock.Any()).
 						Return(nil)
 
+					return mock
+				}()),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					Action: constant.ActionReject,
+					Data: &entity.Loan{
+						ID: 3,
+					},
+				},
+			},
+		},
+		{
+			name: "ineligible cancel on proposed loan",
+			fields: fields{
+				db:   newDB(),
+				repo: mockRepo(),
+				machine: mockMachine(func() *service.MockLoanState {
+					mock := service.NewMockLoanState(ctrl)
+					mock.EXPECT().
+						Cancel(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}()),
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.LoanProceed{
+					Action: constant.ActionCancel,
+					Data: &entity.Loan{
+						ID: 3,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on transaction",
+			fields: fields{
+				db: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						WithTx(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
 					return mock
 				}(),
 			},
-			args: defaultArgs,
+			args:    defaultArgs,
+			wantErr: true,
 		},
 		{
 			name:   "invalid param",
@@ -294,10 +876,11 @@ func TestLoanImpl_Proceed(t *testing.T) {
 		{
 			name: "error on get detail",
 			fields: fields{
+				db: newDB(),
 				repo: func() *repository.MockLoan {
 					mock := repository.NewMockLoan(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
 						Return(nil, assert.AnError)
 
 					return mock
@@ -308,42 +891,299 @@ func TestLoanImpl_Proceed(t *testing.T) {
 		},
 		{
 			name: "unknown state",
+			fields: fields{
+				db:   newDB(),
+				repo: mockRepo(),
+				machine: func() *service.MockLoanStateMachine {
+					mock := service.NewMockLoanStateMachine(ctrl)
+					mock.EXPECT().
+						DetermineState(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on action",
+			fields: fields{
+				db:   newDB(),
+				repo: mockRepo(),
+				machine: mockMachine(func() *service.MockLoanState {
+					mock := service.NewMockLoanState(ctrl)
+					mock.EXPECT().
+						Approve(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}()),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LoanImpl{
+				repo:        tt.fields.repo,
+				repoProduct: tt.fields.repoProduct,
+				machine:     tt.fields.machine,
+				db:          tt.fields.db,
+			}
+			if err := l.Proceed(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("LoanImpl.Proceed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanImpl_GetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo        repository.Loan
+		repoProduct repository.LoanProduct
+		machine     service.LoanStateMachine
+		db          database.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.LoanStateHistory
+		wantErr bool
+	}{
+		{
+			name: "success",
 			fields: fields{
 				repo: func() *repository.MockLoan {
 					mock := repository.NewMockLoan(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
-						Return(&entity.Loan{
-							ID:     3,
-							Amount: 2000000,
+						GetHistory(gomock.Any(), int64(4)).
+						Return([]*entity.LoanStateHistory{
+							{
+								ID:         1,
+								LoanID:     4,
+								FromStatus: constant.StatusProposed,
+								ToStatus:   constant.StatusApproved,
+							},
 						}, nil)
 
 					return mock
 				}(),
 			},
-			args:    defaultArgs,
+			args: args{
+				ctx: context.Background(),
+				id:  4,
+			},
+			want: []*entity.LoanStateHistory{
+				{
+					ID:         1,
+					LoanID:     4,
+					FromStatus: constant.StatusProposed,
+					ToStatus:   constant.StatusApproved,
+				},
+			},
+		},
+		{
+			name:   "invalid loan ID",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
 			wantErr: true,
 		},
 		{
-			name: "error on action",
+			name: "error on get history",
 			fields: fields{
 				repo: func() *repository.MockLoan {
 					mock := repository.NewMockLoan(ctrl)
 					mock.EXPECT().
-						GetDetail(gomock.Any(), gomock.Any()).
+						GetHistory(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  4,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LoanImpl{
+				repo:        tt.fields.repo,
+				repoProduct: tt.fields.repoProduct,
+				machine:     tt.fields.machine,
+				db:          tt.fields.db,
+			}
+			got, err := l.GetHistory(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoanImpl.GetHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoanImpl.GetHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoanImpl_GetTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo        repository.Loan
+		repoProduct repository.LoanProduct
+		machine     service.LoanStateMachine
+		db          database.DB
+	}
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   entity.LoanTransitionGraph
+	}{
+		{
+			name: "success",
+			fields: fields{
+				machine: func() *service.MockLoanStateMachine {
+					mock := service.NewMockLoanStateMachine(ctrl)
+					mock.EXPECT().
+						Transitions().
+						Return(entity.LoanTransitionGraph{
+							{
+								From:   constant.StatusProposed,
+								Action: constant.ActionApprove,
+								To:     constant.StatusApproved,
+							},
+						})
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: entity.LoanTransitionGraph{
+				{
+					From:   constant.StatusProposed,
+					Action: constant.ActionApprove,
+					To:     constant.StatusApproved,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &LoanImpl{
+				repo:        tt.fields.repo,
+				repoProduct: tt.fields.repoProduct,
+				machine:     tt.fields.machine,
+				db:          tt.fields.db,
+			}
+			if got := l.GetTransitions(tt.args.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoanImpl.GetTransitions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoanImpl_GetAllowedActions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo        repository.Loan
+		repoProduct repository.LoanProduct
+		machine     service.LoanStateMachine
+		db          database.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		id:  3,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.LoanAllowedAction
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(3)).
 						Return(&entity.Loan{
 							ID:     3,
-							Amount: 2000000,
-							Status: constant.StatusProposed,
+							Status: constant.StatusInvested,
 						}, nil)
 
 					return mock
 				}(),
-				action: func() *service.MockLoanAction {
-					mock := service.NewMockLoanAction(ctrl)
+				machine: func() *service.MockLoanStateMachine {
+					mock := service.NewMockLoanStateMachine(ctrl)
 					mock.EXPECT().
-						Approve(gomock.Any(), gomock.Any()).
-						Return(assert.AnError)
+						AllowedActions(gomock.Any(), constant.StatusInvested).
+						Return([]*entity.LoanAllowedAction{
+							{
+								Action: constant.ActionDisburse,
+								Name:   "disburse",
+								Fields: []string{"disbursed_by", "disbursed_at"},
+								Files:  []string{"agreement_letter"},
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+			want: []*entity.LoanAllowedAction{
+				{
+					Action: constant.ActionDisburse,
+					Name:   "disburse",
+					Fields: []string{"disbursed_by", "disbursed_at"},
+					Files:  []string{"agreement_letter"},
+				},
+			},
+		},
+		{
+			name:   "invalid loan ID",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get detail",
+			fields: fields{
+				repo: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
 
 					return mock
 				}(),
@@ -355,11 +1195,18 @@ func TestLoanImpl_Proceed(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			l := &LoanImpl{
-				repo:   tt.fields.repo,
-				action: tt.fields.action,
+				repo:        tt.fields.repo,
+				repoProduct: tt.fields.repoProduct,
+				machine:     tt.fields.machine,
+				db:          tt.fields.db,
 			}
-			if err := l.Proceed(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
-				t.Errorf("LoanImpl.Proceed() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := l.GetAllowedActions(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoanImpl.GetAllowedActions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoanImpl.GetAllowedActions() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,49 @@
+package state
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type (
+	// Guard validates whether a transition may proceed
+	Guard func(ctx context.Context, req *entity.LoanProceed) error
+
+	// Hook is executed after the transaction of a successfully proceeded transition has been committed
+	Hook func(ctx context.Context, req *entity.LoanProceed, transition *entity.LoanTransition)
+)
+
+var (
+	// guards lists all guards which can be referred by name in the transition table
+	guards = map[string]Guard{
+		"reason_required": reasonRequired,
+	}
+
+	// hooks lists all hooks which can be referred by name in the transition table
+	hooks = map[string]Hook{
+		"log_transition": logTransition,
+	}
+)
+
+// reasonRequired makes sure the transition is accompanied by a reason
+func reasonRequired(ctx context.Context, req *entity.LoanProceed) error {
+	if strings.TrimSpace(req.Data.Reason) == "" {
+		return errorwrapper.E("reason is required", errorwrapper.CodeInvalid)
+	}
+
+	return nil
+}
+
+// logTransition writes the transition to the service log
+func logTransition(ctx context.Context, req *entity.LoanProceed, transition *entity.LoanTransition) {
+	log.Printf("loan %d moved from %s to %s by %s action\n",
+		req.Data.ID,
+		transition.From.Name(),
+		transition.To.Name(),
+		transition.Action.Name(),
+	)
+}
@@ -0,0 +1,36 @@
+package state
+
+import (
+	"github.com/ecintiawan/loan-service/internal/constant"
+)
+
+// requirement lists the form fields and files a loan proceed request must carry for certain action
+type requirement struct {
+	fields []string
+	files  []string
+}
+
+// requirements of every supported action,
+// automatic actions are proceeded by the service itself so they don't require anything
+var requirements = map[constant.LoanAction]requirement{
+	constant.ActionApprove: {
+		fields: []string{"approved_by", "approved_at"},
+		files:  []string{"approval_proof"},
+	},
+	constant.ActionInvest: {
+		fields: []string{"invested_at"},
+	},
+	constant.ActionDisburse: {
+		fields: []string{"disbursed_by", "disbursed_at"},
+		files:  []string{"agreement_letter"},
+	},
+	constant.ActionReject: {
+		fields: []string{"rejected_by", "rejected_at", "reason"},
+	},
+	constant.ActionCancel: {
+		fields: []string{"cancelled_by", "cancelled_at", "reason"},
+	},
+	constant.ActionRepay:   {},
+	constant.ActionDefault: {},
+	constant.ActionExpire:  {},
+}
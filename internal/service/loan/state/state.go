@@ -2,9 +2,13 @@ package state
 
 import (
 	"context"
+	"fmt"
+	"log"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
 	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
 )
 
@@ -13,41 +17,194 @@ var (
 	errorIneligibleAction = errorwrapper.E("ineligible action", errorwrapper.CodeInvalid)
 )
 
-func DetermineState(
+type (
+	// Machine is the loan state machine built from the declarative transition table,
+	// every loan status is represented by the same State struct holding its outgoing transitions
+	Machine struct {
+		states  map[constant.LoanStatus]map[constant.LoanAction]*transition
+		graph   entity.LoanTransitionGraph
+		effects map[constant.LoanAction]effect
+	}
+
+	// transition is a single allowed edge of the state machine along with its resolved guards and hooks
+	transition struct {
+		*entity.LoanTransition
+		guards []Guard
+		hooks  []Hook
+	}
+
+	// effect is the loan action executed once a transition is allowed
+	effect func(ctx context.Context, req *entity.LoanProceed) error
+)
+
+// NewLoanStateMachine builds the loan state machine from config,
+// the service is not able to run without a valid transition table
+func NewLoanStateMachine(
+	config *config.Config,
+	action service.LoanAction,
+) service.LoanStateMachine {
+	machine, err := NewMachine(config.StateMachine.Transitions, action)
+	if err != nil {
+		log.Fatalf("error building loan state machine: %v", err)
+	}
+
+	return machine
+}
+
+// NewMachine validates the given transition table and builds the loan state machine out of it
+func NewMachine(
+	transitions []config.TransitionConfig,
+	action service.LoanAction,
+) (*Machine, error) {
+	var (
+		machine = &Machine{
+			states:  map[constant.LoanStatus]map[constant.LoanAction]*transition{},
+			graph:   entity.LoanTransitionGraph{},
+			effects: newEffects(action),
+		}
+	)
+
+	if len(transitions) <= 0 {
+		return nil, fmt.Errorf("no transition configured")
+	}
+
+	for _, cfg := range transitions {
+		from, ok := constant.ParseLoanStatus(cfg.From)
+		if !ok {
+			return nil, fmt.Errorf("unknown status %q", cfg.From)
+		}
+		to, ok := constant.ParseLoanStatus(cfg.To)
+		if !ok {
+			return nil, fmt.Errorf("unknown status %q", cfg.To)
+		}
+		loanAction, ok := constant.ParseLoanAction(cfg.Action)
+		if !ok {
+			return nil, fmt.Errorf("unknown action %q", cfg.Action)
+		}
+		if _, ok := machine.effects[loanAction]; !ok {
+			return nil, fmt.Errorf("action %q is not supported", cfg.Action)
+		}
+
+		t := &transition{
+			LoanTransition: &entity.LoanTransition{
+				From:   from,
+				Action: loanAction,
+				To:     to,
+				Guards: cfg.Guards,
+				Hooks:  cfg.Hooks,
+			},
+		}
+		for _, name := range cfg.Guards {
+			guard, ok := guards[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown guard %q", name)
+			}
+			t.guards = append(t.guards, guard)
+		}
+		for _, name := range cfg.Hooks {
+			hook, ok := hooks[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown hook %q", name)
+			}
+			t.hooks = append(t.hooks, hook)
+		}
+
+		if _, ok := machine.states[from]; !ok {
+			machine.states[from] = map[constant.LoanAction]*transition{}
+		}
+		if _, ok := machine.states[to]; !ok {
+			machine.states[to] = map[constant.LoanAction]*transition{}
+		}
+		if _, ok := machine.states[from][loanAction]; ok {
+			return nil, fmt.Errorf("duplicate transition of action %q from status %q", cfg.Action, cfg.From)
+		}
+
+		machine.states[from][loanAction] = t
+		machine.graph = append(machine.graph, t.LoanTransition)
+	}
+
+	return machine, nil
+}
+
+// DetermineState will return the state of loan with the given status,
+// a status which doesn't appear in the transition table is considered unknown
+func (m *Machine) DetermineState(
 	ctx context.Context,
 	status constant.LoanStatus,
-	action service.LoanAction,
 ) (service.LoanState, error) {
+	transitions, ok := m.states[status]
+	if !ok {
+		return nil, errorUnknownState
+	}
+
+	return &State{
+		transitions: transitions,
+		effects:     m.effects,
+	}, nil
+}
+
+// Transitions will return all allowed status transitions in the configured order
+func (m *Machine) Transitions() entity.LoanTransitionGraph {
+	return m.graph
+}
+
+// AllowedActions will return all actions which can be proceeded on loan with the given status
+// along with their requirements, in the configured order
+func (m *Machine) AllowedActions(
+	ctx context.Context,
+	status constant.LoanStatus,
+) ([]*entity.LoanAllowedAction, error) {
 	var (
-		state service.LoanState
-		err   error
+		result = []*entity.LoanAllowedAction{}
 	)
 
-	switch status {
-	case constant.StatusProposed:
-		// Initial state, after the loan has been created
-		// May only do approval action
-		state = NewProposedState(action)
+	if _, ok := m.states[status]; !ok {
+		return nil, errorUnknownState
+	}
 
-	case constant.StatusApproved:
-		// Second state, after the loan has been approved
-		// May only do invest action after it's been fully invested
-		state = NewApprovedState(action)
+	for _, transition := range m.graph {
+		if transition.From != status {
+			continue
+		}
 
-	case constant.StatusInvested:
-		// Third state, after the loan has been invested
-		// May only do disbursement action
-		state = NewInvestedState(action)
+		requirement := requirements[transition.Action]
+		result = append(result, &entity.LoanAllowedAction{
+			Action:    transition.Action,
+			Name:      transition.Action.Name(),
+			Fields:    append([]string{}, requirement.fields...),
+			Files:     append([]string{}, requirement.files...),
+			Automatic: transition.Action.IsAutomatic(),
+		})
+	}
 
-	case constant.StatusDisbursed:
-		// Fourth and final state, after the loan has been disbursed
-		// No more action can be done
-		state = NewDisbursedState(action)
+	return result, nil
+}
 
-	default:
-		// Impossible state, throw error
-		err = errorUnknownState
+func newEffects(action service.LoanAction) map[constant.LoanAction]effect {
+	return map[constant.LoanAction]effect{
+		constant.ActionApprove: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Approve(ctx, req)
+		},
+		constant.ActionInvest: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Invest(ctx, req)
+		},
+		constant.ActionDisburse: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Disburse(ctx, req)
+		},
+		constant.ActionReject: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Reject(ctx, req)
+		},
+		constant.ActionCancel: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Cancel(ctx, req)
+		},
+		constant.ActionRepay: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Repay(ctx, req)
+		},
+		constant.ActionDefault: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Default(ctx, req)
+		},
+		constant.ActionExpire: func(ctx context.Context, req *entity.LoanProceed) error {
+			return action.Expire(ctx, req)
+		},
 	}
-
-	return state, err
 }
@@ -0,0 +1,81 @@
+package state
+
+import (
+	"context"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/pkg/database"
+)
+
+// State is a single loan status of the state machine
+// 1. only actions listed in its outgoing transitions are eligible
+// 2. every guard of the transition must pass before the action is executed
+// 3. every hook of the transition is executed once the transaction of the action is committed
+// a state without any outgoing transition is final, meaning it can't be any more forwarded or backwarded
+type State struct {
+	transitions map[constant.LoanAction]*transition
+	effects     map[constant.LoanAction]effect
+}
+
+func (s *State) Approve(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionApprove, req)
+}
+
+func (s *State) Invest(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionInvest, req)
+}
+
+func (s *State) Disburse(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionDisburse, req)
+}
+
+func (s *State) Reject(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionReject, req)
+}
+
+func (s *State) Cancel(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionCancel, req)
+}
+
+func (s *State) Repay(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionRepay, req)
+}
+
+func (s *State) Default(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionDefault, req)
+}
+
+func (s *State) Expire(ctx context.Context, req *entity.LoanProceed) error {
+	return s.proceed(ctx, constant.ActionExpire, req)
+}
+
+func (s *State) proceed(ctx context.Context, action constant.LoanAction, req *entity.LoanProceed) error {
+	transition, ok := s.transitions[action]
+	if !ok {
+		return errorIneligibleAction
+	}
+
+	for _, guard := range transition.guards {
+		err := guard(ctx, req)
+		if err != nil {
+			return err
+		}
+	}
+
+	req.NextStatus = transition.To
+	err := s.effects[action](ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// the action may be part of a larger transaction which can still be rolled back,
+	// so hooks only run for transitions which have actually happened
+	database.AfterCommit(ctx, func(ctx context.Context) {
+		for _, hook := range transition.hooks {
+			hook(ctx, req, transition.LoanTransition)
+		}
+	})
+
+	return nil
+}
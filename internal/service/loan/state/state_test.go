@@ -6,19 +6,143 @@ import (
 	"testing"
 
 	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
 	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
-func TestDetermineState(t *testing.T) {
+var defaultTransitions = []config.TransitionConfig{
+	{From: "proposed", Action: "approve", To: "approved"},
+	{From: "proposed", Action: "reject", To: "rejected", Guards: []string{"reason_required"}},
+	{From: "approved", Action: "invest", To: "invested"},
+	{From: "approved", Action: "cancel", To: "cancelled", Guards: []string{"reason_required"}},
+	{From: "approved", Action: "expire", To: "expired"},
+	{From: "invested", Action: "disburse", To: "disbursed"},
+	{From: "disbursed", Action: "repay", To: "repaid"},
+	{From: "disbursed", Action: "default", To: "defaulted", Hooks: []string{"log_transition"}},
+	{From: "defaulted", Action: "repay", To: "repaid"},
+}
+
+func TestNewMachine(t *testing.T) {
+	type args struct {
+		transitions []config.TransitionConfig
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    entity.LoanTransitionGraph
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				transitions: []config.TransitionConfig{
+					{From: "proposed", Action: "reject", To: "rejected", Guards: []string{"reason_required"}},
+				},
+			},
+			want: entity.LoanTransitionGraph{
+				{
+					From:   constant.StatusProposed,
+					Action: constant.ActionReject,
+					To:     constant.StatusRejected,
+					Guards: []string{"reason_required"},
+				},
+			},
+		},
+		{
+			name:    "no transition",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "unknown from status",
+			args: args{
+				transitions: []config.TransitionConfig{
+					{From: "drafted", Action: "approve", To: "approved"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown to status",
+			args: args{
+				transitions: []config.TransitionConfig{
+					{From: "proposed", Action: "approve", To: "drafted"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown action",
+			args: args{
+				transitions: []config.TransitionConfig{
+					{From: "proposed", Action: "draft", To: "approved"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown guard",
+			args: args{
+				transitions: []config.TransitionConfig{
+					{From: "proposed", Action: "approve", To: "approved", Guards: []string{"unknown"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown hook",
+			args: args{
+				transitions: []config.TransitionConfig{
+					{From: "proposed", Action: "approve", To: "approved", Hooks: []string{"unknown"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate transition",
+			args: args{
+				transitions: []config.TransitionConfig{
+					{From: "proposed", Action: "approve", To: "approved"},
+					{From: "proposed", Action: "approve", To: "invested"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMachine(tt.args.transitions, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewMachine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Transitions(), tt.want) {
+				t.Errorf("NewMachine() = %v, want %v", got.Transitions(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMachine_DetermineState(t *testing.T) {
+	machine, err := NewMachine(defaultTransitions, nil)
+	if err != nil {
+		t.Fatalf("NewMachine() error = %v", err)
+	}
+
 	type args struct {
 		ctx    context.Context
 		status constant.LoanStatus
-		action service.LoanAction
 	}
 	tests := []struct {
 		name    string
 		args    args
-		want    service.LoanState
+		want    []constant.LoanAction
 		wantErr bool
 	}{
 		{
@@ -27,23 +151,241 @@ func TestDetermineState(t *testing.T) {
 				ctx:    context.Background(),
 				status: constant.StatusProposed,
 			},
-			want: &ProposedState{},
+			want: []constant.LoanAction{constant.ActionApprove, constant.ActionReject},
+		},
+		{
+			name: "success disbursed",
+			args: args{
+				ctx:    context.Background(),
+				status: constant.StatusDisbursed,
+			},
+			want: []constant.LoanAction{constant.ActionRepay, constant.ActionDefault},
+		},
+		{
+			name: "success repaid",
+			args: args{
+				ctx:    context.Background(),
+				status: constant.StatusRepaid,
+			},
+			want: []constant.LoanAction{},
 		},
 		{
-			name: "success approved",
+			name: "unknown",
 			args: args{
 				ctx:    context.Background(),
-				status: constant.StatusApproved,
+				status: 0,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := machine.DetermineState(tt.args.ctx, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Machine.DetermineState() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			state := got.(*State)
+			if len(state.transitions) != len(tt.want) {
+				t.Errorf("Machine.DetermineState() = %v, want %v", state.transitions, tt.want)
+			}
+			for _, action := range tt.want {
+				if _, ok := state.transitions[action]; !ok {
+					t.Errorf("Machine.DetermineState() missing action %v", action)
+				}
+			}
+		})
+	}
+}
+
+func TestState_Proceed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		status  constant.LoanStatus
+		proceed func(state service.LoanState, ctx context.Context, req *entity.LoanProceed) error
+		req     *entity.LoanProceed
+	}
+	tests := []struct {
+		name           string
+		action         service.LoanAction
+		args           args
+		wantNextStatus constant.LoanStatus
+		wantErr        bool
+	}{
+		{
+			name: "success approve",
+			action: func() *service.MockLoanAction {
+				mock := service.NewMockLoanAction(ctrl)
+				mock.EXPECT().
+					Approve(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				return mock
+			}(),
+			args: args{
+				status:  constant.StatusProposed,
+				proceed: service.LoanState.Approve,
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{ID: 1},
+				},
 			},
-			want: &ApprovedState{},
+			wantNextStatus: constant.StatusApproved,
 		},
 		{
-			name: "success invested",
+			name: "success default with hook",
+			action: func() *service.MockLoanAction {
+				mock := service.NewMockLoanAction(ctrl)
+				mock.EXPECT().
+					Default(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				return mock
+			}(),
+			args: args{
+				status:  constant.StatusDisbursed,
+				proceed: service.LoanState.Default,
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{ID: 1},
+				},
+			},
+			wantNextStatus: constant.StatusDefaulted,
+		},
+		{
+			name: "success repay defaulted",
+			action: func() *service.MockLoanAction {
+				mock := service.NewMockLoanAction(ctrl)
+				mock.EXPECT().
+					Repay(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				return mock
+			}(),
+			args: args{
+				status:  constant.StatusDefaulted,
+				proceed: service.LoanState.Repay,
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{ID: 1},
+				},
+			},
+			wantNextStatus: constant.StatusRepaid,
+		},
+		{
+			name: "ineligible action",
+			args: args{
+				status:  constant.StatusProposed,
+				proceed: service.LoanState.Disburse,
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{ID: 1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ineligible action on final state",
+			args: args{
+				status:  constant.StatusCancelled,
+				proceed: service.LoanState.Invest,
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{ID: 1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "guard rejected",
+			args: args{
+				status:  constant.StatusApproved,
+				proceed: service.LoanState.Cancel,
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{ID: 1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on action",
+			action: func() *service.MockLoanAction {
+				mock := service.NewMockLoanAction(ctrl)
+				mock.EXPECT().
+					Reject(gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+
+				return mock
+			}(),
+			args: args{
+				status:  constant.StatusProposed,
+				proceed: service.LoanState.Reject,
+				req: &entity.LoanProceed{
+					Data: &entity.Loan{ID: 1, Reason: "incomplete document"},
+				},
+			},
+			wantNextStatus: constant.StatusRejected,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine, err := NewMachine(defaultTransitions, tt.action)
+			if err != nil {
+				t.Fatalf("NewMachine() error = %v", err)
+			}
+			state, err := machine.DetermineState(context.Background(), tt.args.status)
+			if err != nil {
+				t.Fatalf("Machine.DetermineState() error = %v", err)
+			}
+
+			if err := tt.args.proceed(state, context.Background(), tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("State.proceed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.args.req.NextStatus != tt.wantNextStatus {
+				t.Errorf("State.proceed() next status = %v, want %v", tt.args.req.NextStatus, tt.wantNextStatus)
+			}
+		})
+	}
+}
+
+func TestMachine_AllowedActions(t *testing.T) {
+	machine, err := NewMachine(defaultTransitions, nil)
+	if err != nil {
+		t.Fatalf("NewMachine() error = %v", err)
+	}
+
+	type args struct {
+		ctx    context.Context
+		status constant.LoanStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*entity.LoanAllowedAction
+		wantErr bool
+	}{
+		{
+			name: "success proposed",
 			args: args{
 				ctx:    context.Background(),
-				status: constant.StatusInvested,
+				status: constant.StatusProposed,
+			},
+			want: []*entity.LoanAllowedAction{
+				{
+					Action: constant.ActionApprove,
+					Name:   "approve",
+					Fields: []string{"approved_by", "approved_at"},
+					Files:  []string{"approval_proof"},
+				},
+				{
+					Action: constant.ActionReject,
+					Name:   "reject",
+					Fields: []string{"rejected_by", "rejected_at", "reason"},
+					Files:  []string{},
+				},
 			},
-			want: &InvestedState{},
 		},
 		{
 			name: "success disbursed",
@@ -51,27 +393,49 @@ func TestDetermineState(t *testing.T) {
 				ctx:    context.Background(),
 				status: constant.StatusDisbursed,
 			},
-			want: &DisbursedState{},
+			want: []*entity.LoanAllowedAction{
+				{
+					Action:    constant.ActionRepay,
+					Name:      "repay",
+					Fields:    []string{},
+					Files:     []string{},
+					Automatic: true,
+				},
+				{
+					Action:    constant.ActionDefault,
+					Name:      "default",
+					Fields:    []string{},
+					Files:     []string{},
+					Automatic: true,
+				},
+			},
+		},
+		{
+			name: "success final state",
+			args: args{
+				ctx:    context.Background(),
+				status: constant.StatusRepaid,
+			},
+			want: []*entity.LoanAllowedAction{},
 		},
 		{
 			name: "unknown state",
 			args: args{
 				ctx:    context.Background(),
-				status: 10,
+				status: 0,
 			},
-			want:    nil,
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := DetermineState(tt.args.ctx, tt.args.status, tt.args.action)
+			got, err := machine.AllowedActions(tt.args.ctx, tt.args.status)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("DetermineState() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Machine.AllowedActions() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("DetermineState() = %v, want %v", got, tt.want)
+				t.Errorf("Machine.AllowedActions() = %v, want %v", got, tt.want)
 			}
 		})
 	}
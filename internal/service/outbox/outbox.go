@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type OutboxImpl struct {
+	config       *config.Config
+	repoOutbox   repository.Outbox
+	repoNotifier repository.Notifier
+	db           database.DB
+}
+
+func NewOutboxImpl(
+	config *config.Config,
+	repoOutbox repository.Outbox,
+	repoNotifier repository.Notifier,
+	db database.DB,
+) service.Outbox {
+	return &OutboxImpl{
+		config:       config,
+		repoOutbox:   repoOutbox,
+		repoNotifier: repoNotifier,
+		db:           db,
+	}
+}
+
+// Dispatch will deliver pending notifications which are due,
+// failed deliveries are retried with exponential backoff until they are given up as dead
+// the batch is claimed in a short transaction and delivered outside of it, so the delivery state
+// of a sent notification is never rolled back by a failure on another one of the batch
+func (o *OutboxImpl) Dispatch(
+	ctx context.Context,
+	now time.Time,
+) (entity.OutboxResult, error) {
+	var (
+		result = entity.OutboxResult{}
+		cfg    = o.config.Worker.Outbox
+		list   []*entity.Outbox
+	)
+
+	backoff, err := time.ParseDuration(cfg.Backoff)
+	if err != nil || backoff <= 0 {
+		return result, errorwrapper.E(fmt.Sprintf("invalid outbox backoff: %q", cfg.Backoff), errorwrapper.CodeInternal)
+	}
+	maxBackoff, err := time.ParseDuration(cfg.MaxBackoff)
+	if err != nil || maxBackoff < backoff {
+		return result, errorwrapper.E(fmt.Sprintf("invalid outbox max backoff: %q", cfg.MaxBackoff), errorwrapper.CodeInternal)
+	}
+
+	// every claimed notification is counted as an attempt and scheduled for its next one up front,
+	// so dispatchers of other instances skip it and it is only picked up again once its outcome is lost
+	err = o.db.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		list, err = o.repoOutbox.Get(ctx, &entity.OutboxFilter{
+			Status:         constant.OutboxStatusPending,
+			NextAttemptEnd: now,
+			Limit:          cfg.BatchSize,
+			ForUpdate:      true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, outbox := range list {
+			outbox.Attempts++
+			outbox.NextAttemptAt = now.Add(getBackoff(outbox.Attempts, backoff, maxBackoff))
+			err = o.repoOutbox.Update(ctx, outbox)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, outbox := range list {
+		result.Evaluated++
+
+		errNotify := o.repoNotifier.Notify(ctx, outbox.ToNotifier())
+		switch {
+		case errNotify == nil:
+			outbox.Status = constant.OutboxStatusSent
+			outbox.SentAt = now
+			outbox.LastError = ""
+			result.Sent++
+		case outbox.Attempts >= cfg.MaxAttempts:
+			log.Println("error delivering outbox, giving up", outbox.ID, errNotify)
+			outbox.Status = constant.OutboxStatusDead
+			outbox.LastError = errNotify.Error()
+			result.Dead++
+		default:
+			log.Println("error delivering outbox, retrying", outbox.ID, errNotify)
+			outbox.LastError = errNotify.Error()
+			result.Retried++
+		}
+
+		// delivery is at least once, a notification whose outcome can't be stored is sent again on its next attempt
+		err = o.repoOutbox.Update(ctx, outbox)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// getBackoff returns the delay before the next attempt,
+// it is doubled on every failed attempt and capped at max backoff
+func getBackoff(attempts int, backoff, maxBackoff time.Duration) time.Duration {
+	delay := backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return delay
+}
@@ -0,0 +1,363 @@
+package outbox
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOutboxImpl(t *testing.T) {
+	type args struct {
+		config       *config.Config
+		repoOutbox   repository.Outbox
+		repoNotifier repository.Notifier
+		db           database.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Outbox
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &OutboxImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutboxImpl(tt.args.config, tt.args.repoOutbox, tt.args.repoNotifier, tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewOutboxImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutboxImpl_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config       *config.Config
+		repoOutbox   repository.Outbox
+		repoNotifier repository.Notifier
+		db           database.DB
+	}
+	type args struct {
+		ctx context.Context
+		now time.Time
+	}
+	var (
+		now           = time.Date(2024, 9, 17, 10, 0, 0, 0, time.Local)
+		defaultConfig = &config.Config{
+			Worker: config.Worker{
+				Outbox: config.OutboxConfig{
+					BatchSize:   10,
+					MaxAttempts: 3,
+					Backoff:     "1m",
+					MaxBackoff:  "1h",
+				},
+			},
+		}
+		outboxFilter = &entity.OutboxFilter{
+			Status:         constant.OutboxStatusPending,
+			NextAttemptEnd: now,
+			Limit:          10,
+			ForUpdate:      true,
+		}
+		defaultArgs = args{
+			ctx: context.Background(),
+			now: now,
+		}
+	)
+	newOutbox := func(id int64, attempts int) *entity.Outbox {
+		return &entity.Outbox{
+			ID:            id,
+			Recipients:    []string{"test@gmail.com"},
+			Subject:       "Loan Default - Loan ID 1",
+			Body:          "body",
+			Status:        constant.OutboxStatusPending,
+			Attempts:      attempts,
+			NextAttemptAt: now,
+		}
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    entity.OutboxResult
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config: defaultConfig,
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), outboxFilter).
+						Return([]*entity.Outbox{
+							newOutbox(1, 0),
+							newOutbox(2, 1),
+							newOutbox(3, 2),
+						}, nil)
+
+					// claimed up front
+					sent := newOutbox(1, 1)
+					sent.NextAttemptAt = now.Add(time.Minute)
+					mock.EXPECT().
+						Update(gomock.Any(), sent).
+						Return(nil)
+					retried := newOutbox(2, 2)
+					retried.NextAttemptAt = now.Add(2 * time.Minute)
+					mock.EXPECT().
+						Update(gomock.Any(), retried).
+						Return(nil)
+					dead := newOutbox(3, 3)
+					dead.NextAttemptAt = now.Add(4 * time.Minute)
+					mock.EXPECT().
+						Update(gomock.Any(), dead).
+						Return(nil)
+
+					// outcome of the delivery
+					sent = newOutbox(1, 1)
+					sent.NextAttemptAt = now.Add(time.Minute)
+					sent.Status = constant.OutboxStatusSent
+					sent.SentAt = now
+					mock.EXPECT().
+						Update(gomock.Any(), sent).
+						Return(nil)
+					retried = newOutbox(2, 2)
+					retried.NextAttemptAt = now.Add(2 * time.Minute)
+					retried.LastError = assert.AnError.Error()
+					mock.EXPECT().
+						Update(gomock.Any(), retried).
+						Return(nil)
+					dead = newOutbox(3, 3)
+					dead.NextAttemptAt = now.Add(4 * time.Minute)
+					dead.Status = constant.OutboxStatusDead
+					dead.LastError = assert.AnError.Error()
+					mock.EXPECT().
+						Update(gomock.Any(), dead).
+						Return(nil)
+
+					return mock
+				}(),
+				repoNotifier: func() *repository.MockNotifier {
+					mock := repository.NewMockNotifier(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().
+							Notify(gomock.Any(), gomock.Any()).
+							Return(nil),
+						mock.EXPECT().
+							Notify(gomock.Any(), gomock.Any()).
+							Return(assert.AnError).
+							Times(2),
+					)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args: defaultArgs,
+			want: entity.OutboxResult{
+				Evaluated: 3,
+				Sent:      1,
+				Retried:   1,
+				Dead:      1,
+			},
+		},
+		{
+			name: "invalid backoff config",
+			fields: fields{
+				config: &config.Config{
+					Worker: config.Worker{
+						Outbox: config.OutboxConfig{
+							Backoff:    "1h",
+							MaxBackoff: "1m",
+						},
+					},
+				},
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				config: defaultConfig,
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), outboxFilter).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on claim",
+			fields: fields{
+				config: defaultConfig,
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), outboxFilter).
+						Return([]*entity.Outbox{newOutbox(1, 0)}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				config: defaultConfig,
+				repoOutbox: func() *repository.MockOutbox {
+					mock := repository.NewMockOutbox(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), outboxFilter).
+						Return([]*entity.Outbox{newOutbox(1, 0), newOutbox(2, 0)}, nil)
+					gomock.InOrder(
+						mock.EXPECT().
+							Update(gomock.Any(), gomock.Any()).
+							Return(nil).
+							Times(2),
+						mock.EXPECT().
+							Update(gomock.Any(), gomock.Any()).
+							Return(assert.AnError),
+					)
+
+					return mock
+				}(),
+				repoNotifier: func() *repository.MockNotifier {
+					mock := repository.NewMockNotifier(ctrl)
+					mock.EXPECT().
+						Notify(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args: defaultArgs,
+			want: entity.OutboxResult{
+				Evaluated: 1,
+				Sent:      1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on transaction",
+			fields: fields{
+				config: defaultConfig,
+				db: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						WithTx(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &OutboxImpl{
+				config:       tt.fields.config,
+				repoOutbox:   tt.fields.repoOutbox,
+				repoNotifier: tt.fields.repoNotifier,
+				db:           tt.fields.db,
+			}
+			got, err := o.Dispatch(tt.args.ctx, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OutboxImpl.Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OutboxImpl.Dispatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getBackoff(t *testing.T) {
+	type args struct {
+		attempts   int
+		backoff    time.Duration
+		maxBackoff time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Duration
+	}{
+		{
+			name: "first attempt",
+			args: args{
+				attempts:   1,
+				backoff:    time.Minute,
+				maxBackoff: time.Hour,
+			},
+			want: time.Minute,
+		},
+		{
+			name: "doubled on every attempt",
+			args: args{
+				attempts:   4,
+				backoff:    time.Minute,
+				maxBackoff: time.Hour,
+			},
+			want: 8 * time.Minute,
+		},
+		{
+			name: "capped at max backoff",
+			args: args{
+				attempts:   10,
+				backoff:    time.Minute,
+				maxBackoff: time.Hour,
+			},
+			want: time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getBackoff(tt.args.attempts, tt.args.backoff, tt.args.maxBackoff); got != tt.want {
+				t.Errorf("getBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
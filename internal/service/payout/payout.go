@@ -0,0 +1,198 @@
+package payout
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type PayoutImpl struct {
+	config         *config.Config
+	repoPayout     repository.Payout
+	repoInvestment repository.Investment
+	serviceWallet  service.Wallet
+	serviceLedger  service.Ledger
+}
+
+func NewPayoutImpl(
+	config *config.Config,
+	repoPayout repository.Payout,
+	repoInvestment repository.Investment,
+	serviceWallet service.Wallet,
+	serviceLedger service.Ledger,
+) service.Payout {
+	return &PayoutImpl{
+		config:         config,
+		repoPayout:     repoPayout,
+		repoInvestment: repoInvestment,
+		serviceWallet:  serviceWallet,
+		serviceLedger:  serviceLedger,
+	}
+}
+
+// Distribute will split a repayment across active investments of the loan
+// proportional to their invested amount, less the configured service fee and withholding tax on the interest
+func (p *PayoutImpl) Distribute(
+	ctx context.Context,
+	repayment *entity.Repayment,
+) error {
+	if repayment.ID <= 0 || repayment.LoanID <= 0 {
+		return errorwrapper.E("invalid repayment for payout", errorwrapper.CodeInvalid)
+	}
+
+	investment, err := p.repoInvestment.Get(ctx, &entity.InvestmentFilter{
+		DataTable: entity.DataTableFilter{
+			Pagination: entity.DataTablePagination{
+				DisablePagination: true,
+			},
+		},
+		LoanID: repayment.LoanID,
+		Status: constant.GeneralStatusActive,
+	})
+	if err != nil {
+		return err
+	}
+	if len(investment.List) <= 0 {
+		return nil
+	}
+
+	payouts := split(repayment, investment.List, p.config.Vendor.Fee)
+	err = p.repoPayout.CreateBulk(ctx, payouts)
+	if err != nil {
+		return err
+	}
+
+	// investments of a loan share its currency
+	code := investment.List[0].Currency
+	err = p.creditWallets(ctx, payouts, code)
+	if err != nil {
+		return err
+	}
+
+	return p.serviceLedger.Post(ctx, entity.NewRepaymentJournal(repayment, payouts, code))
+}
+
+// creditWallets pays out every payout into the wallet of its investor,
+// wallets are locked in the order of investor ID as every transaction moving more than one wallet does
+func (p *PayoutImpl) creditWallets(
+	ctx context.Context,
+	payouts []*entity.Payout,
+	code string,
+) error {
+	sorted := make([]*entity.Payout, len(payouts))
+	copy(sorted, payouts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].InvestorID < sorted[j].InvestorID
+	})
+
+	for _, payout := range sorted {
+		err := p.serviceWallet.Payout(ctx, payout, code)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByInvestment will return payout history of an investment
+func (p *PayoutImpl) GetByInvestment(
+	ctx context.Context,
+	investmentID int64,
+) ([]*entity.Payout, error) {
+	if investmentID <= 0 {
+		return nil, errorwrapper.E("invalid investment ID", errorwrapper.CodeInvalid)
+	}
+
+	return p.repoPayout.Get(ctx, &entity.PayoutFilter{
+		InvestmentID: investmentID,
+	})
+}
+
+// split calculates the payout of every investment based on its share of the total invested amount,
+// the payouts always add up to the repayment and none of them is ever negative,
+// service fee and withholding tax are deducted from the interest share of every payout
+func split(repayment *entity.Repayment, investments []*entity.Investment, cfg config.FeeConfig) []*entity.Payout {
+	var (
+		payouts = make([]*entity.Payout, 0, len(investments))
+		sorted  = make([]*entity.Investment, len(investments))
+		weights = make([]int64, len(investments))
+		total   int64
+	)
+
+	copy(sorted, investments)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	for idx, investment := range sorted {
+		weights[idx] = int64(investment.Amount)
+		total += int64(investment.Amount)
+	}
+
+	var (
+		principals = allocate(repayment.Principal, weights, total)
+		interests  = allocate(repayment.Interest, weights, total)
+		penalties  = allocate(repayment.Penalty, weights, total)
+	)
+	for idx, investment := range sorted {
+		payout := &entity.Payout{
+			RepaymentID:  repayment.ID,
+			InvestmentID: investment.ID,
+			InvestorID:   investment.InvestorID,
+			LoanID:       repayment.LoanID,
+			Principal:    principals[idx],
+			Interest:     interests[idx],
+			Penalty:      penalties[idx],
+			Amount:       principals[idx] + interests[idx] + penalties[idx],
+		}
+		payout.Deduct(cfg.ServiceFee, cfg.WithholdingTax)
+		payouts = append(payouts, payout)
+	}
+
+	return payouts
+}
+
+// allocate splits a non-negative amount in proportion to the weights, every share is rounded down
+// and the leftover minor units are handed out one at a time by the largest remainder,
+// ties going to the earlier weight
+func allocate(amount currency.Amount, weights []int64, total int64) []currency.Amount {
+	var (
+		shares     = make([]currency.Amount, len(weights))
+		remainders = make([]int64, len(weights))
+		order      = make([]int, len(weights))
+		leftover   = amount
+	)
+	if total <= 0 {
+		return shares
+	}
+
+	for idx, weight := range weights {
+		quo, rem := new(big.Int).QuoRem(
+			new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(weight)),
+			big.NewInt(total),
+			new(big.Int),
+		)
+		shares[idx] = currency.Amount(quo.Int64())
+		remainders[idx] = rem.Int64()
+		order[idx] = idx
+		leftover -= shares[idx]
+	}
+
+	// the leftover is always less than the number of weights since every remainder is less than the total
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for idx := 0; idx < int(leftover); idx++ {
+		shares[order[idx]]++
+	}
+
+	return shares
+}
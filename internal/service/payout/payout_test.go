@@ -0,0 +1,518 @@
+package payout
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPayoutImpl(t *testing.T) {
+	type args struct {
+		config         *config.Config
+		repoPayout     repository.Payout
+		repoInvestment repository.Investment
+		serviceWallet  service.Wallet
+		serviceLedger  service.Ledger
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Payout
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &PayoutImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPayoutImpl(tt.args.config, tt.args.repoPayout, tt.args.repoInvestment, tt.args.serviceWallet, tt.args.serviceLedger); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPayoutImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// assertInvestor checks that wallets are credited in the order of investor ID
+func assertInvestor(t *testing.T, investorID int64) func(context.Context, *entity.Payout, string) error {
+	return func(_ context.Context, payout *entity.Payout, _ string) error {
+		if payout.InvestorID != investorID {
+			t.Errorf("PayoutImpl.creditWallets() investor = %v, want %v", payout.InvestorID, investorID)
+		}
+
+		return nil
+	}
+}
+
+func TestPayoutImpl_Distribute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		config         *config.Config
+		repoPayout     repository.Payout
+		repoInvestment repository.Investment
+		serviceWallet  service.Wallet
+		serviceLedger  service.Ledger
+	}
+	type args struct {
+		ctx       context.Context
+		repayment *entity.Repayment
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		repayment: &entity.Repayment{
+			ID:        3,
+			LoanID:    4,
+			Amount:    currency.FromMajor(101000),
+			Principal: currency.FromMajor(100000),
+			Interest:  currency.FromMajor(1000),
+		},
+	}
+	defaultConfig := &config.Config{
+		Vendor: config.Vendor{
+			Fee: config.FeeConfig{
+				ServiceFee:     10,
+				WithholdingTax: 15,
+			},
+		},
+	}
+	mockInvestment := func() *repository.MockInvestment {
+		mock := repository.NewMockInvestment(ctrl)
+		mock.EXPECT().
+			Get(gomock.Any(), gomock.Any()).
+			Return(entity.InvestmentResult{
+				List: []*entity.Investment{
+					{
+						ID:         7,
+						Currency:   currency.IDR,
+						InvestorID: 3,
+						Amount:     currency.FromMajor(100000),
+					},
+					{
+						ID:         5,
+						Currency:   currency.IDR,
+						InvestorID: 1,
+						Amount:     currency.FromMajor(100000),
+					},
+					{
+						ID:         6,
+						Currency:   currency.IDR,
+						InvestorID: 2,
+						Amount:     currency.FromMajor(100000),
+					},
+				},
+			}, nil)
+
+		return mock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				config: defaultConfig,
+				repoPayout: func() *repository.MockPayout {
+					mock := repository.NewMockPayout(ctrl)
+					mock.EXPECT().
+						CreateBulk(gomock.Any(), []*entity.Payout{
+							{
+								RepaymentID:  3,
+								InvestmentID: 5,
+								InvestorID:   1,
+								LoanID:       4,
+								Principal:    currency.Amount(3333334),
+								Interest:     currency.Amount(33334),
+								Amount:       currency.Amount(3366668),
+								ServiceFee:   currency.Amount(3333),
+								Tax:          currency.Amount(5000),
+								NetAmount:    currency.Amount(3358335),
+							},
+							{
+								RepaymentID:  3,
+								InvestmentID: 6,
+								InvestorID:   2,
+								LoanID:       4,
+								Principal:    currency.Amount(3333333),
+								Interest:     currency.Amount(33333),
+								Amount:       currency.Amount(3366666),
+								ServiceFee:   currency.Amount(3333),
+								Tax:          currency.Amount(5000),
+								NetAmount:    currency.Amount(3358333),
+							},
+							{
+								RepaymentID:  3,
+								InvestmentID: 7,
+								InvestorID:   3,
+								LoanID:       4,
+								Principal:    currency.Amount(3333333),
+								Interest:     currency.Amount(33333),
+								Amount:       currency.Amount(3366666),
+								ServiceFee:   currency.Amount(3333),
+								Tax:          currency.Amount(5000),
+								NetAmount:    currency.Amount(3358333),
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: mockInvestment(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().
+							Payout(gomock.Any(), gomock.AssignableToTypeOf(&entity.Payout{}), currency.IDR).
+							DoAndReturn(assertInvestor(t, 1)),
+						mock.EXPECT().
+							Payout(gomock.Any(), gomock.AssignableToTypeOf(&entity.Payout{}), currency.IDR).
+							DoAndReturn(assertInvestor(t, 2)),
+						mock.EXPECT().
+							Payout(gomock.Any(), gomock.AssignableToTypeOf(&entity.Payout{}), currency.IDR).
+							DoAndReturn(assertInvestor(t, 3)),
+					)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), &entity.LedgerJournal{
+							Reference:   "repayment:3",
+							Currency:    currency.IDR,
+							LoanID:      4,
+							Description: "loan repayment",
+							Entries: []*entity.LedgerEntry{
+								{AccountCode: constant.LedgerAccountBank, Debit: currency.FromMajor(101000)},
+								{AccountCode: constant.LedgerAccountInvestorWallet, Credit: currency.Amount(10075001)},
+								{AccountCode: constant.LedgerAccountServiceFeeRevenue, Credit: currency.Amount(9999)},
+								{AccountCode: constant.LedgerAccountWithholdingTaxPayable, Credit: currency.FromMajor(150)},
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name:   "invalid repayment",
+			fields: fields{},
+			args: args{
+				ctx:       context.Background(),
+				repayment: &entity.Repayment{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get investment",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "no active investment",
+			fields: fields{
+				repoInvestment: func() *repository.MockInvestment {
+					mock := repository.NewMockInvestment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(entity.InvestmentResult{}, nil)
+
+					return mock
+				}(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "error on create",
+			fields: fields{
+				config: defaultConfig,
+				repoPayout: func() *repository.MockPayout {
+					mock := repository.NewMockPayout(ctrl)
+					mock.EXPECT().
+						CreateBulk(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInvestment: mockInvestment(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on crediting wallet",
+			fields: fields{
+				config: defaultConfig,
+				repoPayout: func() *repository.MockPayout {
+					mock := repository.NewMockPayout(ctrl)
+					mock.EXPECT().
+						CreateBulk(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: mockInvestment(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Payout(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on posting ledger",
+			fields: fields{
+				config: defaultConfig,
+				repoPayout: func() *repository.MockPayout {
+					mock := repository.NewMockPayout(ctrl)
+					mock.EXPECT().
+						CreateBulk(gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestment: mockInvestment(),
+				serviceWallet: func() *service.MockWallet {
+					mock := service.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Payout(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(3)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PayoutImpl{
+				config:         tt.fields.config,
+				repoPayout:     tt.fields.repoPayout,
+				repoInvestment: tt.fields.repoInvestment,
+				serviceWallet:  tt.fields.serviceWallet,
+				serviceLedger:  tt.fields.serviceLedger,
+			}
+			if err := p.Distribute(tt.args.ctx, tt.args.repayment); (err != nil) != tt.wantErr {
+				t.Errorf("PayoutImpl.Distribute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	type args struct {
+		repayment   *entity.Repayment
+		investments []*entity.Investment
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*entity.Payout
+	}{
+		{
+			name: "leftover goes to the earlier investment",
+			args: args{
+				repayment: &entity.Repayment{
+					ID:        1,
+					LoanID:    2,
+					Principal: currency.Amount(100),
+					Interest:  currency.Amount(1),
+				},
+				investments: []*entity.Investment{
+					{ID: 4, InvestorID: 2, Amount: currency.FromMajor(100)},
+					{ID: 3, InvestorID: 1, Amount: currency.FromMajor(100)},
+				},
+			},
+			want: []*entity.Payout{
+				{RepaymentID: 1, InvestmentID: 3, InvestorID: 1, LoanID: 2, Principal: 50, Interest: 1, Amount: 51, NetAmount: 51},
+				{RepaymentID: 1, InvestmentID: 4, InvestorID: 2, LoanID: 2, Principal: 50, Amount: 50, NetAmount: 50},
+			},
+		},
+		{
+			name: "tiny interest and penalty are never split into negative shares",
+			args: args{
+				repayment: &entity.Repayment{
+					ID:       1,
+					LoanID:   2,
+					Interest: currency.Amount(2),
+					Penalty:  currency.Amount(2),
+				},
+				investments: []*entity.Investment{
+					{ID: 1, InvestorID: 1, Amount: currency.FromMajor(100)},
+					{ID: 2, InvestorID: 2, Amount: currency.FromMajor(100)},
+					{ID: 3, InvestorID: 3, Amount: currency.FromMajor(100)},
+					{ID: 4, InvestorID: 4, Amount: currency.FromMajor(100)},
+				},
+			},
+			want: []*entity.Payout{
+				{RepaymentID: 1, InvestmentID: 1, InvestorID: 1, LoanID: 2, Interest: 1, Penalty: 1, Amount: 2, NetAmount: 2},
+				{RepaymentID: 1, InvestmentID: 2, InvestorID: 2, LoanID: 2, Interest: 1, Penalty: 1, Amount: 2, NetAmount: 2},
+				{RepaymentID: 1, InvestmentID: 3, InvestorID: 3, LoanID: 2},
+				{RepaymentID: 1, InvestmentID: 4, InvestorID: 4, LoanID: 2},
+			},
+		},
+		{
+			name: "largest remainder receives the leftover",
+			args: args{
+				repayment: &entity.Repayment{
+					ID:       1,
+					LoanID:   2,
+					Interest: currency.Amount(3),
+				},
+				investments: []*entity.Investment{
+					{ID: 1, InvestorID: 1, Amount: currency.FromMajor(100)},
+					{ID: 2, InvestorID: 2, Amount: currency.FromMajor(300)},
+					{ID: 3, InvestorID: 3, Amount: currency.FromMajor(600)},
+				},
+			},
+			want: []*entity.Payout{
+				{RepaymentID: 1, InvestmentID: 1, InvestorID: 1, LoanID: 2},
+				{RepaymentID: 1, InvestmentID: 2, InvestorID: 2, LoanID: 2, Interest: 1, Amount: 1, NetAmount: 1},
+				{RepaymentID: 1, InvestmentID: 3, InvestorID: 3, LoanID: 2, Interest: 2, Amount: 2, NetAmount: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := split(tt.args.repayment, tt.args.investments, config.FeeConfig{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayoutImpl_GetByInvestment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoPayout     repository.Payout
+		repoInvestment repository.Investment
+	}
+	type args struct {
+		ctx          context.Context
+		investmentID int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Payout
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoPayout: func() *repository.MockPayout {
+					mock := repository.NewMockPayout(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.PayoutFilter{
+							InvestmentID: 5,
+						}).
+						Return([]*entity.Payout{
+							{
+								ID:           1,
+								InvestmentID: 5,
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:          context.Background(),
+				investmentID: 5,
+			},
+			want: []*entity.Payout{
+				{
+					ID:           1,
+					InvestmentID: 5,
+				},
+			},
+		},
+		{
+			name:   "invalid investment ID",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repoPayout: func() *repository.MockPayout {
+					mock := repository.NewMockPayout(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:          context.Background(),
+				investmentID: 5,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PayoutImpl{
+				repoPayout:     tt.fields.repoPayout,
+				repoInvestment: tt.fields.repoInvestment,
+			}
+			got, err := p.GetByInvestment(tt.args.ctx, tt.args.investmentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PayoutImpl.GetByInvestment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PayoutImpl.GetByInvestment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package product
+
+import (
+	"context"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type LoanProductImpl struct {
+	repo repository.LoanProduct
+}
+
+func NewLoanProductImpl(
+	repo repository.LoanProduct,
+) service.LoanProduct {
+	return &LoanProductImpl{
+		repo: repo,
+	}
+}
+
+// Get will return loan product data based on filter
+func (p *LoanProductImpl) Get(
+	ctx context.Context,
+	filter *entity.LoanProductFilter,
+) ([]*entity.LoanProduct, error) {
+	return p.repo.Get(ctx, filter)
+}
+
+// GetDetail will return loan product data based on ID
+func (p *LoanProductImpl) GetDetail(
+	ctx context.Context,
+	id int64,
+) (*entity.LoanProduct, error) {
+	if id <= 0 {
+		return nil, errorwrapper.E("invalid loan product ID", errorwrapper.CodeInvalid)
+	}
+
+	return p.repo.GetDetail(ctx, id)
+}
+
+// Create will insert loan product data
+func (p *LoanProductImpl) Create(
+	ctx context.Context,
+	model *entity.LoanProduct,
+) error {
+	if !model.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+	if model.Currency == "" {
+		model.Currency = currency.IDR
+	}
+	model.Status = constant.GeneralStatusActive
+
+	return p.repo.Create(ctx, model)
+}
+
+// Update will update loan product data
+func (p *LoanProductImpl) Update(
+	ctx context.Context,
+	model *entity.LoanProduct,
+) error {
+	if !model.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+
+	existing, err := p.GetDetail(ctx, model.ID)
+	if err != nil {
+		return err
+	}
+	if model.Currency == "" {
+		model.Currency = existing.Currency
+	}
+	if model.Status <= 0 {
+		model.Status = existing.Status
+	}
+
+	return p.repo.Update(ctx, model)
+}
+
+// Delete will deactivate loan product so no new loan can be created under it
+// existing loans of the product are left untouched
+func (p *LoanProductImpl) Delete(
+	ctx context.Context,
+	id int64,
+) error {
+	existing, err := p.GetDetail(ctx, id)
+	if err != nil {
+		return err
+	}
+	existing.Status = constant.GeneralStatusInactive
+
+	return p.repo.Update(ctx, existing)
+}
@@ -0,0 +1,585 @@
+package product
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoanProductImpl(t *testing.T) {
+	type args struct {
+		repo repository.LoanProduct
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.LoanProduct
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &LoanProductImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLoanProductImpl(tt.args.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewLoanProductImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoanProductImpl_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.LoanProduct
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.LoanProductFilter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.LoanProduct
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.LoanProductFilter{
+							Status: constant.GeneralStatusActive,
+						}).
+						Return([]*entity.LoanProduct{
+							{
+								ID:           1,
+								Name:         "Personal Loan",
+								MinAmount:    currency.FromMajor(1000000),
+								MaxAmount:    currency.FromMajor(50000000),
+								MinRate:      8,
+								MaxRate:      15,
+								TenorOptions: []int{6, 12},
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.LoanProductFilter{
+					Status: constant.GeneralStatusActive,
+				},
+			},
+			want: []*entity.LoanProduct{
+				{
+					ID:           1,
+					Name:         "Personal Loan",
+					MinAmount:    currency.FromMajor(1000000),
+					MaxAmount:    currency.FromMajor(50000000),
+					MinRate:      8,
+					MaxRate:      15,
+					TenorOptions: []int{6, 12},
+				},
+			},
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.LoanProductFilter{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LoanProductImpl{
+				repo: tt.fields.repo,
+			}
+			got, err := p.Get(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoanProductImpl.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoanProductImpl.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoanProductImpl_GetDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.LoanProduct
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.LoanProduct
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.LoanProduct{
+							ID:           1,
+							Name:         "Personal Loan",
+							MinAmount:    currency.FromMajor(1000000),
+							MaxAmount:    currency.FromMajor(50000000),
+							MinRate:      8,
+							MaxRate:      15,
+							TenorOptions: []int{6, 12},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want: &entity.LoanProduct{
+				ID:           1,
+				Name:         "Personal Loan",
+				MinAmount:    currency.FromMajor(1000000),
+				MaxAmount:    currency.FromMajor(50000000),
+				MinRate:      8,
+				MaxRate:      15,
+				TenorOptions: []int{6, 12},
+			},
+		},
+		{
+			name:   "invalid id",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error on get detail",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LoanProductImpl{
+				repo: tt.fields.repo,
+			}
+			got, err := p.GetDetail(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoanProductImpl.GetDetail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoanProductImpl.GetDetail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoanProductImpl_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.LoanProduct
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.LoanProduct
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					want := &entity.LoanProduct{
+						ID:           1,
+						Name:         "Personal Loan",
+						MinAmount:    currency.FromMajor(1000000),
+						MaxAmount:    currency.FromMajor(50000000),
+						MinRate:      8,
+						MaxRate:      15,
+						TenorOptions: []int{6, 12},
+						Currency:     currency.IDR,
+						Status:       constant.GeneralStatusActive,
+					}
+
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.LoanProduct{
+					ID:           1,
+					Name:         "Personal Loan",
+					MinAmount:    currency.FromMajor(1000000),
+					MaxAmount:    currency.FromMajor(50000000),
+					MinRate:      8,
+					MaxRate:      15,
+					TenorOptions: []int{6, 12},
+				},
+			},
+		},
+		{
+			name:   "invalid param",
+			fields: fields{},
+			args: args{
+				ctx:   context.Background(),
+				model: &entity.LoanProduct{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on create",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.LoanProduct{
+					ID:           1,
+					Name:         "Personal Loan",
+					MinAmount:    currency.FromMajor(1000000),
+					MaxAmount:    currency.FromMajor(50000000),
+					MinRate:      8,
+					MaxRate:      15,
+					TenorOptions: []int{6, 12},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LoanProductImpl{
+				repo: tt.fields.repo,
+			}
+			if err := p.Create(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("LoanProductImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanProductImpl_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.LoanProduct
+	}
+	type args struct {
+		ctx   context.Context
+		model *entity.LoanProduct
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					existing := &entity.LoanProduct{
+						ID:           1,
+						Name:         "Personal Loan",
+						MinAmount:    currency.FromMajor(1000000),
+						MaxAmount:    currency.FromMajor(50000000),
+						MinRate:      8,
+						MaxRate:      15,
+						TenorOptions: []int{6, 12},
+						Status:       constant.GeneralStatusInactive,
+					}
+
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(existing, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), existing).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.LoanProduct{
+					ID:           1,
+					Name:         "Personal Loan",
+					MinAmount:    currency.FromMajor(1000000),
+					MaxAmount:    currency.FromMajor(50000000),
+					MinRate:      8,
+					MaxRate:      15,
+					TenorOptions: []int{6, 12},
+				},
+			},
+		},
+		{
+			name:   "invalid param",
+			fields: fields{},
+			args: args{
+				ctx:   context.Background(),
+				model: &entity.LoanProduct{ID: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get detail",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.LoanProduct{
+					ID:           1,
+					Name:         "Personal Loan",
+					MinAmount:    currency.FromMajor(1000000),
+					MaxAmount:    currency.FromMajor(50000000),
+					MinRate:      8,
+					MaxRate:      15,
+					TenorOptions: []int{6, 12},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.LoanProduct{
+							ID:           1,
+							Name:         "Personal Loan",
+							MinAmount:    currency.FromMajor(1000000),
+							MaxAmount:    currency.FromMajor(50000000),
+							MinRate:      8,
+							MaxRate:      15,
+							TenorOptions: []int{6, 12},
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				model: &entity.LoanProduct{
+					ID:           1,
+					Name:         "Personal Loan",
+					MinAmount:    currency.FromMajor(1000000),
+					MaxAmount:    currency.FromMajor(50000000),
+					MinRate:      8,
+					MaxRate:      15,
+					TenorOptions: []int{6, 12},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LoanProductImpl{
+				repo: tt.fields.repo,
+			}
+			if err := p.Update(tt.args.ctx, tt.args.model); (err != nil) != tt.wantErr {
+				t.Errorf("LoanProductImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanProductImpl_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repo repository.LoanProduct
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					existing := &entity.LoanProduct{
+						ID:           1,
+						Name:         "Personal Loan",
+						MinAmount:    currency.FromMajor(1000000),
+						MaxAmount:    currency.FromMajor(50000000),
+						MinRate:      8,
+						MaxRate:      15,
+						TenorOptions: []int{6, 12},
+						Status:       constant.GeneralStatusActive,
+					}
+					want := &entity.LoanProduct{
+						ID:           1,
+						Name:         "Personal Loan",
+						MinAmount:    currency.FromMajor(1000000),
+						MaxAmount:    currency.FromMajor(50000000),
+						MinRate:      8,
+						MaxRate:      15,
+						TenorOptions: []int{6, 12},
+						Status:       constant.GeneralStatusInactive,
+					}
+
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(existing, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), want).
+						Return(nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+		},
+		{
+			name:   "invalid id",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repo: func() *repository.MockLoanProduct {
+					mock := repository.NewMockLoanProduct(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), int64(1)).
+						Return(&entity.LoanProduct{
+							ID:           1,
+							Name:         "Personal Loan",
+							MinAmount:    currency.FromMajor(1000000),
+							MaxAmount:    currency.FromMajor(50000000),
+							MinRate:      8,
+							MaxRate:      15,
+							TenorOptions: []int{6, 12},
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &LoanProductImpl{
+				repo: tt.fields.repo,
+			}
+			if err := p.Delete(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("LoanProductImpl.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
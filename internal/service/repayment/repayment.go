@@ -0,0 +1,186 @@
+package repayment
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/ecintiawan/loan-service/pkg/lock"
+)
+
+type RepaymentImpl struct {
+	repoRepayment   repository.Repayment
+	repoInstallment repository.Installment
+	repoLoan        repository.Loan
+	serviceLoan     service.Loan
+	servicePayout   service.Payout
+	lock            lock.Lock
+	db              database.DB
+}
+
+func NewRepaymentImpl(
+	repoRepayment repository.Repayment,
+	repoInstallment repository.Installment,
+	repoLoan repository.Loan,
+	serviceLoan service.Loan,
+	servicePayout service.Payout,
+	lock lock.Lock,
+	db database.DB,
+) service.Repayment {
+	return &RepaymentImpl{
+		repoRepayment:   repoRepayment,
+		repoInstallment: repoInstallment,
+		repoLoan:        repoLoan,
+		serviceLoan:     serviceLoan,
+		servicePayout:   servicePayout,
+		lock:            lock,
+		db:              db,
+	}
+}
+
+// Repay will record a borrower payment and allocate it across outstanding installments
+// recording, payout distribution to investors and the transition to repaid state are done within a single transaction
+func (r *RepaymentImpl) Repay(
+	ctx context.Context,
+	req *entity.Repayment,
+) error {
+	if !req.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+	if req.PaidAt.IsZero() {
+		req.PaidAt = time.Now()
+	}
+
+	return r.db.WithTx(ctx, func(ctx context.Context) error {
+		// locking to prevent racing repayment case on the same loan data
+		lockKey := getLockKey(req.LoanID)
+		err := r.lock.Lock(ctx, lockKey)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			errUnlock := r.lock.Unlock(ctx, lockKey)
+			if errUnlock != nil {
+				log.Println("error releasing repayment lock", errUnlock)
+			}
+		}()
+
+		return r.record(ctx, req)
+	})
+}
+
+// record validates and stores the repayment, it must be called within a transaction
+func (r *RepaymentImpl) record(
+	ctx context.Context,
+	req *entity.Repayment,
+) error {
+	// validate loan status
+	// the loan row stays locked until the transaction ends
+	loan, err := r.repoLoan.GetDetailForUpdate(ctx, req.LoanID)
+	if err != nil {
+		return err
+	}
+	if loan.Status != constant.StatusDisbursed && loan.Status != constant.StatusDefaulted {
+		return errorwrapper.E("loan status must be disbursed or defaulted", errorwrapper.CodeInvalid)
+	}
+
+	// validate if the payment will exceed the outstanding balance
+	installments, err := r.repoInstallment.Get(ctx, &entity.InstallmentFilter{
+		LoanID: req.LoanID,
+		Status: constant.InstallmentStatusUnpaid,
+	})
+	if err != nil {
+		return err
+	}
+	outstanding := getOutstanding(installments)
+	if outstanding <= 0 {
+		return errorwrapper.E("loan doesn't have any outstanding balance", errorwrapper.CodeInvalid)
+	}
+	if req.Amount > outstanding {
+		return errorwrapper.E("repayment amount exceeds outstanding balance", errorwrapper.CodeInvalid)
+	}
+
+	err = r.repoRepayment.Create(ctx, req, allocate(req, installments))
+	if err != nil {
+		return err
+	}
+
+	// distribute the repayment to all investors of the loan
+	err = r.servicePayout.Distribute(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// trigger loan to proceed to repaid state
+	if req.Amount == outstanding {
+		loan.RepaidAt = req.PaidAt
+		return r.serviceLoan.Proceed(ctx, &entity.LoanProceed{
+			Action: constant.ActionRepay,
+			Data:   loan,
+		})
+	}
+
+	return nil
+}
+
+// getOutstanding sums up the unpaid penalty, interest and principal of installments
+func getOutstanding(installments []*entity.Installment) currency.Amount {
+	var outstanding currency.Amount
+	for _, installment := range installments {
+		outstanding += installment.PenaltyDue() + installment.InterestDue() + installment.PrincipalDue()
+	}
+
+	return outstanding
+}
+
+// allocate distributes the repayment amount across installments starting from the earliest due,
+// each installment is settled in penalty, interest then principal order before moving to the next one
+func allocate(req *entity.Repayment, installments []*entity.Installment) []*entity.Installment {
+	var (
+		allocated = []*entity.Installment{}
+		remaining = req.Amount
+	)
+
+	pay := func(due currency.Amount) currency.Amount {
+		paid := currency.Min(remaining, due)
+		remaining -= paid
+		return paid
+	}
+
+	for _, installment := range installments {
+		if remaining <= 0 {
+			break
+		}
+
+		penalty := pay(installment.PenaltyDue())
+		interest := pay(installment.InterestDue())
+		principal := pay(installment.PrincipalDue())
+
+		installment.PenaltyPaid += penalty
+		installment.InterestPaid += interest
+		installment.PrincipalPaid += principal
+		if installment.PenaltyDue() <= 0 && installment.InterestDue() <= 0 && installment.PrincipalDue() <= 0 {
+			installment.Status = constant.InstallmentStatusPaid
+			installment.PaidAt = req.PaidAt
+		}
+
+		req.Penalty += penalty
+		req.Interest += interest
+		req.Principal += principal
+		allocated = append(allocated, installment)
+	}
+
+	return allocated
+}
+
+func getLockKey(loanID int64) string {
+	return fmt.Sprintf("repayment:repay:%d", loanID)
+}
@@ -0,0 +1,452 @@
+package repayment
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/lock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRepaymentImpl(t *testing.T) {
+	type args struct {
+		repoRepayment   repository.Repayment
+		repoInstallment repository.Installment
+		repoLoan        repository.Loan
+		serviceLoan     service.Loan
+		servicePayout   service.Payout
+		lock            lock.Lock
+		db              database.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Repayment
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &RepaymentImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewRepaymentImpl(tt.args.repoRepayment, tt.args.repoInstallment, tt.args.repoLoan, tt.args.serviceLoan, tt.args.servicePayout, tt.args.lock, tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRepaymentImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepaymentImpl_Repay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoRepayment   repository.Repayment
+		repoInstallment repository.Installment
+		repoLoan        repository.Loan
+		serviceLoan     service.Loan
+		servicePayout   service.Payout
+		lock            lock.Lock
+		db              database.DB
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.Repayment
+	}
+	defaultDate := time.Date(2024, 8, 17, 13, 58, 0, 0, time.Local)
+	defaultArgs := func(amount currency.Amount) args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.Repayment{
+				LoanID: 4,
+				Amount: amount,
+				PaidAt: defaultDate,
+			},
+		}
+	}
+	mockLock := func() *lock.MockLock {
+		mock := lock.NewMockLock(ctrl)
+		mock.EXPECT().
+			Lock(gomock.Any(), getLockKey(4)).
+			Return(nil)
+		mock.EXPECT().
+			Unlock(gomock.Any(), getLockKey(4)).
+			Return(nil)
+
+		return mock
+	}
+	mockLoan := func(status constant.LoanStatus) *repository.MockLoan {
+		mock := repository.NewMockLoan(ctrl)
+		mock.EXPECT().
+			GetDetailForUpdate(gomock.Any(), int64(4)).
+			Return(&entity.Loan{
+				ID:     4,
+				Amount: currency.FromMajor(200000),
+				Status: status,
+			}, nil)
+
+		return mock
+	}
+	mockInstallment := func() *repository.MockInstallment {
+		mock := repository.NewMockInstallment(ctrl)
+		mock.EXPECT().
+			Get(gomock.Any(), &entity.InstallmentFilter{
+				LoanID: 4,
+				Status: constant.InstallmentStatusUnpaid,
+			}).
+			Return([]*entity.Installment{
+				{
+					ID:        1,
+					Sequence:  1,
+					Principal: currency.FromMajor(100000),
+					Interest:  currency.FromMajor(2000),
+					Penalty:   currency.FromMajor(500),
+					Status:    constant.InstallmentStatusUnpaid,
+				},
+				{
+					ID:        2,
+					Sequence:  2,
+					Principal: currency.FromMajor(100000),
+					Interest:  currency.FromMajor(1000),
+					Status:    constant.InstallmentStatusUnpaid,
+				},
+			}, nil)
+
+		return mock
+	}
+	mockDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	mockPayout := func(err error) *service.MockPayout {
+		mock := service.NewMockPayout(ctrl)
+		mock.EXPECT().
+			Distribute(gomock.Any(), gomock.Any()).
+			Return(err)
+
+		return mock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success partial repayment",
+			fields: fields{
+				repoRepayment: func() *repository.MockRepayment {
+					mock := repository.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), &entity.Repayment{
+							LoanID:    4,
+							Amount:    currency.FromMajor(110000),
+							Principal: currency.FromMajor(106500),
+							Interest:  currency.FromMajor(3000),
+							Penalty:   currency.FromMajor(500),
+							PaidAt:    defaultDate,
+						}, []*entity.Installment{
+							{
+								ID:            1,
+								Sequence:      1,
+								Principal:     currency.FromMajor(100000),
+								Interest:      currency.FromMajor(2000),
+								Penalty:       currency.FromMajor(500),
+								PrincipalPaid: currency.FromMajor(100000),
+								InterestPaid:  currency.FromMajor(2000),
+								PenaltyPaid:   currency.FromMajor(500),
+								Status:        constant.InstallmentStatusPaid,
+								PaidAt:        defaultDate,
+							},
+							{
+								ID:            2,
+								Sequence:      2,
+								Principal:     currency.FromMajor(100000),
+								Interest:      currency.FromMajor(1000),
+								InterestPaid:  currency.FromMajor(1000),
+								PrincipalPaid: currency.FromMajor(6500),
+								Status:        constant.InstallmentStatusUnpaid,
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInstallment: mockInstallment(),
+				repoLoan:        mockLoan(constant.StatusDisbursed),
+				servicePayout:   mockPayout(nil),
+				lock:            mockLock(),
+				db:              mockDB(),
+			},
+			args: defaultArgs(currency.FromMajor(110000)),
+		},
+		{
+			name: "error distributing repayment",
+			fields: fields{
+				repoRepayment: func() *repository.MockRepayment {
+					mock := repository.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInstallment: mockInstallment(),
+				repoLoan:        mockLoan(constant.StatusDisbursed),
+				servicePayout:   mockPayout(assert.AnError),
+				lock:            mockLock(),
+				db:              mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(110000)),
+			wantErr: true,
+		},
+		{
+			name: "success full repayment",
+			fields: fields{
+				repoRepayment: func() *repository.MockRepayment {
+					mock := repository.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInstallment: mockInstallment(),
+				repoLoan:        mockLoan(constant.StatusDisbursed),
+				serviceLoan: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Proceed(gomock.Any(), &entity.LoanProceed{
+							Action: constant.ActionRepay,
+							Data: &entity.Loan{
+								ID:       4,
+								Amount:   currency.FromMajor(200000),
+								Status:   constant.StatusDisbursed,
+								RepaidAt: defaultDate,
+							},
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+				servicePayout: mockPayout(nil),
+				lock:          mockLock(),
+				db:            mockDB(),
+			},
+			args: defaultArgs(currency.FromMajor(203500)),
+		},
+		{
+			name: "success repayment on defaulted loan",
+			fields: fields{
+				repoRepayment: func() *repository.MockRepayment {
+					mock := repository.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInstallment: mockInstallment(),
+				repoLoan:        mockLoan(constant.StatusDefaulted),
+				servicePayout:   mockPayout(nil),
+				lock:            mockLock(),
+				db:              mockDB(),
+			},
+			args: defaultArgs(currency.FromMajor(110000)),
+		},
+		{
+			name: "error acquiring lock",
+			fields: fields{
+				lock: func() *lock.MockLock {
+					mock := lock.NewMockLock(ctrl)
+					mock.EXPECT().
+						Lock(gomock.Any(), getLockKey(4)).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				db: mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(110000)),
+			wantErr: true,
+		},
+		{
+			name:   "invalid request",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.Repayment{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error get loan detail",
+			fields: fields{
+				repoLoan: func() *repository.MockLoan {
+					mock := repository.NewMockLoan(ctrl)
+					mock.EXPECT().
+						GetDetailForUpdate(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				lock: mockLock(),
+				db:   mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(110000)),
+			wantErr: true,
+		},
+		{
+			name: "invalid loan detail status",
+			fields: fields{
+				repoLoan: mockLoan(constant.StatusInvested),
+				lock:     mockLock(),
+				db:       mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(110000)),
+			wantErr: true,
+		},
+		{
+			name: "error get installment",
+			fields: fields{
+				repoInstallment: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				repoLoan: mockLoan(constant.StatusDisbursed),
+				lock:     mockLock(),
+				db:       mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(110000)),
+			wantErr: true,
+		},
+		{
+			name: "no outstanding balance",
+			fields: fields{
+				repoInstallment: func() *repository.MockInstallment {
+					mock := repository.NewMockInstallment(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Installment{}, nil)
+
+					return mock
+				}(),
+				repoLoan: mockLoan(constant.StatusDisbursed),
+				lock:     mockLock(),
+				db:       mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(110000)),
+			wantErr: true,
+		},
+		{
+			name: "repayment amount exceeded",
+			fields: fields{
+				repoInstallment: mockInstallment(),
+				repoLoan:        mockLoan(constant.StatusDisbursed),
+				lock:            mockLock(),
+				db:              mockDB(),
+			},
+			args:    defaultArgs(currency.Amount(20350001)),
+			wantErr: true,
+		},
+		{
+			name: "error create",
+			fields: fields{
+				repoRepayment: func() *repository.MockRepayment {
+					mock := repository.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInstallment: mockInstallment(),
+				repoLoan:        mockLoan(constant.StatusDisbursed),
+				lock:            mockLock(),
+				db:              mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(203500)),
+			wantErr: true,
+		},
+		{
+			name: "error proceeding loan to repaid",
+			fields: fields{
+				repoRepayment: func() *repository.MockRepayment {
+					mock := repository.NewMockRepayment(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInstallment: mockInstallment(),
+				repoLoan:        mockLoan(constant.StatusDisbursed),
+				serviceLoan: func() *service.MockLoan {
+					mock := service.NewMockLoan(ctrl)
+					mock.EXPECT().
+						Proceed(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				servicePayout: mockPayout(nil),
+				lock:          mockLock(),
+				db:            mockDB(),
+			},
+			args:    defaultArgs(currency.FromMajor(203500)),
+			wantErr: true,
+		},
+		{
+			name: "error on transaction",
+			fields: fields{
+				db: func() *database.MockDB {
+					mock := database.NewMockDB(ctrl)
+					mock.EXPECT().
+						WithTx(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(currency.FromMajor(110000)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RepaymentImpl{
+				repoRepayment:   tt.fields.repoRepayment,
+				repoInstallment: tt.fields.repoInstallment,
+				repoLoan:        tt.fields.repoLoan,
+				serviceLoan:     tt.fields.serviceLoan,
+				servicePayout:   tt.fields.servicePayout,
+				lock:            tt.fields.lock,
+				db:              tt.fields.db,
+			}
+			if err := r.Repay(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("RepaymentImpl.Repay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
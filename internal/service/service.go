@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/ecintiawan/loan-service/internal/constant"
 	"github.com/ecintiawan/loan-service/internal/entity"
 )
 
@@ -22,11 +24,52 @@ type Loan interface {
 		model *entity.Loan,
 	) error
 
+	// Simulate will calculate the quote of a hypothetical loan and investment without storing anything
+	Simulate(
+		ctx context.Context,
+		req *entity.LoanSimulation,
+	) (*entity.LoanQuote, error)
+
 	// Proceed is an action to go through all loan states for certain loan data
 	Proceed(
 		ctx context.Context,
 		req *entity.LoanProceed,
 	) error
+
+	// GetHistory will return status transition history of a loan
+	GetHistory(
+		ctx context.Context,
+		id int64,
+	) ([]*entity.LoanStateHistory, error)
+
+	// GetTransitions will return all allowed status transitions of loan
+	GetTransitions(
+		ctx context.Context,
+	) entity.LoanTransitionGraph
+
+	// GetAllowedActions will return all actions which can be proceeded on a loan
+	GetAllowedActions(
+		ctx context.Context,
+		id int64,
+	) ([]*entity.LoanAllowedAction, error)
+}
+
+// LoanStateMachine encapsulates loan state transition table related logics
+type LoanStateMachine interface {
+	// DetermineState will return the state of loan with the given status
+	DetermineState(
+		ctx context.Context,
+		status constant.LoanStatus,
+	) (LoanState, error)
+
+	// Transitions will return all allowed status transitions
+	Transitions() entity.LoanTransitionGraph
+
+	// AllowedActions will return all actions which can be proceeded on loan with the given status
+	AllowedActions(
+		ctx context.Context,
+		status constant.LoanStatus,
+	) ([]*entity.LoanAllowedAction, error)
 }
 
 // LoanState encapsulates loan state related logics
@@ -48,6 +91,36 @@ type LoanState interface {
 		ctx context.Context,
 		req *entity.LoanProceed,
 	) error
+
+	// Reject will proceed loan to rejected state
+	Reject(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Cancel will proceed loan to cancelled state
+	Cancel(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Repay will proceed loan to repaid state
+	Repay(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Default will proceed loan to defaulted state
+	Default(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Expire will proceed loan to expired state
+	Expire(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
 }
 
 // LoanAction encapsulates loan action related logics
@@ -69,6 +142,36 @@ type LoanAction interface {
 		ctx context.Context,
 		req *entity.LoanProceed,
 	) error
+
+	// Reject will proceed loan to rejected state
+	Reject(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Cancel will proceed loan to cancelled state
+	Cancel(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Repay will proceed loan to repaid state
+	Repay(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Default will proceed loan to defaulted state
+	Default(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
+
+	// Expire will proceed loan to expired state
+	Expire(
+		ctx context.Context,
+		req *entity.LoanProceed,
+	) error
 }
 
 // Investment encapsulates investment related logics
@@ -84,9 +187,281 @@ type Investment interface {
 		ctx context.Context,
 		req *entity.Investment,
 	) error
+
+	// Cancel will deactivate an investment while its loan is still open for funding
+	Cancel(
+		ctx context.Context,
+		id int64,
+	) error
+
+	// GetPortfolio will return the aggregated active investments of an investor
+	GetPortfolio(
+		ctx context.Context,
+		filter *entity.PortfolioFilter,
+	) (*entity.Portfolio, error)
+}
+
+// Wallet encapsulates investor wallet related logics
+type Wallet interface {
+	// Get will return wallets of an investor in every currency
+	Get(
+		ctx context.Context,
+		investorID int64,
+	) ([]*entity.Wallet, error)
+
+	// Deposit will add funds to the available balance of an investor
+	Deposit(
+		ctx context.Context,
+		req *entity.WalletDeposit,
+	) error
+
+	// Hold will move the investment amount from the available to the held balance of its investor
+	Hold(
+		ctx context.Context,
+		investment *entity.Investment,
+	) error
+
+	// Release will move the investment amount from the held back to the available balance of its investor
+	Release(
+		ctx context.Context,
+		investment *entity.Investment,
+	) error
+
+	// Commit will move the investment amount from the held to the committed balance of its investor
+	Commit(
+		ctx context.Context,
+		investment *entity.Investment,
+	) error
+
+	// Lock will lock the wallets of the investors in the order of investor ID until the transaction ends
+	Lock(
+		ctx context.Context,
+		investorIDs []int64,
+		code string,
+	) error
+
+	// Payout will return the principal of the payout out of the committed balance of its investor
+	// and credit its net amount to the available balance
+	Payout(
+		ctx context.Context,
+		payout *entity.Payout,
+		code string,
+	) error
+}
+
+// Ledger encapsulates double-entry ledger related logics
+type Ledger interface {
+	// Post will record a journal of a money movement, the journal must balance
+	Post(
+		ctx context.Context,
+		journal *entity.LedgerJournal,
+	) error
+
+	// GetTrialBalance will return debit and credit totals of every ledger account in a currency up to a point in time
+	GetTrialBalance(
+		ctx context.Context,
+		filter *entity.TrialBalanceFilter,
+	) (*entity.TrialBalance, error)
+}
+
+// Installment encapsulates loan repayment schedule related logics
+type Installment interface {
+	// Generate will build and store the full repayment schedule of a disbursed loan
+	Generate(
+		ctx context.Context,
+		loan *entity.Loan,
+	) error
+
+	// GetSchedule will return the repayment schedule of a loan
+	GetSchedule(
+		ctx context.Context,
+		loanID int64,
+	) ([]*entity.Installment, error)
+
+	// Calculate will build the repayment schedule of a loan without storing it
+	Calculate(
+		ctx context.Context,
+		loan *entity.Loan,
+	) ([]*entity.Installment, error)
+
+	// CalculateReturn will return the projected return of an investment over the tenor of its loan
+	CalculateReturn(
+		ctx context.Context,
+		investment *entity.Investment,
+		loan *entity.Loan,
+	) (*entity.InvestmentReturn, error)
+}
+
+// Repayment encapsulates borrower repayment related logics
+type Repayment interface {
+	// Repay will record a borrower payment and allocate it across outstanding installments
+	Repay(
+		ctx context.Context,
+		req *entity.Repayment,
+	) error
+}
+
+// Payout encapsulates investor payout related logics
+type Payout interface {
+	// Distribute will split a repayment across active investments of the loan
+	// proportional to their invested amount
+	Distribute(
+		ctx context.Context,
+		repayment *entity.Repayment,
+	) error
+
+	// GetByInvestment will return payout history of an investment
+	GetByInvestment(
+		ctx context.Context,
+		investmentID int64,
+	) ([]*entity.Payout, error)
+}
+
+// LoanProduct encapsulates loan product related logics
+type LoanProduct interface {
+	// Get will return loan product data based on filter
+	Get(
+		ctx context.Context,
+		filter *entity.LoanProductFilter,
+	) ([]*entity.LoanProduct, error)
+
+	// GetDetail will return loan product data based on ID
+	GetDetail(
+		ctx context.Context,
+		id int64,
+	) (*entity.LoanProduct, error)
+
+	// Create will insert loan product data
+	Create(
+		ctx context.Context,
+		model *entity.LoanProduct,
+	) error
+
+	// Update will update loan product data
+	Update(
+		ctx context.Context,
+		model *entity.LoanProduct,
+	) error
+
+	// Delete will deactivate loan product so no new loan can be created under it
+	Delete(
+		ctx context.Context,
+		id int64,
+	) error
+}
+
+// Borrower encapsulates borrower related logics
+type Borrower interface {
+	// Get will return borrower data based on filter
+	Get(
+		ctx context.Context,
+		filter *entity.BorrowerFilter,
+	) ([]*entity.Borrower, error)
+
+	// GetDetail will return borrower data based on ID
+	GetDetail(
+		ctx context.Context,
+		id int64,
+	) (*entity.Borrower, error)
+
+	// Create will register an active borrower with unique identification number
+	Create(
+		ctx context.Context,
+		model *entity.Borrower,
+	) error
+
+	// Update will update borrower data, status is left untouched
+	Update(
+		ctx context.Context,
+		model *entity.Borrower,
+	) error
+
+	// UpdateStatus will activate or deactivate borrower,
+	// inactive borrowers can't submit new loans
+	UpdateStatus(
+		ctx context.Context,
+		id int64,
+		status int,
+	) error
+}
+
+// Investor encapsulates investor related logics
+type Investor interface {
+	// Get will return investor data based on filter
+	Get(
+		ctx context.Context,
+		filter *entity.InvestorFilter,
+	) ([]*entity.Investor, error)
+
+	// GetDetail will return investor data based on ID
+	GetDetail(
+		ctx context.Context,
+		id int64,
+	) (*entity.Investor, error)
+
+	// Create will register an active investor with unique email and identification number
+	Create(
+		ctx context.Context,
+		model *entity.Investor,
+	) error
+
+	// Update will update investor data, status is left untouched
+	Update(
+		ctx context.Context,
+		model *entity.Investor,
+	) error
+
+	// UpdateStatus will activate or deactivate investor,
+	// inactive investors can't make new investments
+	UpdateStatus(
+		ctx context.Context,
+		id int64,
+		status int,
+	) error
+}
+
+// Delinquency encapsulates overdue loan related logics
+type Delinquency interface {
+	// Evaluate will scan repayable loans for overdue installments, accrue late penalty
+	// and default loans which have been overdue past the configured threshold
+	Evaluate(
+		ctx context.Context,
+		now time.Time,
+	) (entity.DelinquencyResult, error)
+}
+
+// Expiry encapsulates under-funded loan related logics
+type Expiry interface {
+	// Expire will scan approved loans which funding deadline has passed
+	// and proceed them to expired state
+	Expire(
+		ctx context.Context,
+		now time.Time,
+	) (entity.ExpiryResult, error)
+}
+
+// Outbox encapsulates notification outbox related logics
+type Outbox interface {
+	// Dispatch will deliver pending notifications which are due,
+	// failed deliveries are retried with exponential backoff until they are given up as dead
+	Dispatch(
+		ctx context.Context,
+		now time.Time,
+	) (entity.OutboxResult, error)
 }
 
 type Services struct {
 	Loan
 	Investment
+	Wallet
+	Ledger
+	Installment
+	Repayment
+	Payout
+	LoanProduct
+	Borrower
+	Investor
+	Delinquency
+	Expiry
+	Outbox
 }
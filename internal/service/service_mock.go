@@ -7,7 +7,9 @@ package service
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	constant "github.com/ecintiawan/loan-service/internal/constant"
 	entity "github.com/ecintiawan/loan-service/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -64,6 +66,50 @@ func (mr *MockLoanMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoan)(nil).Get), ctx, filter)
 }
 
+// GetAllowedActions mocks base method.
+func (m *MockLoan) GetAllowedActions(ctx context.Context, id int64) ([]*entity.LoanAllowedAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllowedActions", ctx, id)
+	ret0, _ := ret[0].([]*entity.LoanAllowedAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllowedActions indicates an expected call of GetAllowedActions.
+func (mr *MockLoanMockRecorder) GetAllowedActions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllowedActions", reflect.TypeOf((*MockLoan)(nil).GetAllowedActions), ctx, id)
+}
+
+// GetHistory mocks base method.
+func (m *MockLoan) GetHistory(ctx context.Context, id int64) ([]*entity.LoanStateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id)
+	ret0, _ := ret[0].([]*entity.LoanStateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockLoanMockRecorder) GetHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockLoan)(nil).GetHistory), ctx, id)
+}
+
+// GetTransitions mocks base method.
+func (m *MockLoan) GetTransitions(ctx context.Context) entity.LoanTransitionGraph {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions", ctx)
+	ret0, _ := ret[0].(entity.LoanTransitionGraph)
+	return ret0
+}
+
+// GetTransitions indicates an expected call of GetTransitions.
+func (mr *MockLoanMockRecorder) GetTransitions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockLoan)(nil).GetTransitions), ctx)
+}
+
 // Proceed mocks base method.
 func (m *MockLoan) Proceed(ctx context.Context, req *entity.LoanProceed) error {
 	m.ctrl.T.Helper()
@@ -78,6 +124,88 @@ func (mr *MockLoanMockRecorder) Proceed(ctx, req interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proceed", reflect.TypeOf((*MockLoan)(nil).Proceed), ctx, req)
 }
 
+// Simulate mocks base method.
+func (m *MockLoan) Simulate(ctx context.Context, req *entity.LoanSimulation) (*entity.LoanQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", ctx, req)
+	ret0, _ := ret[0].(*entity.LoanQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockLoanMockRecorder) Simulate(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockLoan)(nil).Simulate), ctx, req)
+}
+
+// MockLoanStateMachine is a mock of LoanStateMachine interface.
+type MockLoanStateMachine struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanStateMachineMockRecorder
+}
+
+// MockLoanStateMachineMockRecorder is the mock recorder for MockLoanStateMachine.
+type MockLoanStateMachineMockRecorder struct {
+	mock *MockLoanStateMachine
+}
+
+// NewMockLoanStateMachine creates a new mock instance.
+func NewMockLoanStateMachine(ctrl *gomock.Controller) *MockLoanStateMachine {
+	mock := &MockLoanStateMachine{ctrl: ctrl}
+	mock.recorder = &MockLoanStateMachineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanStateMachine) EXPECT() *MockLoanStateMachineMockRecorder {
+	return m.recorder
+}
+
+// AllowedActions mocks base method.
+func (m *MockLoanStateMachine) AllowedActions(ctx context.Context, status constant.LoanStatus) ([]*entity.LoanAllowedAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllowedActions", ctx, status)
+	ret0, _ := ret[0].([]*entity.LoanAllowedAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllowedActions indicates an expected call of AllowedActions.
+func (mr *MockLoanStateMachineMockRecorder) AllowedActions(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllowedActions", reflect.TypeOf((*MockLoanStateMachine)(nil).AllowedActions), ctx, status)
+}
+
+// DetermineState mocks base method.
+func (m *MockLoanStateMachine) DetermineState(ctx context.Context, status constant.LoanStatus) (LoanState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetermineState", ctx, status)
+	ret0, _ := ret[0].(LoanState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetermineState indicates an expected call of DetermineState.
+func (mr *MockLoanStateMachineMockRecorder) DetermineState(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetermineState", reflect.TypeOf((*MockLoanStateMachine)(nil).DetermineState), ctx, status)
+}
+
+// Transitions mocks base method.
+func (m *MockLoanStateMachine) Transitions() entity.LoanTransitionGraph {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transitions")
+	ret0, _ := ret[0].(entity.LoanTransitionGraph)
+	return ret0
+}
+
+// Transitions indicates an expected call of Transitions.
+func (mr *MockLoanStateMachineMockRecorder) Transitions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transitions", reflect.TypeOf((*MockLoanStateMachine)(nil).Transitions))
+}
+
 // MockLoanState is a mock of LoanState interface.
 type MockLoanState struct {
 	ctrl     *gomock.Controller
@@ -115,6 +243,34 @@ func (mr *MockLoanStateMockRecorder) Approve(ctx, req interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockLoanState)(nil).Approve), ctx, req)
 }
 
+// Cancel mocks base method.
+func (m *MockLoanState) Cancel(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockLoanStateMockRecorder) Cancel(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockLoanState)(nil).Cancel), ctx, req)
+}
+
+// Default mocks base method.
+func (m *MockLoanState) Default(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Default", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Default indicates an expected call of Default.
+func (mr *MockLoanStateMockRecorder) Default(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Default", reflect.TypeOf((*MockLoanState)(nil).Default), ctx, req)
+}
+
 // Disburse mocks base method.
 func (m *MockLoanState) Disburse(ctx context.Context, req *entity.LoanProceed) error {
 	m.ctrl.T.Helper()
@@ -129,6 +285,20 @@ func (mr *MockLoanStateMockRecorder) Disburse(ctx, req interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disburse", reflect.TypeOf((*MockLoanState)(nil).Disburse), ctx, req)
 }
 
+// Expire mocks base method.
+func (m *MockLoanState) Expire(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockLoanStateMockRecorder) Expire(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockLoanState)(nil).Expire), ctx, req)
+}
+
 // Invest mocks base method.
 func (m *MockLoanState) Invest(ctx context.Context, req *entity.LoanProceed) error {
 	m.ctrl.T.Helper()
@@ -143,6 +313,34 @@ func (mr *MockLoanStateMockRecorder) Invest(ctx, req interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invest", reflect.TypeOf((*MockLoanState)(nil).Invest), ctx, req)
 }
 
+// Reject mocks base method.
+func (m *MockLoanState) Reject(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockLoanStateMockRecorder) Reject(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockLoanState)(nil).Reject), ctx, req)
+}
+
+// Repay mocks base method.
+func (m *MockLoanState) Repay(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repay", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Repay indicates an expected call of Repay.
+func (mr *MockLoanStateMockRecorder) Repay(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repay", reflect.TypeOf((*MockLoanState)(nil).Repay), ctx, req)
+}
+
 // MockLoanAction is a mock of LoanAction interface.
 type MockLoanAction struct {
 	ctrl     *gomock.Controller
@@ -180,6 +378,34 @@ func (mr *MockLoanActionMockRecorder) Approve(ctx, req interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockLoanAction)(nil).Approve), ctx, req)
 }
 
+// Cancel mocks base method.
+func (m *MockLoanAction) Cancel(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockLoanActionMockRecorder) Cancel(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockLoanAction)(nil).Cancel), ctx, req)
+}
+
+// Default mocks base method.
+func (m *MockLoanAction) Default(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Default", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Default indicates an expected call of Default.
+func (mr *MockLoanActionMockRecorder) Default(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Default", reflect.TypeOf((*MockLoanAction)(nil).Default), ctx, req)
+}
+
 // Disburse mocks base method.
 func (m *MockLoanAction) Disburse(ctx context.Context, req *entity.LoanProceed) error {
 	m.ctrl.T.Helper()
@@ -194,6 +420,20 @@ func (mr *MockLoanActionMockRecorder) Disburse(ctx, req interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disburse", reflect.TypeOf((*MockLoanAction)(nil).Disburse), ctx, req)
 }
 
+// Expire mocks base method.
+func (m *MockLoanAction) Expire(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockLoanActionMockRecorder) Expire(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockLoanAction)(nil).Expire), ctx, req)
+}
+
 // Invest mocks base method.
 func (m *MockLoanAction) Invest(ctx context.Context, req *entity.LoanProceed) error {
 	m.ctrl.T.Helper()
@@ -208,6 +448,34 @@ func (mr *MockLoanActionMockRecorder) Invest(ctx, req interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invest", reflect.TypeOf((*MockLoanAction)(nil).Invest), ctx, req)
 }
 
+// Reject mocks base method.
+func (m *MockLoanAction) Reject(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockLoanActionMockRecorder) Reject(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockLoanAction)(nil).Reject), ctx, req)
+}
+
+// Repay mocks base method.
+func (m *MockLoanAction) Repay(ctx context.Context, req *entity.LoanProceed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repay", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Repay indicates an expected call of Repay.
+func (mr *MockLoanActionMockRecorder) Repay(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repay", reflect.TypeOf((*MockLoanAction)(nil).Repay), ctx, req)
+}
+
 // MockInvestment is a mock of Investment interface.
 type MockInvestment struct {
 	ctrl     *gomock.Controller
@@ -231,6 +499,20 @@ func (m *MockInvestment) EXPECT() *MockInvestmentMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockInvestment) Cancel(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockInvestmentMockRecorder) Cancel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockInvestment)(nil).Cancel), ctx, id)
+}
+
 // Get mocks base method.
 func (m *MockInvestment) Get(ctx context.Context, filter *entity.InvestmentFilter) (entity.InvestmentResult, error) {
 	m.ctrl.T.Helper()
@@ -246,6 +528,21 @@ func (mr *MockInvestmentMockRecorder) Get(ctx, filter interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInvestment)(nil).Get), ctx, filter)
 }
 
+// GetPortfolio mocks base method.
+func (m *MockInvestment) GetPortfolio(ctx context.Context, filter *entity.PortfolioFilter) (*entity.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, filter)
+	ret0, _ := ret[0].(*entity.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockInvestmentMockRecorder) GetPortfolio(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockInvestment)(nil).GetPortfolio), ctx, filter)
+}
+
 // Invest mocks base method.
 func (m *MockInvestment) Invest(ctx context.Context, req *entity.Investment) error {
 	m.ctrl.T.Helper()
@@ -259,3 +556,747 @@ func (mr *MockInvestmentMockRecorder) Invest(ctx, req interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invest", reflect.TypeOf((*MockInvestment)(nil).Invest), ctx, req)
 }
+
+// MockWallet is a mock of Wallet interface.
+type MockWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletMockRecorder
+}
+
+// MockWalletMockRecorder is the mock recorder for MockWallet.
+type MockWalletMockRecorder struct {
+	mock *MockWallet
+}
+
+// NewMockWallet creates a new mock instance.
+func NewMockWallet(ctrl *gomock.Controller) *MockWallet {
+	mock := &MockWallet{ctrl: ctrl}
+	mock.recorder = &MockWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWallet) EXPECT() *MockWalletMockRecorder {
+	return m.recorder
+}
+
+// Commit mocks base method.
+func (m *MockWallet) Commit(ctx context.Context, investment *entity.Investment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, investment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockWalletMockRecorder) Commit(ctx, investment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockWallet)(nil).Commit), ctx, investment)
+}
+
+// Deposit mocks base method.
+func (m *MockWallet) Deposit(ctx context.Context, req *entity.WalletDeposit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockWalletMockRecorder) Deposit(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockWallet)(nil).Deposit), ctx, req)
+}
+
+// Get mocks base method.
+func (m *MockWallet) Get(ctx context.Context, investorID int64) ([]*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, investorID)
+	ret0, _ := ret[0].([]*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWalletMockRecorder) Get(ctx, investorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWallet)(nil).Get), ctx, investorID)
+}
+
+// Hold mocks base method.
+func (m *MockWallet) Hold(ctx context.Context, investment *entity.Investment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", ctx, investment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockWalletMockRecorder) Hold(ctx, investment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockWallet)(nil).Hold), ctx, investment)
+}
+
+// Lock mocks base method.
+func (m *MockWallet) Lock(ctx context.Context, investorIDs []int64, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, investorIDs, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockWalletMockRecorder) Lock(ctx, investorIDs, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockWallet)(nil).Lock), ctx, investorIDs, code)
+}
+
+// Payout mocks base method.
+func (m *MockWallet) Payout(ctx context.Context, payout *entity.Payout, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Payout", ctx, payout, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Payout indicates an expected call of Payout.
+func (mr *MockWalletMockRecorder) Payout(ctx, payout, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Payout", reflect.TypeOf((*MockWallet)(nil).Payout), ctx, payout, code)
+}
+
+// Release mocks base method.
+func (m *MockWallet) Release(ctx context.Context, investment *entity.Investment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, investment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockWalletMockRecorder) Release(ctx, investment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockWallet)(nil).Release), ctx, investment)
+}
+
+// MockLedger is a mock of Ledger interface.
+type MockLedger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerMockRecorder
+}
+
+// MockLedgerMockRecorder is the mock recorder for MockLedger.
+type MockLedgerMockRecorder struct {
+	mock *MockLedger
+}
+
+// NewMockLedger creates a new mock instance.
+func NewMockLedger(ctrl *gomock.Controller) *MockLedger {
+	mock := &MockLedger{ctrl: ctrl}
+	mock.recorder = &MockLedgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedger) EXPECT() *MockLedgerMockRecorder {
+	return m.recorder
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockLedger) GetTrialBalance(ctx context.Context, filter *entity.TrialBalanceFilter) (*entity.TrialBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, filter)
+	ret0, _ := ret[0].(*entity.TrialBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockLedgerMockRecorder) GetTrialBalance(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockLedger)(nil).GetTrialBalance), ctx, filter)
+}
+
+// Post mocks base method.
+func (m *MockLedger) Post(ctx context.Context, journal *entity.LedgerJournal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, journal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockLedgerMockRecorder) Post(ctx, journal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockLedger)(nil).Post), ctx, journal)
+}
+
+// MockInstallment is a mock of Installment interface.
+type MockInstallment struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstallmentMockRecorder
+}
+
+// MockInstallmentMockRecorder is the mock recorder for MockInstallment.
+type MockInstallmentMockRecorder struct {
+	mock *MockInstallment
+}
+
+// NewMockInstallment creates a new mock instance.
+func NewMockInstallment(ctrl *gomock.Controller) *MockInstallment {
+	mock := &MockInstallment{ctrl: ctrl}
+	mock.recorder = &MockInstallmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstallment) EXPECT() *MockInstallmentMockRecorder {
+	return m.recorder
+}
+
+// Calculate mocks base method.
+func (m *MockInstallment) Calculate(ctx context.Context, loan *entity.Loan) ([]*entity.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", ctx, loan)
+	ret0, _ := ret[0].([]*entity.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calculate indicates an expected call of Calculate.
+func (mr *MockInstallmentMockRecorder) Calculate(ctx, loan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockInstallment)(nil).Calculate), ctx, loan)
+}
+
+// CalculateReturn mocks base method.
+func (m *MockInstallment) CalculateReturn(ctx context.Context, investment *entity.Investment, loan *entity.Loan) (*entity.InvestmentReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateReturn", ctx, investment, loan)
+	ret0, _ := ret[0].(*entity.InvestmentReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateReturn indicates an expected call of CalculateReturn.
+func (mr *MockInstallmentMockRecorder) CalculateReturn(ctx, investment, loan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateReturn", reflect.TypeOf((*MockInstallment)(nil).CalculateReturn), ctx, investment, loan)
+}
+
+// Generate mocks base method.
+func (m *MockInstallment) Generate(ctx context.Context, loan *entity.Loan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", ctx, loan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockInstallmentMockRecorder) Generate(ctx, loan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockInstallment)(nil).Generate), ctx, loan)
+}
+
+// GetSchedule mocks base method.
+func (m *MockInstallment) GetSchedule(ctx context.Context, loanID int64) ([]*entity.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, loanID)
+	ret0, _ := ret[0].([]*entity.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockInstallmentMockRecorder) GetSchedule(ctx, loanID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockInstallment)(nil).GetSchedule), ctx, loanID)
+}
+
+// MockRepayment is a mock of Repayment interface.
+type MockRepayment struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepaymentMockRecorder
+}
+
+// MockRepaymentMockRecorder is the mock recorder for MockRepayment.
+type MockRepaymentMockRecorder struct {
+	mock *MockRepayment
+}
+
+// NewMockRepayment creates a new mock instance.
+func NewMockRepayment(ctrl *gomock.Controller) *MockRepayment {
+	mock := &MockRepayment{ctrl: ctrl}
+	mock.recorder = &MockRepaymentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepayment) EXPECT() *MockRepaymentMockRecorder {
+	return m.recorder
+}
+
+// Repay mocks base method.
+func (m *MockRepayment) Repay(ctx context.Context, req *entity.Repayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repay", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Repay indicates an expected call of Repay.
+func (mr *MockRepaymentMockRecorder) Repay(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repay", reflect.TypeOf((*MockRepayment)(nil).Repay), ctx, req)
+}
+
+// MockPayout is a mock of Payout interface.
+type MockPayout struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayoutMockRecorder
+}
+
+// MockPayoutMockRecorder is the mock recorder for MockPayout.
+type MockPayoutMockRecorder struct {
+	mock *MockPayout
+}
+
+// NewMockPayout creates a new mock instance.
+func NewMockPayout(ctrl *gomock.Controller) *MockPayout {
+	mock := &MockPayout{ctrl: ctrl}
+	mock.recorder = &MockPayoutMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayout) EXPECT() *MockPayoutMockRecorder {
+	return m.recorder
+}
+
+// Distribute mocks base method.
+func (m *MockPayout) Distribute(ctx context.Context, repayment *entity.Repayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distribute", ctx, repayment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Distribute indicates an expected call of Distribute.
+func (mr *MockPayoutMockRecorder) Distribute(ctx, repayment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distribute", reflect.TypeOf((*MockPayout)(nil).Distribute), ctx, repayment)
+}
+
+// GetByInvestment mocks base method.
+func (m *MockPayout) GetByInvestment(ctx context.Context, investmentID int64) ([]*entity.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByInvestment", ctx, investmentID)
+	ret0, _ := ret[0].([]*entity.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByInvestment indicates an expected call of GetByInvestment.
+func (mr *MockPayoutMockRecorder) GetByInvestment(ctx, investmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByInvestment", reflect.TypeOf((*MockPayout)(nil).GetByInvestment), ctx, investmentID)
+}
+
+// MockLoanProduct is a mock of LoanProduct interface.
+type MockLoanProduct struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanProductMockRecorder
+}
+
+// MockLoanProductMockRecorder is the mock recorder for MockLoanProduct.
+type MockLoanProductMockRecorder struct {
+	mock *MockLoanProduct
+}
+
+// NewMockLoanProduct creates a new mock instance.
+func NewMockLoanProduct(ctrl *gomock.Controller) *MockLoanProduct {
+	mock := &MockLoanProduct{ctrl: ctrl}
+	mock.recorder = &MockLoanProductMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanProduct) EXPECT() *MockLoanProductMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLoanProduct) Create(ctx context.Context, model *entity.LoanProduct) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoanProductMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoanProduct)(nil).Create), ctx, model)
+}
+
+// Delete mocks base method.
+func (m *MockLoanProduct) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLoanProductMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLoanProduct)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockLoanProduct) Get(ctx context.Context, filter *entity.LoanProductFilter) ([]*entity.LoanProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.LoanProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoanProductMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoanProduct)(nil).Get), ctx, filter)
+}
+
+// GetDetail mocks base method.
+func (m *MockLoanProduct) GetDetail(ctx context.Context, id int64) (*entity.LoanProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetail", ctx, id)
+	ret0, _ := ret[0].(*entity.LoanProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetail indicates an expected call of GetDetail.
+func (mr *MockLoanProductMockRecorder) GetDetail(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockLoanProduct)(nil).GetDetail), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockLoanProduct) Update(ctx context.Context, model *entity.LoanProduct) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLoanProductMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLoanProduct)(nil).Update), ctx, model)
+}
+
+// MockBorrower is a mock of Borrower interface.
+type MockBorrower struct {
+	ctrl     *gomock.Controller
+	recorder *MockBorrowerMockRecorder
+}
+
+// MockBorrowerMockRecorder is the mock recorder for MockBorrower.
+type MockBorrowerMockRecorder struct {
+	mock *MockBorrower
+}
+
+// NewMockBorrower creates a new mock instance.
+func NewMockBorrower(ctrl *gomock.Controller) *MockBorrower {
+	mock := &MockBorrower{ctrl: ctrl}
+	mock.recorder = &MockBorrowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBorrower) EXPECT() *MockBorrowerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBorrower) Create(ctx context.Context, model *entity.Borrower) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBorrowerMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBorrower)(nil).Create), ctx, model)
+}
+
+// Get mocks base method.
+func (m *MockBorrower) Get(ctx context.Context, filter *entity.BorrowerFilter) ([]*entity.Borrower, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Borrower)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBorrowerMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBorrower)(nil).Get), ctx, filter)
+}
+
+// GetDetail mocks base method.
+func (m *MockBorrower) GetDetail(ctx context.Context, id int64) (*entity.Borrower, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetail", ctx, id)
+	ret0, _ := ret[0].(*entity.Borrower)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetail indicates an expected call of GetDetail.
+func (mr *MockBorrowerMockRecorder) GetDetail(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockBorrower)(nil).GetDetail), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockBorrower) Update(ctx context.Context, model *entity.Borrower) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBorrowerMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBorrower)(nil).Update), ctx, model)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockBorrower) UpdateStatus(ctx context.Context, id int64, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockBorrowerMockRecorder) UpdateStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockBorrower)(nil).UpdateStatus), ctx, id, status)
+}
+
+// MockInvestor is a mock of Investor interface.
+type MockInvestor struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvestorMockRecorder
+}
+
+// MockInvestorMockRecorder is the mock recorder for MockInvestor.
+type MockInvestorMockRecorder struct {
+	mock *MockInvestor
+}
+
+// NewMockInvestor creates a new mock instance.
+func NewMockInvestor(ctrl *gomock.Controller) *MockInvestor {
+	mock := &MockInvestor{ctrl: ctrl}
+	mock.recorder = &MockInvestorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvestor) EXPECT() *MockInvestorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockInvestor) Create(ctx context.Context, model *entity.Investor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvestorMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvestor)(nil).Create), ctx, model)
+}
+
+// Get mocks base method.
+func (m *MockInvestor) Get(ctx context.Context, filter *entity.InvestorFilter) ([]*entity.Investor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Investor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInvestorMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInvestor)(nil).Get), ctx, filter)
+}
+
+// GetDetail mocks base method.
+func (m *MockInvestor) GetDetail(ctx context.Context, id int64) (*entity.Investor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetail", ctx, id)
+	ret0, _ := ret[0].(*entity.Investor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetail indicates an expected call of GetDetail.
+func (mr *MockInvestorMockRecorder) GetDetail(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockInvestor)(nil).GetDetail), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockInvestor) Update(ctx context.Context, model *entity.Investor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockInvestorMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInvestor)(nil).Update), ctx, model)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockInvestor) UpdateStatus(ctx context.Context, id int64, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockInvestorMockRecorder) UpdateStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockInvestor)(nil).UpdateStatus), ctx, id, status)
+}
+
+// MockDelinquency is a mock of Delinquency interface.
+type MockDelinquency struct {
+	ctrl     *gomock.Controller
+	recorder *MockDelinquencyMockRecorder
+}
+
+// MockDelinquencyMockRecorder is the mock recorder for MockDelinquency.
+type MockDelinquencyMockRecorder struct {
+	mock *MockDelinquency
+}
+
+// NewMockDelinquency creates a new mock instance.
+func NewMockDelinquency(ctrl *gomock.Controller) *MockDelinquency {
+	mock := &MockDelinquency{ctrl: ctrl}
+	mock.recorder = &MockDelinquencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDelinquency) EXPECT() *MockDelinquencyMockRecorder {
+	return m.recorder
+}
+
+// Evaluate mocks base method.
+func (m *MockDelinquency) Evaluate(ctx context.Context, now time.Time) (entity.DelinquencyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, now)
+	ret0, _ := ret[0].(entity.DelinquencyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockDelinquencyMockRecorder) Evaluate(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockDelinquency)(nil).Evaluate), ctx, now)
+}
+
+// MockExpiry is a mock of Expiry interface.
+type MockExpiry struct {
+	ctrl     *gomock.Controller
+	recorder *MockExpiryMockRecorder
+}
+
+// MockExpiryMockRecorder is the mock recorder for MockExpiry.
+type MockExpiryMockRecorder struct {
+	mock *MockExpiry
+}
+
+// NewMockExpiry creates a new mock instance.
+func NewMockExpiry(ctrl *gomock.Controller) *MockExpiry {
+	mock := &MockExpiry{ctrl: ctrl}
+	mock.recorder = &MockExpiryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExpiry) EXPECT() *MockExpiryMockRecorder {
+	return m.recorder
+}
+
+// Expire mocks base method.
+func (m *MockExpiry) Expire(ctx context.Context, now time.Time) (entity.ExpiryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, now)
+	ret0, _ := ret[0].(entity.ExpiryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockExpiryMockRecorder) Expire(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockExpiry)(nil).Expire), ctx, now)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *MockOutbox) Dispatch(ctx context.Context, now time.Time) (entity.OutboxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx, now)
+	ret0, _ := ret[0].(entity.OutboxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockOutboxMockRecorder) Dispatch(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockOutbox)(nil).Dispatch), ctx, now)
+}
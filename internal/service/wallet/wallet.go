@@ -0,0 +1,256 @@
+package wallet
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+type WalletImpl struct {
+	repoWallet    repository.Wallet
+	repoInvestor  repository.Investor
+	serviceLedger service.Ledger
+	db            database.DB
+}
+
+func NewWalletImpl(
+	repoWallet repository.Wallet,
+	repoInvestor repository.Investor,
+	serviceLedger service.Ledger,
+	db database.DB,
+) service.Wallet {
+	return &WalletImpl{
+		repoWallet:    repoWallet,
+		repoInvestor:  repoInvestor,
+		serviceLedger: serviceLedger,
+		db:            db,
+	}
+}
+
+// Get will return wallets of an investor in every currency
+func (w *WalletImpl) Get(
+	ctx context.Context,
+	investorID int64,
+) ([]*entity.Wallet, error) {
+	if investorID <= 0 {
+		return nil, errorwrapper.E("invalid investor ID", errorwrapper.CodeInvalid)
+	}
+
+	return w.repoWallet.Get(ctx, &entity.WalletFilter{
+		InvestorID: investorID,
+	})
+}
+
+// Deposit will add funds to the available balance of a registered and active investor,
+// the wallet is opened on the first deposit in its currency
+func (w *WalletImpl) Deposit(
+	ctx context.Context,
+	req *entity.WalletDeposit,
+) error {
+	if !req.IsValid() {
+		return errorwrapper.E("invalid parameter values", errorwrapper.CodeInvalid)
+	}
+	if req.Currency == "" {
+		req.Currency = currency.IDR
+	}
+
+	err := w.validateInvestor(ctx, req.InvestorID)
+	if err != nil {
+		return err
+	}
+
+	return w.db.WithTx(ctx, func(ctx context.Context) error {
+		err := w.repoWallet.Create(ctx, &entity.Wallet{
+			InvestorID: req.InvestorID,
+			Currency:   req.Currency,
+		})
+		if err != nil {
+			return err
+		}
+
+		wallet, err := w.getForUpdate(ctx, req.InvestorID, req.Currency)
+		if err != nil {
+			return err
+		}
+		wallet.Deposit(req.Amount)
+
+		err = w.repoWallet.Update(ctx, wallet, &entity.WalletTransaction{
+			WalletID: wallet.ID,
+			Type:     constant.WalletTransactionDeposit,
+			Amount:   req.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		return w.serviceLedger.Post(ctx, entity.NewDepositJournal(req))
+	})
+}
+
+// Hold will move the investment amount from the available to the held balance of its investor,
+// so the same funds can't be placed on another investment
+func (w *WalletImpl) Hold(
+	ctx context.Context,
+	investment *entity.Investment,
+) error {
+	return w.move(ctx, investment, constant.WalletTransactionHold, (*entity.Wallet).Hold,
+		"insufficient available balance")
+}
+
+// Release will move the investment amount from the held back to the available balance of its investor
+func (w *WalletImpl) Release(
+	ctx context.Context,
+	investment *entity.Investment,
+) error {
+	return w.move(ctx, investment, constant.WalletTransactionRelease, (*entity.Wallet).Release,
+		"insufficient held balance")
+}
+
+// Commit will move the investment amount from the held to the committed balance of its investor
+func (w *WalletImpl) Commit(
+	ctx context.Context,
+	investment *entity.Investment,
+) error {
+	return w.move(ctx, investment, constant.WalletTransactionCommit, (*entity.Wallet).Commit,
+		"insufficient held balance")
+}
+
+// Lock will lock the wallets of the investors in the order of investor ID until the transaction ends,
+// every transaction moving the balance of more than one wallet takes their locks in this order
+// so transactions sharing investors can't lock each other's wallets in opposite orders
+func (w *WalletImpl) Lock(
+	ctx context.Context,
+	investorIDs []int64,
+	code string,
+) error {
+	sorted := make([]int64, len(investorIDs))
+	copy(sorted, investorIDs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return w.db.WithTx(ctx, func(ctx context.Context) error {
+		for idx, investorID := range sorted {
+			if idx > 0 && investorID == sorted[idx-1] {
+				continue
+			}
+
+			_, err := w.getForUpdate(ctx, investorID, code)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Payout will return the principal of the payout out of the committed balance of its investor
+// and credit its net amount to the available balance, both movements are recorded within the same transaction
+func (w *WalletImpl) Payout(
+	ctx context.Context,
+	payout *entity.Payout,
+	code string,
+) error {
+	return w.db.WithTx(ctx, func(ctx context.Context) error {
+		wallet, err := w.getForUpdate(ctx, payout.InvestorID, code)
+		if err != nil {
+			return err
+		}
+
+		returned := wallet.Return(payout.Principal)
+		err = w.repoWallet.Update(ctx, wallet, &entity.WalletTransaction{
+			WalletID: wallet.ID,
+			LoanID:   payout.LoanID,
+			Type:     constant.WalletTransactionReturn,
+			Amount:   returned,
+		})
+		if err != nil {
+			return err
+		}
+
+		wallet.Deposit(payout.NetAmount)
+		return w.repoWallet.Update(ctx, wallet, &entity.WalletTransaction{
+			WalletID: wallet.ID,
+			LoanID:   payout.LoanID,
+			Type:     constant.WalletTransactionPayout,
+			Amount:   payout.NetAmount,
+		})
+	})
+}
+
+// validateInvestor makes sure the deposit is made to a registered and active investor
+func (w *WalletImpl) validateInvestor(
+	ctx context.Context,
+	investorID int64,
+) error {
+	investor, err := w.repoInvestor.GetDetail(ctx, investorID)
+	if err != nil {
+		errx, ok := err.(*errorwrapper.Error)
+		if ok && errx.Code == errorwrapper.CodeNotFound {
+			return errorwrapper.E("investor_id refers to a non-existent investor", errorwrapper.CodeInvalid)
+		}
+		return err
+	}
+	if investor.Status != constant.GeneralStatusActive {
+		return errorwrapper.E("investor_id refers to an inactive investor", errorwrapper.CodeInvalid)
+	}
+
+	return nil
+}
+
+// move applies a balance movement of the investment on the locked wallet of its investor
+// and records it within the same transaction
+func (w *WalletImpl) move(
+	ctx context.Context,
+	investment *entity.Investment,
+	transactionType int,
+	apply func(*entity.Wallet, currency.Amount) bool,
+	insufficientMessage string,
+) error {
+	return w.db.WithTx(ctx, func(ctx context.Context) error {
+		wallet, err := w.getForUpdate(ctx, investment.InvestorID, investment.Currency)
+		if err != nil {
+			return err
+		}
+		if !apply(wallet, investment.Amount) {
+			return errorwrapper.E(insufficientMessage, errorwrapper.CodeInvalid)
+		}
+
+		return w.repoWallet.Update(ctx, wallet, &entity.WalletTransaction{
+			WalletID: wallet.ID,
+			LoanID:   investment.LoanID,
+			Type:     transactionType,
+			Amount:   investment.Amount,
+		})
+	})
+}
+
+// getForUpdate returns the wallet of the investor in the currency and locks its row
+// until the transaction carried by the context ends
+func (w *WalletImpl) getForUpdate(
+	ctx context.Context,
+	investorID int64,
+	code string,
+) (*entity.Wallet, error) {
+	list, err := w.repoWallet.Get(ctx, &entity.WalletFilter{
+		InvestorID: investorID,
+		Currency:   code,
+		ForUpdate:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) <= 0 {
+		return nil, errorwrapper.E("investor doesn't have any wallet in the currency", errorwrapper.CodeInvalid)
+	}
+
+	return list[0], nil
+}
@@ -0,0 +1,880 @@
+package wallet
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecintiawan/loan-service/internal/constant"
+	"github.com/ecintiawan/loan-service/internal/entity"
+	"github.com/ecintiawan/loan-service/internal/repository"
+	"github.com/ecintiawan/loan-service/internal/service"
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWalletImpl(t *testing.T) {
+	type args struct {
+		repoWallet    repository.Wallet
+		repoInvestor  repository.Investor
+		serviceLedger service.Ledger
+		db            database.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want service.Wallet
+	}{
+		{
+			name: "success",
+			args: args{},
+			want: &WalletImpl{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewWalletImpl(tt.args.repoWallet, tt.args.repoInvestor, tt.args.serviceLedger, tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewWalletImpl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalletImpl_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoWallet repository.Wallet
+	}
+	type args struct {
+		ctx        context.Context
+		investorID int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*entity.Wallet
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.WalletFilter{
+							InvestorID: 1,
+						}).
+						Return([]*entity.Wallet{
+							{
+								ID:         1,
+								InvestorID: 1,
+								Currency:   currency.IDR,
+								Available:  currency.FromMajor(500000),
+							},
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args: args{
+				ctx:        context.Background(),
+				investorID: 1,
+			},
+			want: []*entity.Wallet{
+				{
+					ID:         1,
+					InvestorID: 1,
+					Currency:   currency.IDR,
+					Available:  currency.FromMajor(500000),
+				},
+			},
+		},
+		{
+			name:   "invalid investor ID",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &WalletImpl{
+				repoWallet: tt.fields.repoWallet,
+			}
+			got, err := w.Get(tt.args.ctx, tt.args.investorID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WalletImpl.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WalletImpl.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalletImpl_Deposit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoWallet    repository.Wallet
+		repoInvestor  repository.Investor
+		serviceLedger service.Ledger
+		db            database.DB
+	}
+	type args struct {
+		ctx context.Context
+		req *entity.WalletDeposit
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	activeInvestor := func() *repository.MockInvestor {
+		mock := repository.NewMockInvestor(ctrl)
+		mock.EXPECT().
+			GetDetail(gomock.Any(), int64(1)).
+			Return(&entity.Investor{
+				ID:     1,
+				Status: constant.GeneralStatusActive,
+			}, nil)
+
+		return mock
+	}
+	lockedFilter := &entity.WalletFilter{
+		InvestorID: 1,
+		Currency:   currency.IDR,
+		ForUpdate:  true,
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			req: &entity.WalletDeposit{
+				InvestorID: 1,
+				Amount:     currency.FromMajor(1000000),
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), &entity.Wallet{
+							InvestorID: 1,
+							Currency:   currency.IDR,
+						}).
+						Return(nil)
+					mock.EXPECT().
+						Get(gomock.Any(), lockedFilter).
+						Return([]*entity.Wallet{
+							{
+								ID:         1,
+								InvestorID: 1,
+								Currency:   currency.IDR,
+								Available:  currency.FromMajor(500000),
+							},
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), &entity.Wallet{
+							ID:         1,
+							InvestorID: 1,
+							Currency:   currency.IDR,
+							Available:  currency.FromMajor(1500000),
+						}, &entity.WalletTransaction{
+							WalletID: 1,
+							Type:     constant.WalletTransactionDeposit,
+							Amount:   currency.FromMajor(1000000),
+						}).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), entity.NewDepositJournal(&entity.WalletDeposit{
+							InvestorID: 1,
+							Currency:   currency.IDR,
+							Amount:     currency.FromMajor(1000000),
+						})).
+						Return(nil)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				db:           newDB(),
+			},
+			args: defaultArgs(),
+		},
+		{
+			name:   "invalid request",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &entity.WalletDeposit{
+					InvestorID: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "investor not found",
+			fields: fields{
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(nil, errorwrapper.E("not found", errorwrapper.CodeNotFound))
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "inactive investor",
+			fields: fields{
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(&entity.Investor{
+							ID:     1,
+							Status: constant.GeneralStatusInactive,
+						}, nil)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on get investor",
+			fields: fields{
+				repoInvestor: func() *repository.MockInvestor {
+					mock := repository.NewMockInvestor(ctrl)
+					mock.EXPECT().
+						GetDetail(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on create",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{
+							{
+								ID: 1,
+							},
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+		{
+			name: "error on posting ledger",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{
+							{
+								ID: 1,
+							},
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+
+					return mock
+				}(),
+				serviceLedger: func() *service.MockLedger {
+					mock := service.NewMockLedger(ctrl)
+					mock.EXPECT().
+						Post(gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				repoInvestor: activeInvestor(),
+				db:           newDB(),
+			},
+			args:    defaultArgs(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &WalletImpl{
+				repoWallet:    tt.fields.repoWallet,
+				repoInvestor:  tt.fields.repoInvestor,
+				serviceLedger: tt.fields.serviceLedger,
+				db:            tt.fields.db,
+			}
+			if err := w.Deposit(tt.args.ctx, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("WalletImpl.Deposit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWalletImpl_Movement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoWallet repository.Wallet
+		db         database.DB
+	}
+	type args struct {
+		ctx        context.Context
+		investment *entity.Investment
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	newWallet := func(updated *entity.Wallet, transactionType int) *repository.MockWallet {
+		mock := repository.NewMockWallet(ctrl)
+		mock.EXPECT().
+			Get(gomock.Any(), &entity.WalletFilter{
+				InvestorID: 1,
+				Currency:   currency.IDR,
+				ForUpdate:  true,
+			}).
+			Return([]*entity.Wallet{
+				{
+					ID:        1,
+					Available: currency.FromMajor(500000),
+					Held:      currency.FromMajor(300000),
+				},
+			}, nil)
+		if updated != nil {
+			mock.EXPECT().
+				Update(gomock.Any(), updated, &entity.WalletTransaction{
+					WalletID: 1,
+					LoanID:   3,
+					Type:     transactionType,
+					Amount:   currency.FromMajor(200000),
+				}).
+				Return(nil)
+		}
+
+		return mock
+	}
+	defaultArgs := func() args {
+		return args{
+			ctx: context.Background(),
+			investment: &entity.Investment{
+				InvestorID: 1,
+				LoanID:     3,
+				Currency:   currency.IDR,
+				Amount:     currency.FromMajor(200000),
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		move    func(w *WalletImpl) func(context.Context, *entity.Investment) error
+		wantErr bool
+	}{
+		{
+			name: "hold",
+			fields: fields{
+				repoWallet: newWallet(&entity.Wallet{
+					ID:        1,
+					Available: currency.FromMajor(300000),
+					Held:      currency.FromMajor(500000),
+				}, constant.WalletTransactionHold),
+				db: newDB(),
+			},
+			args: defaultArgs(),
+			move: func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Hold },
+		},
+		{
+			name: "release",
+			fields: fields{
+				repoWallet: newWallet(&entity.Wallet{
+					ID:        1,
+					Available: currency.FromMajor(700000),
+					Held:      currency.FromMajor(100000),
+				}, constant.WalletTransactionRelease),
+				db: newDB(),
+			},
+			args: defaultArgs(),
+			move: func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Release },
+		},
+		{
+			name: "commit",
+			fields: fields{
+				repoWallet: newWallet(&entity.Wallet{
+					ID:        1,
+					Available: currency.FromMajor(500000),
+					Held:      currency.FromMajor(100000),
+					Committed: currency.FromMajor(200000),
+				}, constant.WalletTransactionCommit),
+				db: newDB(),
+			},
+			args: defaultArgs(),
+			move: func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Commit },
+		},
+		{
+			name: "insufficient available balance",
+			fields: fields{
+				repoWallet: newWallet(nil, 0),
+				db:         newDB(),
+			},
+			args: args{
+				ctx: context.Background(),
+				investment: &entity.Investment{
+					InvestorID: 1,
+					Currency:   currency.IDR,
+					Amount:     currency.FromMajor(600000),
+				},
+			},
+			move:    func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Hold },
+			wantErr: true,
+		},
+		{
+			name: "insufficient held balance",
+			fields: fields{
+				repoWallet: newWallet(nil, 0),
+				db:         newDB(),
+			},
+			args: args{
+				ctx: context.Background(),
+				investment: &entity.Investment{
+					InvestorID: 1,
+					Currency:   currency.IDR,
+					Amount:     currency.FromMajor(400000),
+				},
+			},
+			move:    func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Commit },
+			wantErr: true,
+		},
+		{
+			name: "wallet not found",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{}, nil)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs(),
+			move:    func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Hold },
+			wantErr: true,
+		},
+		{
+			name: "error on get",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return(nil, assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs(),
+			move:    func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Release },
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{
+							{
+								ID:   1,
+								Held: currency.FromMajor(200000),
+							},
+						}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs(),
+			move:    func(w *WalletImpl) func(context.Context, *entity.Investment) error { return w.Commit },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &WalletImpl{
+				repoWallet: tt.fields.repoWallet,
+				db:         tt.fields.db,
+			}
+			if err := tt.move(w)(tt.args.ctx, tt.args.investment); (err != nil) != tt.wantErr {
+				t.Errorf("WalletImpl.move() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWalletImpl_Lock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoWallet repository.Wallet
+		db         database.DB
+	}
+	type args struct {
+		ctx         context.Context
+		investorIDs []int64
+		code        string
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					gomock.InOrder(
+						mock.EXPECT().
+							Get(gomock.Any(), &entity.WalletFilter{
+								InvestorID: 1,
+								Currency:   currency.IDR,
+								ForUpdate:  true,
+							}).
+							Return([]*entity.Wallet{{ID: 1}}, nil),
+						mock.EXPECT().
+							Get(gomock.Any(), &entity.WalletFilter{
+								InvestorID: 2,
+								Currency:   currency.IDR,
+								ForUpdate:  true,
+							}).
+							Return([]*entity.Wallet{{ID: 2}}, nil),
+						mock.EXPECT().
+							Get(gomock.Any(), &entity.WalletFilter{
+								InvestorID: 3,
+								Currency:   currency.IDR,
+								ForUpdate:  true,
+							}).
+							Return([]*entity.Wallet{{ID: 3}}, nil),
+					)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args: args{
+				ctx:         context.Background(),
+				investorIDs: []int64{3, 1, 2, 1},
+				code:        currency.IDR,
+			},
+		},
+		{
+			name: "wallet not found",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{}, nil)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args: args{
+				ctx:         context.Background(),
+				investorIDs: []int64{1, 2},
+				code:        currency.IDR,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &WalletImpl{
+				repoWallet: tt.fields.repoWallet,
+				db:         tt.fields.db,
+			}
+			if err := w.Lock(tt.args.ctx, tt.args.investorIDs, tt.args.code); (err != nil) != tt.wantErr {
+				t.Errorf("WalletImpl.Lock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWalletImpl_Payout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		repoWallet repository.Wallet
+		db         database.DB
+	}
+	type args struct {
+		ctx    context.Context
+		payout *entity.Payout
+		code   string
+	}
+	newDB := func() *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			WithTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		return mock
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		payout: &entity.Payout{
+			InvestorID: 1,
+			LoanID:     3,
+			Principal:  currency.FromMajor(100000),
+			NetAmount:  currency.FromMajor(100900),
+		},
+		code: currency.IDR,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), &entity.WalletFilter{
+							InvestorID: 1,
+							Currency:   currency.IDR,
+							ForUpdate:  true,
+						}).
+						Return([]*entity.Wallet{
+							{
+								ID:        1,
+								Available: currency.FromMajor(500000),
+								Committed: currency.FromMajor(200000),
+							},
+						}, nil)
+					gomock.InOrder(
+						mock.EXPECT().
+							Update(gomock.Any(), gomock.Any(), &entity.WalletTransaction{
+								WalletID: 1,
+								LoanID:   3,
+								Type:     constant.WalletTransactionReturn,
+								Amount:   currency.FromMajor(100000),
+							}).
+							Return(nil),
+						mock.EXPECT().
+							Update(gomock.Any(), &entity.Wallet{
+								ID:        1,
+								Available: currency.FromMajor(600900),
+								Committed: currency.FromMajor(100000),
+							}, &entity.WalletTransaction{
+								WalletID: 1,
+								LoanID:   3,
+								Type:     constant.WalletTransactionPayout,
+								Amount:   currency.FromMajor(100900),
+							}).
+							Return(nil),
+					)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args: defaultArgs,
+		},
+		{
+			name: "wallet not found",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{}, nil)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on returning principal",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{{ID: 1}}, nil)
+					mock.EXPECT().
+						Update(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(assert.AnError)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+		{
+			name: "error on crediting payout",
+			fields: fields{
+				repoWallet: func() *repository.MockWallet {
+					mock := repository.NewMockWallet(ctrl)
+					mock.EXPECT().
+						Get(gomock.Any(), gomock.Any()).
+						Return([]*entity.Wallet{{ID: 1}}, nil)
+					gomock.InOrder(
+						mock.EXPECT().
+							Update(gomock.Any(), gomock.Any(), gomock.Any()).
+							Return(nil),
+						mock.EXPECT().
+							Update(gomock.Any(), gomock.Any(), gomock.Any()).
+							Return(assert.AnError),
+					)
+
+					return mock
+				}(),
+				db: newDB(),
+			},
+			args:    defaultArgs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &WalletImpl{
+				repoWallet: tt.fields.repoWallet,
+				db:         tt.fields.db,
+			}
+			if err := w.Payout(tt.args.ctx, tt.args.payout, tt.args.code); (err != nil) != tt.wantErr {
+				t.Errorf("WalletImpl.Payout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package config
 
 // Config holds necessary config to run the service.
 type Config struct {
-	App        App        `json:"app"`
-	Vendor     Vendor     `json:"vendor"`
-	Credential Credential `json:"credential"`
+	App          App          `json:"app"`
+	Vendor       Vendor       `json:"vendor"`
+	Worker       Worker       `json:"worker"`
+	StateMachine StateMachine `json:"state_machine"`
+	Credential   Credential   `json:"credential"`
 }
 
 type (
@@ -19,6 +21,22 @@ type (
 		Email                  EmailConfig       `json:"email"`
 		DefaultApprovalProof   DefaultFileConfig `json:"default_approval_proof"`
 		DefaultAgreementLetter DefaultFileConfig `json:"default_agreement_letter"`
+		Funding                FundingConfig     `json:"funding"`
+		Lock                   LockConfig        `json:"lock"`
+		Interest               InterestConfig    `json:"interest"`
+		Fee                    FeeConfig         `json:"fee"`
+	}
+
+	// Worker holds config value necessary to run scheduled jobs
+	Worker struct {
+		Delinquency DelinquencyConfig `json:"delinquency"`
+		Expiry      ExpiryConfig      `json:"expiry"`
+		Outbox      OutboxConfig      `json:"outbox"`
+	}
+
+	// StateMachine holds the declarative loan state transition table
+	StateMachine struct {
+		Transitions []TransitionConfig `json:"transitions"`
 	}
 
 	// Credential config
@@ -48,6 +66,73 @@ type (
 		SenderName string `json:"sender_name"`
 	}
 
+	// DelinquencyConfig holds all delinquency job configs
+	// penalty rate is the daily percentage accrued from the overdue installment amount
+	DelinquencyConfig struct {
+		Interval             string  `json:"interval"`
+		PenaltyRate          float64 `json:"penalty_rate"`
+		DefaultThresholdDays int     `json:"default_threshold_days"`
+	}
+
+	// FundingConfig holds all loan funding configs
+	// deadline days is the default duration an approved loan is open for investment, zero means no deadline,
+	// cancellation window is the cooling-off duration an investment can be cancelled within, empty means no limit
+	FundingConfig struct {
+		DeadlineDays       int    `json:"deadline_days"`
+		CancellationWindow string `json:"cancellation_window"`
+	}
+
+	// LockConfig holds all lock configs
+	// driver is either "local" for a process-local lock or "postgres" for a database advisory lock,
+	// timeout bounds how long a lock acquisition may wait
+	LockConfig struct {
+		Driver  string `json:"driver"`
+		Timeout string `json:"timeout"`
+	}
+
+	// InterestConfig holds all interest calculation configs
+	// method is one of "flat", "effective" or "annuity",
+	// day count is one of "30/360", "actual/365" or "actual/360"
+	InterestConfig struct {
+		Method   string `json:"method"`
+		DayCount string `json:"day_count"`
+	}
+
+	// FeeConfig holds all platform fee and tax configs in percent
+	// origination fee is deducted from the loan amount on disbursement,
+	// service fee and withholding tax are deducted from the interest received by investors
+	FeeConfig struct {
+		OriginationFee float64 `json:"origination_fee"`
+		ServiceFee     float64 `json:"service_fee"`
+		WithholdingTax float64 `json:"withholding_tax"`
+	}
+
+	// ExpiryConfig holds all expiry job configs
+	ExpiryConfig struct {
+		Interval string `json:"interval"`
+	}
+
+	// OutboxConfig holds all outbox dispatcher job configs
+	// a failed delivery is retried after backoff doubled on every attempt up to max backoff,
+	// and is given up as dead once it has been attempted max attempts times
+	OutboxConfig struct {
+		Interval    string `json:"interval"`
+		BatchSize   int    `json:"batch_size"`
+		MaxAttempts int    `json:"max_attempts"`
+		Backoff     string `json:"backoff"`
+		MaxBackoff  string `json:"max_backoff"`
+	}
+
+	// TransitionConfig holds a single allowed loan status transition
+	// guards and hooks refer to the names registered in the loan state package
+	TransitionConfig struct {
+		From   string   `json:"from"`
+		Action string   `json:"action"`
+		To     string   `json:"to"`
+		Guards []string `json:"guards"`
+		Hooks  []string `json:"hooks"`
+	}
+
 	// CredentialDB holds all database credential
 	CredentialDB struct {
 		URL string `json:"url"`
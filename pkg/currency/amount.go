@@ -0,0 +1,190 @@
+package currency
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// minorUnits is the number of minor units in a single major unit
+	minorUnits = 100
+	// minorDigits is the number of decimal places of minor units
+	minorDigits = 2
+	// rateScale is the precision of percentage rates, rates are taken with up to four decimal places
+	rateScale = 10000
+)
+
+// Amount is a monetary amount in minor units (1/100 of the major unit of its currency),
+// money arithmetic is done on integers so sums and comparisons are exact.
+// The currency itself is kept alongside the amount, only currencies of 2 minor digits are supported
+type Amount int64
+
+// FromMajor returns the amount of the given whole major units
+func FromMajor(major int64) Amount {
+	return Amount(major * minorUnits)
+}
+
+// FromFloat returns the amount nearest to the given value in major units,
+// it must only be used for results of inexact calculation such as annuity payment
+func FromFloat(major float64) Amount {
+	return Amount(math.Round(major * minorUnits))
+}
+
+// ParseAmount parses a decimal value in major units such as "1500000" or "1500000.50",
+// values with more decimal places than minor units are rejected instead of rounded
+func ParseAmount(value string) (Amount, error) {
+	value = strings.TrimSpace(value)
+
+	sign := int64(1)
+	unsigned := strings.TrimPrefix(value, "-")
+	if unsigned != value {
+		sign = -1
+	}
+
+	major, minor, _ := strings.Cut(unsigned, ".")
+	if len(minor) > minorDigits && strings.Trim(minor[minorDigits:], "0") == "" {
+		// trailing zeros of a wider NUMERIC scale don't lose any precision
+		minor = minor[:minorDigits]
+	}
+	if major == "" || len(minor) > minorDigits || strings.ContainsAny(major+minor, "+-") {
+		return 0, fmt.Errorf("invalid amount: %q", value)
+	}
+	minor += strings.Repeat("0", minorDigits-len(minor))
+
+	majorVal, err := strconv.ParseInt(major, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %q", value)
+	}
+	minorVal, err := strconv.ParseInt(minor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %q", value)
+	}
+	if majorVal > (math.MaxInt64-minorVal)/minorUnits {
+		return 0, fmt.Errorf("amount out of range: %q", value)
+	}
+
+	return Amount(sign * (majorVal*minorUnits + minorVal)), nil
+}
+
+// Min returns the smaller of the amounts
+func Min(a, b Amount) Amount {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// MulDiv returns the amount multiplied by num and divided by den,
+// rounded half away from zero to the nearest minor unit
+func (a Amount) MulDiv(num, den int64) Amount {
+	if den == 0 {
+		return 0
+	}
+
+	var (
+		product  = new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(num))
+		divisor  = big.NewInt(den)
+		quo, rem = new(big.Int).QuoRem(product, divisor, new(big.Int))
+	)
+
+	// round half away from zero by comparing twice the remainder against the divisor
+	if new(big.Int).Abs(new(big.Int).Mul(rem, big.NewInt(2))).Cmp(new(big.Int).Abs(divisor)) >= 0 {
+		if product.Sign()*divisor.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return Amount(quo.Int64())
+}
+
+// Percent returns rate percent of the amount, rounded half away from zero to the nearest minor unit
+func (a Amount) Percent(rate float64) Amount {
+	return a.PercentDiv(rate, 1)
+}
+
+// PercentDiv returns rate percent of the amount divided by den, such as a monthly share of an annual rate,
+// the result is rounded only once
+func (a Amount) PercentDiv(rate float64, den int64) Amount {
+	return a.MulDiv(int64(math.Round(rate*rateScale)), 100*rateScale*den)
+}
+
+// Float64 returns the amount in major units, it must only be used as an input of inexact calculation
+func (a Amount) Float64() float64 {
+	return float64(a) / minorUnits
+}
+
+// String returns the amount as a plain decimal value in major units
+func (a Amount) String() string {
+	var (
+		sign  = ""
+		major = int64(a) / minorUnits
+		minor = int64(a) % minorUnits
+	)
+	if a < 0 {
+		sign = "-"
+		major, minor = -major, -minor
+	}
+
+	return sign + strconv.FormatInt(major, 10) + "." + padMinor(minor)
+}
+
+// MarshalJSON encodes the amount as a decimal number of major units
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strings.TrimSuffix(strings.TrimRight(a.String(), "0"), ".")), nil
+}
+
+// UnmarshalJSON decodes a decimal number or string of major units
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" {
+		return nil
+	}
+
+	return a.UnmarshalParam(value)
+}
+
+// UnmarshalParam decodes a decimal value in major units of a form or query param
+func (a *Amount) UnmarshalParam(param string) error {
+	amount, err := ParseAmount(param)
+	if err != nil {
+		return err
+	}
+	*a = amount
+
+	return nil
+}
+
+// Scan reads a NUMERIC column holding major units
+func (a *Amount) Scan(src any) error {
+	switch val := src.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case string:
+		return a.UnmarshalParam(val)
+	case []byte:
+		return a.UnmarshalParam(string(val))
+	case int64:
+		*a = FromMajor(val)
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T into currency amount", src)
+}
+
+// Value writes the amount as a NUMERIC value in major units
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func padMinor(minor int64) string {
+	str := strconv.FormatInt(minor, 10)
+	return strings.Repeat("0", minorDigits-len(str)) + str
+}
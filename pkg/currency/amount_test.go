@@ -0,0 +1,382 @@
+package currency
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Amount
+		wantErr bool
+	}{
+		{
+			name:  "whole value",
+			value: "1500000",
+			want:  150000000,
+		},
+		{
+			name:  "with minor units",
+			value: "1500000.50",
+			want:  150000050,
+		},
+		{
+			name:  "with single decimal place",
+			value: "1500000.5",
+			want:  150000050,
+		},
+		{
+			name:  "with surrounding spaces",
+			value: " 12.34 ",
+			want:  1234,
+		},
+		{
+			name:  "negative value",
+			value: "-12.34",
+			want:  -1234,
+		},
+		{
+			name:  "negative value below one",
+			value: "-0.05",
+			want:  -5,
+		},
+		{
+			name:  "trailing zeros of a wider scale",
+			value: "12.3400",
+			want:  1234,
+		},
+		{
+			name:  "largest value",
+			value: "92233720368547758.07",
+			want:  math.MaxInt64,
+		},
+		{
+			name:    "more than 2 decimal places",
+			value:   "12.345",
+			wantErr: true,
+		},
+		{
+			name:    "overflow",
+			value:   "92233720368547758.08",
+			wantErr: true,
+		},
+		{
+			name:    "overflow on major units",
+			value:   "9223372036854775808",
+			wantErr: true,
+		},
+		{
+			name:    "empty value",
+			value:   "",
+			wantErr: true,
+		},
+		{
+			name:    "missing major units",
+			value:   ".5",
+			wantErr: true,
+		},
+		{
+			name:    "double sign",
+			value:   "--12",
+			wantErr: true,
+		},
+		{
+			name:    "plus sign",
+			value:   "+12",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			value:   "12a",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAmount_MulDiv(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		num    int64
+		den    int64
+		want   Amount
+	}{
+		{
+			name:   "exact",
+			amount: 1000,
+			num:    1,
+			den:    4,
+			want:   250,
+		},
+		{
+			name:   "rounded down below half",
+			amount: 1000,
+			num:    1,
+			den:    3,
+			want:   333,
+		},
+		{
+			name:   "rounded up from half",
+			amount: 5,
+			num:    1,
+			den:    2,
+			want:   3,
+		},
+		{
+			name:   "negative amount rounded away from zero",
+			amount: -5,
+			num:    1,
+			den:    2,
+			want:   -3,
+		},
+		{
+			name:   "negative divisor rounded away from zero",
+			amount: 5,
+			num:    1,
+			den:    -2,
+			want:   -3,
+		},
+		{
+			name:   "negative numerator and divisor",
+			amount: 5,
+			num:    -1,
+			den:    -2,
+			want:   3,
+		},
+		{
+			name:   "intermediate product beyond int64",
+			amount: math.MaxInt64,
+			num:    3,
+			den:    4,
+			want:   6917529027641081855,
+		},
+		{
+			name:   "zero divisor",
+			amount: 1000,
+			num:    1,
+			den:    0,
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.amount.MulDiv(tt.num, tt.den))
+		})
+	}
+}
+
+func TestAmount_PercentDiv(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		rate   float64
+		den    int64
+		want   Amount
+	}{
+		{
+			name:   "monthly share of an annual rate",
+			amount: FromMajor(1000000),
+			rate:   12,
+			den:    12,
+			want:   FromMajor(10000),
+		},
+		{
+			name:   "rate with four decimal places",
+			amount: FromMajor(1000000),
+			rate:   1.2345,
+			den:    1,
+			want:   FromMajor(12345),
+		},
+		{
+			name:   "rounded half away from zero",
+			amount: 50,
+			rate:   1,
+			den:    1,
+			want:   1,
+		},
+		{
+			name:   "negative amount rounded half away from zero",
+			amount: -50,
+			rate:   1,
+			den:    1,
+			want:   -1,
+		},
+		{
+			name:   "rounded only once",
+			amount: 25,
+			rate:   10,
+			den:    2,
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.amount.PercentDiv(tt.rate, tt.den))
+		})
+	}
+}
+
+func TestAmount_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		want   string
+	}{
+		{
+			name:   "whole value",
+			amount: FromMajor(1500000),
+			want:   "1500000",
+		},
+		{
+			name:   "with minor units",
+			amount: 150000050,
+			want:   "1500000.5",
+		},
+		{
+			name:   "negative value",
+			amount: -5,
+			want:   "-0.05",
+		},
+		{
+			name:   "zero",
+			amount: 0,
+			want:   "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.amount)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestAmount_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Amount
+		wantErr bool
+	}{
+		{
+			name: "number",
+			data: "1500000.5",
+			want: 150000050,
+		},
+		{
+			name: "string",
+			data: `"1500000.50"`,
+			want: 150000050,
+		},
+		{
+			name: "negative number",
+			data: "-12.34",
+			want: -1234,
+		},
+		{
+			name: "null",
+			data: "null",
+			want: 0,
+		},
+		{
+			name:    "more than 2 decimal places",
+			data:    "12.345",
+			wantErr: true,
+		},
+		{
+			name:    "overflow",
+			data:    "92233720368547758.08",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			data:    `"abc"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Amount
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Amount.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAmount_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    Amount
+		wantErr bool
+	}{
+		{
+			name: "nil",
+			src:  nil,
+			want: 0,
+		},
+		{
+			name: "string",
+			src:  "1500000.50",
+			want: 150000050,
+		},
+		{
+			name: "bytes",
+			src:  []byte("-12.34"),
+			want: -1234,
+		},
+		{
+			name: "integer",
+			src:  int64(1500000),
+			want: FromMajor(1500000),
+		},
+		{
+			name:    "more than 2 decimal places",
+			src:     "12.345",
+			wantErr: true,
+		},
+		{
+			name:    "overflow",
+			src:     "92233720368547758.08",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported type",
+			src:     float64(12.34),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Amount(1)
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Amount.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package currency
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// supported currency codes following ISO 4217,
+// all of them have 2 minor digits so their amounts share the same Amount precision
+const (
+	IDR = "IDR"
+	USD = "USD"
+	SGD = "SGD"
+	MYR = "MYR"
+	EUR = "EUR"
+)
+
+// Formatter describes how amounts of a currency are written in its locale
+type Formatter struct {
+	// MinorDigits is the number of decimal places of the currency following ISO 4217
+	MinorDigits int
+	// Symbol is written before the amount
+	Symbol string
+	// Thousands separates every 3 digits of the major units
+	Thousands string
+	// Decimal separates the major and minor units
+	Decimal string
+	// ShowMinor always writes the minor units,
+	// otherwise they are only written when the amount has any
+	ShowMinor bool
+}
+
+var (
+	formattersLock sync.RWMutex
+	formatters     = map[string]Formatter{
+		IDR: {MinorDigits: minorDigits, Symbol: "Rp", Thousands: ".", Decimal: ","},
+		USD: {MinorDigits: minorDigits, Symbol: "$", Thousands: ",", Decimal: ".", ShowMinor: true},
+		SGD: {MinorDigits: minorDigits, Symbol: "S$", Thousands: ",", Decimal: ".", ShowMinor: true},
+		MYR: {MinorDigits: minorDigits, Symbol: "RM", Thousands: ",", Decimal: ".", ShowMinor: true},
+		EUR: {MinorDigits: minorDigits, Symbol: "€", Thousands: ".", Decimal: ",", ShowMinor: true},
+	}
+)
+
+// Register adds or replaces the formatter of a currency code,
+// currencies whose minor digits differ from the precision of Amount are rejected
+func Register(code string, formatter Formatter) error {
+	if formatter.MinorDigits != minorDigits {
+		return fmt.Errorf("unsupported currency %s: %d minor digits, only %d are supported", code, formatter.MinorDigits, minorDigits)
+	}
+
+	formattersLock.Lock()
+	defer formattersLock.Unlock()
+
+	formatters[code] = formatter
+	return nil
+}
+
+// IsSupported returns whether the currency code has a registered formatter
+func IsSupported(code string) bool {
+	formattersLock.RLock()
+	defer formattersLock.RUnlock()
+
+	_, found := formatters[code]
+	return found
+}
+
+// Format formats amount in the locale of the currency code,
+// unknown codes are written with the code itself and plain separators
+func Format(amount Amount, code string) string {
+	formattersLock.RLock()
+	formatter, found := formatters[code]
+	formattersLock.RUnlock()
+	if !found {
+		formatter = Formatter{MinorDigits: minorDigits, Symbol: code + " ", Thousands: ",", Decimal: ".", ShowMinor: true}
+	}
+
+	return formatter.Format(amount)
+}
+
+// Format formats amount with the separators and symbol of the formatter
+func (f Formatter) Format(amount Amount) string {
+	var (
+		sign  = ""
+		major = int64(amount) / minorUnits
+		minor = int64(amount) % minorUnits
+	)
+	if amount < 0 {
+		sign = "-"
+		major, minor = -major, -minor
+	}
+
+	// Insert separators every 3 digits from the end
+	amountStr := strconv.FormatInt(major, 10)
+	n := len(amountStr)
+	result := strings.Builder{}
+	for i, c := range amountStr {
+		if i > 0 && (n-i)%3 == 0 {
+			result.WriteString(f.Thousands)
+		}
+		result.WriteRune(c)
+	}
+	if minor > 0 || f.ShowMinor {
+		result.WriteString(f.Decimal + padMinor(minor))
+	}
+
+	return sign + f.Symbol + result.String()
+}
@@ -0,0 +1,120 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		code   string
+		want   string
+	}{
+		{
+			name:   "IDR whole value",
+			amount: FromMajor(1500000),
+			code:   IDR,
+			want:   "Rp1.500.000",
+		},
+		{
+			name:   "IDR with minor units",
+			amount: 150000050,
+			code:   IDR,
+			want:   "Rp1.500.000,50",
+		},
+		{
+			name:   "IDR negative value",
+			amount: FromMajor(-1500),
+			code:   IDR,
+			want:   "-Rp1.500",
+		},
+		{
+			name:   "USD",
+			amount: FromMajor(1500000),
+			code:   USD,
+			want:   "$1,500,000.00",
+		},
+		{
+			name:   "SGD",
+			amount: 123456,
+			code:   SGD,
+			want:   "S$1,234.56",
+		},
+		{
+			name:   "MYR",
+			amount: 5,
+			code:   MYR,
+			want:   "RM0.05",
+		},
+		{
+			name:   "EUR",
+			amount: 123456789,
+			code:   EUR,
+			want:   "€1.234.567,89",
+		},
+		{
+			name:   "unknown code",
+			amount: FromMajor(1500),
+			code:   "XTS",
+			want:   "XTS 1,500.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.amount, tt.code))
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		formatter Formatter
+		amount    Amount
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			code:      "XTS",
+			formatter: Formatter{MinorDigits: 2, Symbol: "T", Thousands: " ", Decimal: ","},
+			amount:    123456789,
+			want:      "T1 234 567,89",
+		},
+		{
+			name:      "currency without minor units",
+			code:      "JPY",
+			formatter: Formatter{MinorDigits: 0, Symbol: "¥", Thousands: ","},
+			wantErr:   true,
+		},
+		{
+			name:      "currency of 3 minor digits",
+			code:      "KWD",
+			formatter: Formatter{MinorDigits: 3, Symbol: "KD", Thousands: ",", Decimal: "."},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				formattersLock.Lock()
+				delete(formatters, tt.code)
+				formattersLock.Unlock()
+			}()
+
+			err := Register(tt.code, tt.formatter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, !tt.wantErr, IsSupported(tt.code))
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, Format(tt.amount, tt.code))
+			}
+		})
+	}
+}
@@ -10,6 +10,25 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+type (
+	// querier is implemented by both the connection pool and a running transaction
+	querier interface {
+		Begin(ctx context.Context) (pgx.Tx, error)
+		Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+		Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+		QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	}
+
+	// txKey is the context key of the running transaction
+	txKey struct{}
+
+	// txState is the running transaction along with the callbacks waiting for its commit
+	txState struct {
+		tx          pgx.Tx
+		afterCommit []func(ctx context.Context)
+	}
+)
+
 func NewDB(cfg *config.Config) DB {
 	log.Println(cfg.Credential.DB.URL)
 	client, err := pgxpool.New(context.Background(), cfg.Credential.DB.URL)
@@ -22,18 +41,80 @@ func NewDB(cfg *config.Config) DB {
 	}
 }
 
+// Begin starts a new transaction, or a savepoint when the context already carries a transaction
 func (d *dbImpl) Begin(ctx context.Context) (pgx.Tx, error) {
-	return d.client.Begin(ctx)
+	return d.querier(ctx).Begin(ctx)
 }
 
 func (d *dbImpl) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
-	return d.client.Exec(ctx, sql, arguments...)
+	return d.querier(ctx).Exec(ctx, sql, arguments...)
 }
 
 func (d *dbImpl) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
-	return d.client.Query(ctx, sql, args...)
+	return d.querier(ctx).Query(ctx, sql, args...)
 }
 
 func (d *dbImpl) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
-	return d.client.QueryRow(ctx, sql, args...)
+	return d.querier(ctx).QueryRow(ctx, sql, args...)
+}
+
+// WithTx runs fn within a single transaction carried by the context given to fn,
+// so every query made through the context joins the same transaction.
+// The transaction is committed when fn succeeds and rolled back otherwise,
+// fn joins the outer transaction when the context already carries one.
+func (d *dbImpl) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if d.InTx(ctx) {
+		return fn(ctx)
+	}
+
+	tx, err := d.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	state := &txState{tx: tx}
+	err = fn(context.WithValue(ctx, txKey{}, state))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, callback := range state.afterCommit {
+		callback(ctx)
+	}
+
+	return nil
+}
+
+// InTx reports whether the context carries a running transaction
+func (d *dbImpl) InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txState)
+	return ok
+}
+
+// AfterCommit runs fn once the transaction carried by the context has been committed,
+// fn is dropped when the transaction is rolled back and runs right away when the context carries no transaction.
+// fn is given the context the transaction was started with, so it doesn't query through the finished transaction
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn(ctx)
+		return
+	}
+
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// querier returns the running transaction of the context, or the connection pool if there is none
+func (d *dbImpl) querier(ctx context.Context) querier {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+
+	return d.client
 }
@@ -11,8 +11,10 @@ import (
 
 // MockPgxTx represents a mock implementation of pgx.Tx
 type MockPgxTx struct {
-	ExecFunc   func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
-	CommitFunc func(ctx context.Context) error
+	ExecFunc     func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	QueryFunc    func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRowFunc func(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	CommitFunc   func(ctx context.Context) error
 }
 
 func (m *MockPgxTx) Begin(ctx context.Context) (pgx.Tx, error) {
@@ -53,9 +55,15 @@ func (m *MockPgxTx) Prepare(
 	return nil, nil
 }
 func (m *MockPgxTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if m.QueryFunc != nil {
+		return m.QueryFunc(ctx, sql, args...)
+	}
 	return nil, nil
 }
 func (m *MockPgxTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if m.QueryRowFunc != nil {
+		return m.QueryRowFunc(ctx, sql, args...)
+	}
 	return nil
 }
 func (m *MockPgxTx) Conn() *pgx.Conn {
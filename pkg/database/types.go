@@ -14,6 +14,8 @@ type (
 		Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
 		Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 		QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+		WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+		InTx(ctx context.Context) bool
 	}
 
 	dbImpl struct {
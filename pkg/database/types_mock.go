@@ -71,6 +71,20 @@ func (mr *MockDBMockRecorder) Exec(ctx, sql interface{}, arguments ...interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockDB)(nil).Exec), varargs...)
 }
 
+// InTx mocks base method.
+func (m *MockDB) InTx(ctx context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockDBMockRecorder) InTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockDB)(nil).InTx), ctx)
+}
+
 // Query mocks base method.
 func (m *MockDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
 	m.ctrl.T.Helper()
@@ -109,3 +123,17 @@ func (mr *MockDBMockRecorder) QueryRow(ctx, sql interface{}, args ...interface{}
 	varargs := append([]interface{}{ctx, sql}, args...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockDB)(nil).QueryRow), varargs...)
 }
+
+// WithTx mocks base method.
+func (m *MockDB) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockDBMockRecorder) WithTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockDB)(nil).WithTx), ctx, fn)
+}
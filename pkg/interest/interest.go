@@ -0,0 +1,222 @@
+package interest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+)
+
+// supported interest calculation methods
+const (
+	// MethodFlat charges interest on the original principal every period
+	MethodFlat Method = "flat"
+	// MethodEffective repays the principal in equal parts and charges interest on the declining balance
+	MethodEffective Method = "effective"
+	// MethodAnnuity charges interest on the declining balance with an equal amount every period
+	MethodAnnuity Method = "annuity"
+)
+
+// supported day count conventions
+const (
+	// DayCount30360 counts every monthly period as 30 days of a 360 days year
+	DayCount30360 DayCount = "30/360"
+	// DayCountActual365 counts the actual days of the period in a 365 days year
+	DayCountActual365 DayCount = "actual/365"
+	// DayCountActual360 counts the actual days of the period in a 360 days year
+	DayCountActual360 DayCount = "actual/360"
+)
+
+type (
+	// Method is the way interest of every period is charged
+	Method string
+
+	// DayCount is the convention of counting days of a period against a year
+	DayCount string
+
+	// Terms holds everything the interest of a loan is calculated from,
+	// rate is the annual interest rate in percent and tenor is the number of monthly periods.
+	// zero method and day count default to annuity and 30/360
+	Terms struct {
+		Principal currency.Amount
+		Rate      float64
+		Tenor     int
+		Method    Method
+		DayCount  DayCount
+		StartDate time.Time
+	}
+
+	// Period is a single monthly period of a schedule
+	Period struct {
+		Sequence    int
+		DueDate     time.Time
+		Principal   currency.Amount
+		Interest    currency.Amount
+		Amount      currency.Amount
+		Outstanding currency.Amount
+	}
+)
+
+// Schedule calculates every period of the terms,
+// the last period always settles the remaining balance so the principal adds up exactly
+func Schedule(terms Terms) ([]Period, error) {
+	terms = terms.withDefaults()
+	err := terms.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		schedule = make([]Period, 0, terms.Tenor)
+		balance  = terms.Principal
+		payment  = terms.payment()
+		lastDate = terms.StartDate
+	)
+
+	for sequence := 1; sequence <= terms.Tenor; sequence++ {
+		dueDate := addMonths(terms.StartDate, sequence)
+		days, basis := terms.DayCount.count(lastDate, dueDate)
+		lastDate = dueDate
+
+		var interest, principal currency.Amount
+		switch terms.Method {
+		case MethodFlat:
+			interest = terms.Principal.PercentDiv(terms.Rate*float64(days), basis)
+			principal = terms.Principal.MulDiv(1, int64(terms.Tenor))
+		case MethodEffective:
+			interest = balance.PercentDiv(terms.Rate*float64(days), basis)
+			principal = terms.Principal.MulDiv(1, int64(terms.Tenor))
+		case MethodAnnuity:
+			interest = balance.PercentDiv(terms.Rate*float64(days), basis)
+			principal = payment - interest
+			// a long period counted on actual days may charge more interest than the payment covers,
+			// no principal is repaid on such period and the remainder is settled in the last period
+			if principal < 0 {
+				principal = 0
+			}
+		}
+		if sequence == terms.Tenor || principal > balance {
+			principal = balance
+		}
+		balance -= principal
+
+		schedule = append(schedule, Period{
+			Sequence:    sequence,
+			DueDate:     dueDate,
+			Principal:   principal,
+			Interest:    interest,
+			Amount:      principal + interest,
+			Outstanding: balance,
+		})
+	}
+
+	return schedule, nil
+}
+
+// TotalInterest calculates the interest charged over the whole tenor of the terms
+func TotalInterest(terms Terms) (currency.Amount, error) {
+	schedule, err := Schedule(terms)
+	if err != nil {
+		return 0, err
+	}
+
+	var total currency.Amount
+	for _, period := range schedule {
+		total += period.Interest
+	}
+
+	return total, nil
+}
+
+// IsValid returns whether the method is supported
+func (m Method) IsValid() bool {
+	switch m {
+	case MethodFlat, MethodEffective, MethodAnnuity:
+		return true
+	}
+
+	return false
+}
+
+// IsValid returns whether the day count convention is supported
+func (d DayCount) IsValid() bool {
+	switch d {
+	case DayCount30360, DayCountActual365, DayCountActual360:
+		return true
+	}
+
+	return false
+}
+
+// count returns the days of the period and the days of the year it is counted against,
+// periods are whole months so 30/360 counts every period as 30 days
+func (d DayCount) count(start, end time.Time) (int64, int64) {
+	switch d {
+	case DayCountActual365:
+		return actualDays(start, end), 365
+	case DayCountActual360:
+		return actualDays(start, end), 360
+	}
+
+	return 30, 360
+}
+
+func (terms Terms) withDefaults() Terms {
+	if terms.Method == "" {
+		terms.Method = MethodAnnuity
+	}
+	if terms.DayCount == "" {
+		terms.DayCount = DayCount30360
+	}
+
+	return terms
+}
+
+func (terms Terms) validate() error {
+	if terms.Principal <= 0 || terms.Rate < 0 || terms.Tenor <= 0 {
+		return errors.New("invalid interest terms")
+	}
+	if !terms.Method.IsValid() {
+		return fmt.Errorf("unsupported interest method: %q", terms.Method)
+	}
+	if !terms.DayCount.IsValid() {
+		return fmt.Errorf("unsupported day count convention: %q", terms.DayCount)
+	}
+	if terms.DayCount != DayCount30360 && terms.StartDate.IsZero() {
+		return fmt.Errorf("start date is required for %s day count convention", terms.DayCount)
+	}
+
+	return nil
+}
+
+// payment returns the equal periodic amount of an annuity based on the nominal monthly rate,
+// it is the only inexact calculation of a schedule
+func (terms Terms) payment() currency.Amount {
+	monthlyRate := terms.Rate / 100 / 12
+	if monthlyRate <= 0 {
+		return terms.Principal.MulDiv(1, int64(terms.Tenor))
+	}
+
+	return currency.FromFloat(terms.Principal.Float64() * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(terms.Tenor))))
+}
+
+// addMonths returns the date months after t, clamped to the last day of the target month
+// so a loan started on a month end is due on every month end instead of rolling over into the next month
+func addMonths(t time.Time, months int) time.Time {
+	var (
+		year, month, day = t.Date()
+		// day 0 of the month after the target month is the last day of the target month
+		lastDay = time.Date(year, month+time.Month(months)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	)
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(year, month+time.Month(months), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+func actualDays(start, end time.Time) int64 {
+	return int64(math.Round(end.Sub(start).Hours() / 24))
+}
@@ -0,0 +1,310 @@
+package interest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/currency"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	var (
+		startDate = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		principal = currency.FromMajor(3000000)
+	)
+	tests := []struct {
+		name    string
+		terms   Terms
+		want    []Period
+		wantErr bool
+	}{
+		{
+			name: "annuity on 30/360 by default",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+				Tenor:     3,
+			},
+			want: []Period{
+				{Sequence: 1, DueDate: time.Time{}.AddDate(0, 1, 0), Principal: 99006633, Interest: 3000000, Amount: 102006633, Outstanding: 200993367},
+				{Sequence: 2, DueDate: time.Time{}.AddDate(0, 2, 0), Principal: 99996699, Interest: 2009934, Amount: 102006633, Outstanding: 100996668},
+				{Sequence: 3, DueDate: time.Time{}.AddDate(0, 3, 0), Principal: 100996668, Interest: 1009967, Amount: 102006635, Outstanding: 0},
+			},
+		},
+		{
+			name: "flat on 30/360",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+				Tenor:     3,
+				Method:    MethodFlat,
+				DayCount:  DayCount30360,
+				StartDate: startDate,
+			},
+			want: []Period{
+				{Sequence: 1, DueDate: startDate.AddDate(0, 1, 0), Principal: 100000000, Interest: 3000000, Amount: 103000000, Outstanding: 200000000},
+				{Sequence: 2, DueDate: startDate.AddDate(0, 2, 0), Principal: 100000000, Interest: 3000000, Amount: 103000000, Outstanding: 100000000},
+				{Sequence: 3, DueDate: startDate.AddDate(0, 3, 0), Principal: 100000000, Interest: 3000000, Amount: 103000000, Outstanding: 0},
+			},
+		},
+		{
+			name: "effective on 30/360",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+				Tenor:     3,
+				Method:    MethodEffective,
+				StartDate: startDate,
+			},
+			want: []Period{
+				{Sequence: 1, DueDate: startDate.AddDate(0, 1, 0), Principal: 100000000, Interest: 3000000, Amount: 103000000, Outstanding: 200000000},
+				{Sequence: 2, DueDate: startDate.AddDate(0, 2, 0), Principal: 100000000, Interest: 2000000, Amount: 102000000, Outstanding: 100000000},
+				{Sequence: 3, DueDate: startDate.AddDate(0, 3, 0), Principal: 100000000, Interest: 1000000, Amount: 101000000, Outstanding: 0},
+			},
+		},
+		{
+			name: "annuity on actual/365",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+				Tenor:     3,
+				Method:    MethodAnnuity,
+				DayCount:  DayCountActual365,
+				StartDate: startDate,
+			},
+			want: []Period{
+				{Sequence: 1, DueDate: startDate.AddDate(0, 1, 0), Principal: 98949099, Interest: 3057534, Amount: 102006633, Outstanding: 201050901},
+				{Sequence: 2, DueDate: startDate.AddDate(0, 2, 0), Principal: 100089764, Interest: 1916869, Amount: 102006633, Outstanding: 100961137},
+				{Sequence: 3, DueDate: startDate.AddDate(0, 3, 0), Principal: 100961137, Interest: 1028974, Amount: 101990111, Outstanding: 0},
+			},
+		},
+		{
+			name: "rounding residual settled in the last period",
+			terms: Terms{
+				Principal: currency.FromMajor(1000000),
+				Rate:      0,
+				Tenor:     3,
+				StartDate: startDate,
+			},
+			want: []Period{
+				{Sequence: 1, DueDate: startDate.AddDate(0, 1, 0), Principal: 33333333, Amount: 33333333, Outstanding: 66666667},
+				{Sequence: 2, DueDate: startDate.AddDate(0, 2, 0), Principal: 33333333, Amount: 33333333, Outstanding: 33333334},
+				{Sequence: 3, DueDate: startDate.AddDate(0, 3, 0), Principal: 33333334, Amount: 33333334, Outstanding: 0},
+			},
+		},
+		{
+			name: "invalid principal",
+			terms: Terms{
+				Rate:  12,
+				Tenor: 3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid rate",
+			terms: Terms{
+				Principal: principal,
+				Rate:      -1,
+				Tenor:     3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid tenor",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported method",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+				Tenor:     3,
+				Method:    "balloon",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported day count",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+				Tenor:     3,
+				DayCount:  "actual/actual",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing start date of actual day count",
+			terms: Terms{
+				Principal: principal,
+				Rate:      12,
+				Tenor:     3,
+				DayCount:  DayCountActual360,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Schedule(tt.terms)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Schedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchedule_AnnuityOnActualDays(t *testing.T) {
+	tests := []struct {
+		name  string
+		terms Terms
+	}{
+		{
+			name: "actual/360 with interest above the payment on long periods",
+			terms: Terms{
+				Principal: currency.FromMajor(10000000),
+				Rate:      24,
+				Tenor:     240,
+				Method:    MethodAnnuity,
+				DayCount:  DayCountActual360,
+				StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "actual/365",
+			terms: Terms{
+				Principal: currency.FromMajor(10000000),
+				Rate:      24,
+				Tenor:     240,
+				Method:    MethodAnnuity,
+				DayCount:  DayCountActual365,
+				StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Schedule(tt.terms)
+			assert.NoError(t, err)
+			assert.Len(t, got, tt.terms.Tenor)
+
+			var total currency.Amount
+			for _, period := range got {
+				assert.GreaterOrEqual(t, period.Principal, currency.Amount(0), "principal of period %d", period.Sequence)
+				assert.GreaterOrEqual(t, period.Outstanding, currency.Amount(0), "outstanding of period %d", period.Sequence)
+				total += period.Principal
+			}
+			assert.Equal(t, tt.terms.Principal, total)
+			assert.Equal(t, currency.Amount(0), got[len(got)-1].Outstanding)
+		})
+	}
+}
+
+func TestSchedule_MonthEnd(t *testing.T) {
+	startDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	wantDueDates := []time.Time{
+		time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name     string
+		dayCount DayCount
+		wantDays []int64
+	}{
+		{
+			name:     "30/360",
+			dayCount: DayCount30360,
+			wantDays: []int64{30, 30, 30, 30},
+		},
+		{
+			name:     "actual/365",
+			dayCount: DayCountActual365,
+			wantDays: []int64{28, 31, 30, 31},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := Terms{
+				Principal: currency.FromMajor(4000000),
+				Rate:      12,
+				Tenor:     4,
+				Method:    MethodEffective,
+				DayCount:  tt.dayCount,
+				StartDate: startDate,
+			}
+			got, err := Schedule(terms)
+			assert.NoError(t, err)
+
+			var (
+				lastDate = startDate
+				balance  = terms.Principal
+			)
+			for idx, period := range got {
+				assert.Equal(t, wantDueDates[idx], period.DueDate)
+
+				days, basis := tt.dayCount.count(lastDate, period.DueDate)
+				assert.Equal(t, tt.wantDays[idx], days)
+				assert.Equal(t, balance.PercentDiv(terms.Rate*float64(days), basis), period.Interest)
+
+				lastDate = period.DueDate
+				balance = period.Outstanding
+			}
+		})
+	}
+}
+
+func TestTotalInterest(t *testing.T) {
+	tests := []struct {
+		name    string
+		terms   Terms
+		want    currency.Amount
+		wantErr bool
+	}{
+		{
+			name: "annuity on 30/360",
+			terms: Terms{
+				Principal: currency.FromMajor(3000000),
+				Rate:      12,
+				Tenor:     3,
+			},
+			want: 6019901,
+		},
+		{
+			name: "flat on 30/360",
+			terms: Terms{
+				Principal: currency.FromMajor(3000000),
+				Rate:      12,
+				Tenor:     3,
+				Method:    MethodFlat,
+			},
+			want: 9000000,
+		},
+		{
+			name: "invalid terms",
+			terms: Terms{
+				Rate:  12,
+				Tenor: 3,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TotalInterest(tt.terms)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TotalInterest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+// NewAdvisoryLockImpl returns a lock backed by postgres transaction-level advisory locks,
+// so the lock is shared by every instance connected to the same database.
+// The lock is taken on the transaction carried by the context and is held until that transaction ends,
+// so it never holds a connection of its own.
+func NewAdvisoryLockImpl(db database.DB, timeout time.Duration) Lock {
+	return &advisoryLockImpl{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (a *advisoryLockImpl) Lock(ctx context.Context, key string) error {
+	if !a.db.InTx(ctx) {
+		return errNoTx(key)
+	}
+
+	// cancelling the context cancels the waiting query as well
+	lockCtx, cancel := withTimeout(ctx, a.timeout)
+	defer cancel()
+
+	_, err := a.db.Exec(lockCtx, "SELECT pg_advisory_xact_lock($1)", getLockID(key))
+	if err != nil {
+		return errLockNotAcquired(key, err)
+	}
+
+	return nil
+}
+
+func (a *advisoryLockImpl) TryLock(ctx context.Context, key string) (bool, error) {
+	if !a.db.InTx(ctx) {
+		return false, errNoTx(key)
+	}
+
+	var acquired bool
+	err := a.db.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", getLockID(key)).Scan(&acquired)
+	if err != nil {
+		return false, errLockNotAcquired(key, err)
+	}
+
+	return acquired, nil
+}
+
+// Unlock is a no-op, the advisory lock is released once the transaction holding it ends
+func (a *advisoryLockImpl) Unlock(ctx context.Context, key string) error {
+	return nil
+}
+
+// getLockID maps the key into the 64-bit key space of postgres advisory locks
+func getLockID(key string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+
+	return int64(hash.Sum64())
+}
+
+func errNoTx(key string) error {
+	return errorwrapper.E(fmt.Sprintf("lock %s must be acquired within a transaction", key), errorwrapper.CodeInternal)
+}
@@ -0,0 +1,162 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAdvisoryLockImpl(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := database.NewMockDB(ctrl)
+	got := NewAdvisoryLockImpl(db, time.Second)
+	assert.Equal(t, &advisoryLockImpl{
+		db:      db,
+		timeout: time.Second,
+	}, got)
+}
+
+func TestAdvisoryLockImpl_Lock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name    string
+		db      database.DB
+		wantErr bool
+	}{
+		{
+			name: "success",
+			db: func() *database.MockDB {
+				mock := database.NewMockDB(ctrl)
+				mock.EXPECT().
+					InTx(gomock.Any()).
+					Return(true)
+				mock.EXPECT().
+					Exec(gomock.Any(), "SELECT pg_advisory_xact_lock($1)", getLockID("loan:1")).
+					Return(pgconn.NewCommandTag("SELECT 1"), nil)
+
+				return mock
+			}(),
+		},
+		{
+			name: "outside of transaction",
+			db: func() *database.MockDB {
+				mock := database.NewMockDB(ctrl)
+				mock.EXPECT().
+					InTx(gomock.Any()).
+					Return(false)
+
+				return mock
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "error acquiring lock",
+			db: func() *database.MockDB {
+				mock := database.NewMockDB(ctrl)
+				mock.EXPECT().
+					InTx(gomock.Any()).
+					Return(true)
+				mock.EXPECT().
+					Exec(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(pgconn.CommandTag{}, context.DeadlineExceeded)
+
+				return mock
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &advisoryLockImpl{
+				db:      tt.db,
+				timeout: time.Second,
+			}
+			if err := a.Lock(context.Background(), "loan:1"); (err != nil) != tt.wantErr {
+				t.Errorf("advisoryLockImpl.Lock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdvisoryLockImpl_TryLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuery := func(row *database.MockPgxRow) *database.MockDB {
+		mock := database.NewMockDB(ctrl)
+		mock.EXPECT().
+			InTx(gomock.Any()).
+			Return(true)
+		mock.EXPECT().
+			QueryRow(gomock.Any(), "SELECT pg_try_advisory_xact_lock($1)", getLockID("loan:1")).
+			Return(row)
+
+		return mock
+	}
+	tests := []struct {
+		name    string
+		db      database.DB
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "acquired",
+			db:   mockQuery(database.NewMockPgxRow([]string{"pg_try_advisory_xact_lock"}, []interface{}{true})),
+			want: true,
+		},
+		{
+			name: "not acquired",
+			db:   mockQuery(database.NewMockPgxRow([]string{"pg_try_advisory_xact_lock"}, []interface{}{false})),
+			want: false,
+		},
+		{
+			name: "outside of transaction",
+			db: func() *database.MockDB {
+				mock := database.NewMockDB(ctrl)
+				mock.EXPECT().
+					InTx(gomock.Any()).
+					Return(false)
+
+				return mock
+			}(),
+			wantErr: true,
+		},
+		{
+			name:    "error acquiring lock",
+			db:      mockQuery(database.NewMockPgxRow([]string{}, []interface{}{})),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &advisoryLockImpl{
+				db: tt.db,
+			}
+			got, err := a.TryLock(context.Background(), "loan:1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("advisoryLockImpl.TryLock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAdvisoryLockImpl_Unlock(t *testing.T) {
+	a := &advisoryLockImpl{}
+	assert.NoError(t, a.Unlock(context.Background(), "loan:1"))
+}
+
+func TestGetLockID(t *testing.T) {
+	assert.Equal(t, getLockID("loan:1"), getLockID("loan:1"))
+	assert.NotEqual(t, getLockID("loan:1"), getLockID("loan:2"))
+}
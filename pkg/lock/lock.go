@@ -1,34 +1,139 @@
 package lock
 
 import (
-	"sync"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/ecintiawan/loan-service/pkg/database"
+	"github.com/ecintiawan/loan-service/pkg/errorwrapper"
+)
+
+const (
+	DriverLocal    = "local"
+	DriverPostgres = "postgres"
 )
 
-func NewLockImpl() Lock {
+// NewLock returns the lock implementation selected by the configured driver,
+// the postgres driver is required whenever the service runs on more than one instance
+func NewLock(cfg *config.Config, db database.DB) Lock {
+	var timeout time.Duration
+	if cfg.Vendor.Lock.Timeout != "" {
+		val, err := time.ParseDuration(cfg.Vendor.Lock.Timeout)
+		if err != nil || val < 0 {
+			log.Fatalf("invalid lock timeout: %q", cfg.Vendor.Lock.Timeout)
+		}
+		timeout = val
+	}
+
+	switch cfg.Vendor.Lock.Driver {
+	case "", DriverLocal:
+		return NewLockImpl(timeout)
+	case DriverPostgres:
+		return NewAdvisoryLockImpl(db, timeout)
+	}
+
+	log.Fatalf("unknown lock driver: %q", cfg.Vendor.Lock.Driver)
+	return nil
+}
+
+// NewLockImpl returns a process-local lock, it only guards against concurrent callers within the same instance
+func NewLockImpl(timeout time.Duration) Lock {
 	return &lockImpl{
-		locks: make(map[string]*sync.Mutex),
+		locks:   make(map[string]*localLock),
+		timeout: timeout,
 	}
 }
 
-func (l *lockImpl) Lock(key string) {
-	l.getLockByKey(key).Lock()
+func (l *lockImpl) Lock(ctx context.Context, key string) error {
+	ctx, cancel := withTimeout(ctx, l.timeout)
+	defer cancel()
+
+	lock := l.acquire(key)
+	select {
+	case lock.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		l.release(key)
+		return errLockNotAcquired(key, ctx.Err())
+	}
 }
 
-func (l *lockImpl) Unlock(key string) {
-	l.getLockByKey(key).Unlock()
+func (l *lockImpl) TryLock(ctx context.Context, key string) (bool, error) {
+	lock := l.acquire(key)
+	select {
+	case lock.sem <- struct{}{}:
+		return true, nil
+	default:
+		l.release(key)
+		return false, nil
+	}
 }
 
-func (l *lockImpl) getLockByKey(key string) *sync.Mutex {
+func (l *lockImpl) Unlock(ctx context.Context, key string) error {
+	l.mapLock.Lock()
+	lock, found := l.locks[key]
+	l.mapLock.Unlock()
+	if !found {
+		return errLockNotHeld(key)
+	}
+
+	select {
+	case <-lock.sem:
+	default:
+		return errLockNotHeld(key)
+	}
+	l.release(key)
+
+	return nil
+}
+
+// acquire returns the lock of the key and registers the caller as one of its users
+func (l *lockImpl) acquire(key string) *localLock {
 	l.mapLock.Lock()
 	defer l.mapLock.Unlock()
 
 	lock, found := l.locks[key]
-	if found {
-		return lock
+	if !found {
+		lock = &localLock{
+			sem: make(chan struct{}, 1),
+		}
+		l.locks[key] = lock
 	}
-
-	lock = &sync.Mutex{}
-	l.locks[key] = lock
+	lock.refs++
 
 	return lock
 }
+
+// release unregisters the caller from the lock of the key and evicts the key once it is unused
+func (l *lockImpl) release(key string) {
+	l.mapLock.Lock()
+	defer l.mapLock.Unlock()
+
+	lock, found := l.locks[key]
+	if !found {
+		return
+	}
+	lock.refs--
+	if lock.refs <= 0 {
+		delete(l.locks, key)
+	}
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func errLockNotAcquired(key string, err error) error {
+	return errorwrapper.E(fmt.Errorf("failed to acquire lock %s: %w", key, err), errorwrapper.CodeInternal)
+}
+
+func errLockNotHeld(key string) error {
+	return errorwrapper.E(fmt.Sprintf("lock %s is not held", key), errorwrapper.CodeInternal)
+}
@@ -0,0 +1,211 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLock(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.LockConfig
+		want Lock
+	}{
+		{
+			name: "default driver",
+			cfg:  config.LockConfig{},
+			want: &lockImpl{
+				locks: map[string]*localLock{},
+			},
+		},
+		{
+			name: "local driver",
+			cfg: config.LockConfig{
+				Driver:  DriverLocal,
+				Timeout: "3s",
+			},
+			want: &lockImpl{
+				locks:   map[string]*localLock{},
+				timeout: 3 * time.Second,
+			},
+		},
+		{
+			name: "postgres driver",
+			cfg: config.LockConfig{
+				Driver:  DriverPostgres,
+				Timeout: "5s",
+			},
+			want: &advisoryLockImpl{
+				timeout: 5 * time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Vendor.Lock = tt.cfg
+
+			got := NewLock(cfg, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLockImpl_Lock(t *testing.T) {
+	tests := []struct {
+		name    string
+		held    []string
+		ctx     func() (context.Context, context.CancelFunc)
+		key     string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.Background(), func() {}
+			},
+			key: "loan:1",
+		},
+		{
+			name: "success with other key held",
+			held: []string{"loan:2"},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.Background(), func() {}
+			},
+			key: "loan:1",
+		},
+		{
+			name: "timeout on held key",
+			held: []string{"loan:1"},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.Background(), func() {}
+			},
+			key:     "loan:1",
+			wantErr: true,
+		},
+		{
+			name: "context done on held key",
+			held: []string{"loan:1"},
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			key:     "loan:1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLockImpl(10 * time.Millisecond).(*lockImpl)
+			for _, key := range tt.held {
+				assert.NoError(t, l.Lock(context.Background(), key))
+			}
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			err := l.Lock(ctx, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("lockImpl.Lock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// a failed acquisition must not leave the caller registered on the key
+			if tt.wantErr {
+				assert.Equal(t, 1, l.locks[tt.key].refs)
+			}
+		})
+	}
+}
+
+func TestLockImpl_Lock_Wait(t *testing.T) {
+	l := NewLockImpl(0)
+	assert.NoError(t, l.Lock(context.Background(), "loan:1"))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- l.Lock(context.Background(), "loan:1")
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("lockImpl.Lock() acquired a held key")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	assert.NoError(t, l.Unlock(context.Background(), "loan:1"))
+	assert.NoError(t, <-acquired)
+}
+
+func TestLockImpl_TryLock(t *testing.T) {
+	tests := []struct {
+		name string
+		held []string
+		key  string
+		want bool
+	}{
+		{
+			name: "acquired",
+			key:  "loan:1",
+			want: true,
+		},
+		{
+			name: "not acquired on held key",
+			held: []string{"loan:1"},
+			key:  "loan:1",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLockImpl(0).(*lockImpl)
+			for _, key := range tt.held {
+				assert.NoError(t, l.Lock(context.Background(), key))
+			}
+
+			got, err := l.TryLock(context.Background(), tt.key)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, 1, l.locks[tt.key].refs)
+		})
+	}
+}
+
+func TestLockImpl_Unlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		held    []string
+		key     string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			held: []string{"loan:1"},
+			key:  "loan:1",
+		},
+		{
+			name:    "key is not held",
+			held:    []string{"loan:2"},
+			key:     "loan:1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLockImpl(0).(*lockImpl)
+			for _, key := range tt.held {
+				assert.NoError(t, l.Lock(context.Background(), key))
+			}
+
+			err := l.Unlock(context.Background(), tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("lockImpl.Unlock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// the key is evicted once nobody uses it anymore
+			assert.NotContains(t, l.locks, tt.key)
+		})
+	}
+}
@@ -1,17 +1,40 @@
 package lock
 
 import (
+	"context"
 	"sync"
+	"time"
+
+	"github.com/ecintiawan/loan-service/pkg/database"
 )
 
 type (
+	// Lock must be acquired within the transaction it guards,
+	// a database backed lock is held by that transaction and released once it ends
 	Lock interface {
-		Lock(key string)
-		Unlock(key string)
+		// Lock blocks until the lock of the key is acquired, the context is done or the timeout is reached
+		Lock(ctx context.Context, key string) error
+		// TryLock acquires the lock of the key without waiting and reports whether it is acquired
+		TryLock(ctx context.Context, key string) (bool, error)
+		// Unlock releases the lock of the key
+		Unlock(ctx context.Context, key string) error
 	}
 
 	lockImpl struct {
-		locks   map[string]*sync.Mutex
+		locks   map[string]*localLock
 		mapLock sync.Mutex
+		timeout time.Duration
+	}
+
+	// localLock is a single key lock, refs counts its holders and waiters
+	// so the key can be evicted once nobody uses it anymore
+	localLock struct {
+		sem  chan struct{}
+		refs int
+	}
+
+	advisoryLockImpl struct {
+		db      database.DB
+		timeout time.Duration
 	}
 )
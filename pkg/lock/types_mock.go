@@ -5,6 +5,7 @@
 package lock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,25 +35,44 @@ func (m *MockLock) EXPECT() *MockLockMockRecorder {
 }
 
 // Lock mocks base method.
-func (m *MockLock) Lock(key string) {
+func (m *MockLock) Lock(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Lock", key)
+	ret := m.ctrl.Call(m, "Lock", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Lock indicates an expected call of Lock.
-func (mr *MockLockMockRecorder) Lock(key interface{}) *gomock.Call {
+func (mr *MockLockMockRecorder) Lock(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLock)(nil).Lock), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLock)(nil).Lock), ctx, key)
+}
+
+// TryLock mocks base method.
+func (m *MockLock) TryLock(ctx context.Context, key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockLockMockRecorder) TryLock(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockLock)(nil).TryLock), ctx, key)
 }
 
 // Unlock mocks base method.
-func (m *MockLock) Unlock(key string) {
+func (m *MockLock) Unlock(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Unlock", key)
+	ret := m.ctrl.Call(m, "Unlock", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Unlock indicates an expected call of Unlock.
-func (mr *MockLockMockRecorder) Unlock(key interface{}) *gomock.Call {
+func (mr *MockLockMockRecorder) Unlock(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLock)(nil).Unlock), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLock)(nil).Unlock), ctx, key)
 }
@@ -1,33 +1,211 @@
 CREATE TABLE IF NOT EXISTS loan (
     id SERIAL PRIMARY KEY,
     borrower_id BIGINT NOT NULL,
-    amount FLOAT NOT NULL,
+    product_id BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    amount NUMERIC(20,2) NOT NULL,
     rate FLOAT NOT NULL,
+    tenor INT NOT NULL,
     approval_proof_url VARCHAR NOT NULL,
     agreement_letter_url VARCHAR NOT NULL,
     status INT NOT NULL,
     created_by BIGINT NOT NULL,
     approved_by BIGINT NOT NULL,
     disbursed_by BIGINT NOT NULL,
+    rejected_by BIGINT NOT NULL DEFAULT 0,
+    cancelled_by BIGINT NOT NULL DEFAULT 0,
+    reason VARCHAR NOT NULL DEFAULT '',
+    days_past_due INT NOT NULL DEFAULT 0,
+    admin_fee NUMERIC(20,2) NOT NULL DEFAULT 0,
+    provision_fee NUMERIC(20,2) NOT NULL DEFAULT 0,
+    origination_fee NUMERIC(20,2) NOT NULL DEFAULT 0,
+    disbursed_amount NUMERIC(20,2) NOT NULL DEFAULT 0,
+    funding_deadline TIMESTAMP,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP,
     approved_at TIMESTAMP,
     invested_at TIMESTAMP,
-    disbursed_at TIMESTAMP
+    disbursed_at TIMESTAMP,
+    rejected_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    repaid_at TIMESTAMP,
+    defaulted_at TIMESTAMP,
+    expired_at TIMESTAMP
 );
 CREATE INDEX idx_loan_status ON loan(status);
 
+CREATE TABLE IF NOT EXISTS loan_product (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    min_amount NUMERIC(20,2) NOT NULL,
+    max_amount NUMERIC(20,2) NOT NULL,
+    min_rate FLOAT NOT NULL,
+    max_rate FLOAT NOT NULL,
+    tenor_options INT[] NOT NULL,
+    admin_fee NUMERIC(20,2) NOT NULL DEFAULT 0,
+    provision_fee FLOAT NOT NULL DEFAULT 0,
+    status INT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS loan_state_history (
+    id SERIAL PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    from_status INT NOT NULL,
+    to_status INT NOT NULL,
+    action INT NOT NULL,
+    actor_id BIGINT NOT NULL DEFAULT 0,
+    request_id VARCHAR NOT NULL DEFAULT '',
+    ip_address VARCHAR NOT NULL DEFAULT '',
+    user_agent VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_loan_state_history_loan_id ON loan_state_history(loan_id);
+
 CREATE TABLE IF NOT EXISTS investment (
     id SERIAL PRIMARY KEY,
     investor_id BIGINT NOT NULL,
     loan_id BIGINT NOT NULL,
-    amount FLOAT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    amount NUMERIC(20,2) NOT NULL,
     roi FLOAT NOT NULL,
+    expected_return NUMERIC(20,2) NOT NULL DEFAULT 0,
+    service_fee NUMERIC(20,2) NOT NULL DEFAULT 0,
+    tax NUMERIC(20,2) NOT NULL DEFAULT 0,
     status INT NOT NULL,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP
 );
 CREATE INDEX idx_investment_loan_id ON investment(loan_id);
+CREATE INDEX idx_investment_investor_id_currency_status ON investment(investor_id, currency, status);
+
+CREATE TABLE IF NOT EXISTS installment (
+    id SERIAL PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    sequence INT NOT NULL,
+    due_date DATE NOT NULL,
+    principal NUMERIC(20,2) NOT NULL,
+    interest NUMERIC(20,2) NOT NULL,
+    penalty NUMERIC(20,2) NOT NULL DEFAULT 0,
+    amount NUMERIC(20,2) NOT NULL,
+    outstanding NUMERIC(20,2) NOT NULL,
+    principal_paid NUMERIC(20,2) NOT NULL DEFAULT 0,
+    interest_paid NUMERIC(20,2) NOT NULL DEFAULT 0,
+    penalty_paid NUMERIC(20,2) NOT NULL DEFAULT 0,
+    status INT NOT NULL,
+    paid_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_installment_loan_id_sequence ON installment(loan_id, sequence);
+
+CREATE TABLE IF NOT EXISTS repayment (
+    id SERIAL PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    amount NUMERIC(20,2) NOT NULL,
+    principal NUMERIC(20,2) NOT NULL,
+    interest NUMERIC(20,2) NOT NULL,
+    penalty NUMERIC(20,2) NOT NULL,
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE INDEX idx_repayment_loan_id ON repayment(loan_id);
+
+CREATE TABLE IF NOT EXISTS payout (
+    id SERIAL PRIMARY KEY,
+    repayment_id BIGINT NOT NULL,
+    investment_id BIGINT NOT NULL,
+    investor_id BIGINT NOT NULL,
+    loan_id BIGINT NOT NULL,
+    principal NUMERIC(20,2) NOT NULL,
+    interest NUMERIC(20,2) NOT NULL,
+    penalty NUMERIC(20,2) NOT NULL,
+    amount NUMERIC(20,2) NOT NULL,
+    service_fee NUMERIC(20,2) NOT NULL DEFAULT 0,
+    tax NUMERIC(20,2) NOT NULL DEFAULT 0,
+    net_amount NUMERIC(20,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_payout_repayment_id_investment_id ON payout(repayment_id, investment_id);
+CREATE INDEX idx_payout_investment_id ON payout(investment_id);
+CREATE INDEX idx_payout_investor_id ON payout(investor_id);
+
+CREATE TABLE IF NOT EXISTS wallet (
+    id SERIAL PRIMARY KEY,
+    investor_id BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    available NUMERIC(20,2) NOT NULL DEFAULT 0,
+    held NUMERIC(20,2) NOT NULL DEFAULT 0,
+    committed NUMERIC(20,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_wallet_investor_id_currency ON wallet(investor_id, currency);
+
+CREATE TABLE IF NOT EXISTS wallet_transaction (
+    id SERIAL PRIMARY KEY,
+    wallet_id BIGINT NOT NULL,
+    loan_id BIGINT NOT NULL DEFAULT 0,
+    type INT NOT NULL,
+    amount NUMERIC(20,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX idx_wallet_transaction_wallet_id ON wallet_transaction(wallet_id);
+
+CREATE TABLE IF NOT EXISTS ledger_account (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    type INT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_ledger_account_code ON ledger_account(code);
+
+CREATE TABLE IF NOT EXISTS ledger_journal (
+    id SERIAL PRIMARY KEY,
+    reference VARCHAR,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    loan_id BIGINT NOT NULL DEFAULT 0,
+    investor_id BIGINT NOT NULL DEFAULT 0,
+    description VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE UNIQUE INDEX idx_ledger_journal_reference ON ledger_journal(reference);
+CREATE INDEX idx_ledger_journal_currency_created_at ON ledger_journal(currency, created_at);
+
+CREATE TABLE IF NOT EXISTS ledger_entry (
+    id SERIAL PRIMARY KEY,
+    journal_id BIGINT NOT NULL,
+    account_code VARCHAR NOT NULL,
+    debit NUMERIC(20,2) NOT NULL DEFAULT 0,
+    credit NUMERIC(20,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    CHECK (debit >= 0 AND credit >= 0 AND (debit = 0) <> (credit = 0))
+);
+CREATE INDEX idx_ledger_entry_journal_id ON ledger_entry(journal_id);
+CREATE INDEX idx_ledger_entry_account_code ON ledger_entry(account_code);
+
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id SERIAL PRIMARY KEY,
+    recipients VARCHAR[] NOT NULL,
+    subject VARCHAR NOT NULL,
+    body TEXT NOT NULL,
+    attachment_name VARCHAR,
+    attachment BYTEA,
+    status INT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE INDEX idx_notification_outbox_status_next_attempt_at ON notification_outbox(status, next_attempt_at);
 
 CREATE TABLE IF NOT EXISTS employee (
     id SERIAL PRIMARY KEY,
@@ -45,6 +223,7 @@ CREATE TABLE IF NOT EXISTS borrower (
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP
 );
+CREATE UNIQUE INDEX idx_borrower_identification_number ON borrower(identification_number);
 
 CREATE TABLE IF NOT EXISTS investor (
     id SERIAL PRIMARY KEY,
@@ -54,4 +233,6 @@ CREATE TABLE IF NOT EXISTS investor (
     status INT NOT NULL,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP
-);
\ No newline at end of file
+);
+CREATE UNIQUE INDEX idx_investor_identification_number ON investor(identification_number);
+CREATE UNIQUE INDEX idx_investor_email ON investor(LOWER(email));
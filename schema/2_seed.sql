@@ -1,15 +1,61 @@
-INSERT INTO loan(id, borrower_id, amount, rate, approval_proof_url, agreement_letter_url, status, created_by, approved_by, disbursed_by, created_at, updated_at, approved_at, invested_at, disbursed_at)
+INSERT INTO loan_product(id, name, min_amount, max_amount, min_rate, max_rate, tenor_options, admin_fee, provision_fee, status, created_at, updated_at)
 VALUES
-(1, 1, 1000000, 10, '', '', 1, 1, 0, 0, NOW(), NOW(), NULL, NULL, NULL),
-(2, 2, 3000000, 12, '', '', 1, 1, 0, 0, NOW(), NOW(), NULL, NULL, NULL),
-(3, 3, 350000000, 5, '', '', 1, 2, 0, 0, NOW(), NOW(), NULL, NULL, NULL),
-(4, 3, 350000000, 8, 'http://127.0.0.1/upload/proof_3.jpeg', '', 2, 2, 2, 0, NOW(), NOW(), NOW(), NULL, NULL);
+(1, 'Personal Loan', 1000000, 50000000, 8, 15, '{3, 6, 12}', 50000, 1, 1, NOW(), NOW()),
+(2, 'Business Loan', 50000000, 500000000, 4, 10, '{12, 24, 36}', 250000, 2, 1, NOW(), NOW());
+
+INSERT INTO loan(id, borrower_id, product_id, amount, rate, tenor, approval_proof_url, agreement_letter_url, status, created_by, approved_by, disbursed_by, created_at, updated_at, approved_at, invested_at, disbursed_at)
+VALUES
+(1, 1, 1, 1000000, 10, 6, '', '', 1, 1, 0, 0, NOW(), NOW(), NULL, NULL, NULL),
+(2, 2, 1, 3000000, 12, 12, '', '', 1, 1, 0, 0, NOW(), NOW(), NULL, NULL, NULL),
+(3, 3, 2, 350000000, 5, 24, '', '', 1, 2, 0, 0, NOW(), NOW(), NULL, NULL, NULL),
+(4, 3, 2, 350000000, 8, 12, 'http://127.0.0.1/upload/proof_3.jpeg', '', 2, 2, 2, 0, NOW(), NOW(), NOW(), NULL, NULL);
 
 INSERT INTO investment(id, investor_id, loan_id, amount, roi, status, created_at, updated_at)
 VALUES
 (1, 1, 4, 15000000, 1200000, 1, NOW(), NOW()),
 (2, 2, 4, 10000000, 800000, 1, NOW(), NOW());
 
+INSERT INTO wallet(id, investor_id, currency, available, held, committed, created_at, updated_at)
+VALUES
+(1, 1, 'IDR', 100000000, 15000000, 0, NOW(), NOW()),
+(2, 2, 'IDR', 100000000, 10000000, 0, NOW(), NOW());
+
+INSERT INTO wallet_transaction(id, wallet_id, loan_id, type, amount, created_at)
+VALUES
+(1, 1, 0, 1, 115000000, NOW()),
+(2, 1, 4, 2, 15000000, NOW()),
+(3, 2, 0, 1, 110000000, NOW()),
+(4, 2, 4, 2, 10000000, NOW());
+
+INSERT INTO ledger_account(id, code, name, type, created_at, updated_at)
+VALUES
+(1, 'bank', 'Bank', 1, NOW(), NOW()),
+(2, 'investor_wallet', 'Investor Wallet', 2, NOW(), NOW()),
+(3, 'loan_escrow', 'Loan Funding Escrow', 2, NOW(), NOW()),
+(4, 'origination_fee_revenue', 'Origination Fee Revenue', 3, NOW(), NOW()),
+(5, 'service_fee_revenue', 'Service Fee Revenue', 3, NOW(), NOW()),
+(6, 'withholding_tax_payable', 'Withholding Tax Payable', 2, NOW(), NOW()),
+(7, 'admin_fee_revenue', 'Admin Fee Revenue', 3, NOW(), NOW()),
+(8, 'provision_fee_revenue', 'Provision Fee Revenue', 3, NOW(), NOW());
+
+INSERT INTO ledger_journal(id, reference, currency, loan_id, investor_id, description, created_at)
+VALUES
+(1, NULL, 'IDR', 0, 1, 'investor deposit', NOW()),
+(2, NULL, 'IDR', 4, 1, 'loan funding', NOW()),
+(3, NULL, 'IDR', 0, 2, 'investor deposit', NOW()),
+(4, NULL, 'IDR', 4, 2, 'loan funding', NOW());
+
+INSERT INTO ledger_entry(id, journal_id, account_code, debit, credit, created_at)
+VALUES
+(1, 1, 'bank', 115000000, 0, NOW()),
+(2, 1, 'investor_wallet', 0, 115000000, NOW()),
+(3, 2, 'investor_wallet', 15000000, 0, NOW()),
+(4, 2, 'loan_escrow', 0, 15000000, NOW()),
+(5, 3, 'bank', 110000000, 0, NOW()),
+(6, 3, 'investor_wallet', 0, 110000000, NOW()),
+(7, 4, 'investor_wallet', 10000000, 0, NOW()),
+(8, 4, 'loan_escrow', 0, 10000000, NOW());
+
 INSERT INTO employee(id, name, status, created_at, updated_at)
 VALUES
 (1, 'Employee A', 1, NOW(), NOW()),
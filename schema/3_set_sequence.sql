@@ -2,10 +2,34 @@ SELECT SETVAL(
 	( SELECT PG_GET_SERIAL_SEQUENCE('loan', 'id') ),
 	( SELECT MAX(id) FROM public.loan )
 );
+SELECT SETVAL(
+	( SELECT PG_GET_SERIAL_SEQUENCE('loan_product', 'id') ),
+	( SELECT MAX(id) FROM public.loan_product )
+);
 SELECT SETVAL(
 	( SELECT PG_GET_SERIAL_SEQUENCE('investment', 'id') ),
 	( SELECT MAX(id) FROM public.investment )
 );
+SELECT SETVAL(
+	( SELECT PG_GET_SERIAL_SEQUENCE('wallet', 'id') ),
+	( SELECT MAX(id) FROM public.wallet )
+);
+SELECT SETVAL(
+	( SELECT PG_GET_SERIAL_SEQUENCE('wallet_transaction', 'id') ),
+	( SELECT MAX(id) FROM public.wallet_transaction )
+);
+SELECT SETVAL(
+	( SELECT PG_GET_SERIAL_SEQUENCE('ledger_account', 'id') ),
+	( SELECT MAX(id) FROM public.ledger_account )
+);
+SELECT SETVAL(
+	( SELECT PG_GET_SERIAL_SEQUENCE('ledger_journal', 'id') ),
+	( SELECT MAX(id) FROM public.ledger_journal )
+);
+SELECT SETVAL(
+	( SELECT PG_GET_SERIAL_SEQUENCE('ledger_entry', 'id') ),
+	( SELECT MAX(id) FROM public.ledger_entry )
+);
 SELECT SETVAL(
 	( SELECT PG_GET_SERIAL_SEQUENCE('employee', 'id') ),
 	( SELECT MAX(id) FROM public.employee )